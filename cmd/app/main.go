@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/GalushkoArt/simpleCache"
 	"github.com/galushkoart/finance-api/internal/config"
@@ -9,7 +10,9 @@ import (
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/internal/repository"
 	"github.com/galushkoart/finance-api/internal/service"
-	"github.com/galushkoart/finance-api/pkg/conpool"
+	"github.com/galushkoart/finance-api/pkg/apiclient"
+	"github.com/galushkoart/finance-api/pkg/provider"
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
 	pkg "github.com/galushkoart/finance-api/pkg/service"
 	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/goccy/go-json"
@@ -24,6 +27,7 @@ import (
 	"github.com/rs/zerolog/log"
 	"os"
 	"os/signal"
+	"strings"
 	"time"
 )
 
@@ -61,21 +65,28 @@ func main() {
 	}
 	symbolRepository := repository.NewSymbolRepository(db)
 	userRepository := repository.NewUserRepository(db)
+	exchangeRepository := repository.NewExchangeRepository(db)
+	calendarService := newCalendarService(repository.NewCalendarRepository(db))
 	twelveDataConf := config.Conf.API.TwelveData
-	twelveDataPool := conpool.NewTwelveDataPool(twelveDataConf.ApiKey, twelveDataConf.Host, twelveDataConf.Timeout, twelveDataConf.RateLimit, 1*time.Minute)
+	marketDataProvider, limitedProviders := newMarketDataProvider()
 	auditConf := config.Conf.Audit
 	auditClient, err := pkg.NewAuditClient(auditConf.GRPCEnabled, auditConf.GRPCAddress)
 	utils.PanicOnError(err)
 	auditPublisher := pkg.NewAuditPublisher(auditConf.QueueName)
 	closeMq := auditPublisher.InitPublishChannel(auditConf.MQEnabled, auditConf.MQUri)
 	auditService := service.NewAuditService(auditConf.GRPCEnabled, auditClient, auditConf.MQEnabled, auditPublisher)
-	symbolService := service.NewSymbolService(symbolRepository, twelveDataPool, auditService)
+	symbolService := service.NewSymbolService(symbolRepository, marketDataProvider, newSymbolSearcher(limitedProviders), auditService, twelveDataConf.HistoryDepth, config.Conf.API.LookupTimeout, newFreshnessPolicy(calendarService))
 	symbolCache := simpleCache.NewGenericConcurrentCache[model.Symbol](config.Conf.Cache.SymbolTTL)
+	refreshScheduler := service.NewRefreshScheduler(symbolRepository, symbolService, symbolCache, config.Conf.Refresh.Period)
+	if config.Conf.Refresh.Enabled {
+		refreshScheduler.Start()
+	}
 	hasher := service.NewHasher(dbConf.Salt)
 	jwtConf := config.Conf.JWT
 	jwtProducer := service.NewJwtProducer(jwtConf.HMACSecret, jwtConf.ExpiryTimeout)
 	jwtParser := service.NewJwtParser(jwtConf.HMACSecret)
-	authService := service.NewAuthService(userRepository, hasher, jwtProducer, time.Duration(jwtConf.RefreshTimeoutDays)*24*time.Hour, auditService)
+	authService := service.NewAuthService(userRepository, hasher, jwtProducer, time.Duration(jwtConf.RefreshTimeoutDays)*24*time.Hour, auditService,
+		newNotifier(), config.Conf.PasswordReset.TokenTTL, config.Conf.PasswordReset.URL)
 
 	app := fiber.New(fiber.Config{
 		JSONEncoder:  json.Marshal,
@@ -87,7 +98,10 @@ func main() {
 		AppName:      "Finance App " + config.Conf.Server.Environment,
 	})
 	app.Use(requestid.New())
-	httpHandler := handler.New(swagger.HandlerDefault, authService, symbolService, symbolCache, handler.RequestLogger(), handler.AuthMiddleware(jwtParser))
+	analyticsCache := simpleCache.NewGenericConcurrentCache[model.SymbolAnalytics](config.Conf.Cache.AnalyticsTTL)
+	correlationCache := simpleCache.NewGenericConcurrentCache[model.Correlation](config.Conf.Cache.AnalyticsTTL)
+	httpHandler := handler.New(swagger.HandlerDefault, authService, symbolService, service.NewIndicatorService(symbolService), symbolCache, service.NewExchangeService(exchangeRepository, auditService), calendarService,
+		service.NewAnalyticsService(symbolService), analyticsCache, correlationCache, service.NewConversionService(symbolService), service.NewUserService(userRepository, auditService), service.NewAccountService(userRepository, hasher, auditService), rateLimitReporters(limitedProviders), handler.RequestLogger(), handler.AuthMiddleware(jwtParser))
 	httpHandler.InitRoutes(app)
 
 	exit := make(chan os.Signal, 1)
@@ -109,6 +123,10 @@ func main() {
 	done := make(chan bool)
 
 	go func() {
+		refreshScheduler.Stop()
+		for _, limited := range limitedProviders {
+			limited.Stop()
+		}
 		utils.PanicOnError(auditClient.Close())
 		utils.PanicOnError(closeMq())
 		utils.PanicOnError(driver.Close())
@@ -124,6 +142,112 @@ func main() {
 	}
 }
 
+// newMarketDataProvider creates rate limited client for every configured provider.
+// Several providers are chained in the configured order with failover
+func newMarketDataProvider() (apiclient.MarketDataProvider, []*ratelimit.Provider) {
+	apiConf := config.Conf.API
+	if len(apiConf.Providers) == 0 {
+		log.Fatal().Msg("No market data provider configured")
+	}
+	limited := make([]*ratelimit.Provider, 0, len(apiConf.Providers))
+	providers := make([]apiclient.MarketDataProvider, 0, len(apiConf.Providers))
+	for _, name := range apiConf.Providers {
+		p := newRateLimitedProvider(strings.TrimSpace(name))
+		limited = append(limited, p)
+		providers = append(providers, p)
+	}
+	if len(providers) == 1 {
+		return providers[0], limited
+	}
+	return provider.NewFailoverChain(apiConf.Failover.FailureThreshold, apiConf.Failover.OpenTimeout, providers...), limited
+}
+
+func newRateLimitedProvider(name string) *ratelimit.Provider {
+	apiConf := config.Conf.API
+	switch name {
+	case apiclient.TwelveDataProvider:
+		conf := apiConf.TwelveData
+		apiKeys := conf.ApiKeys
+		if conf.ApiKey != "" {
+			apiKeys = append([]string{conf.ApiKey}, apiKeys...)
+		}
+		newClient := func(apiKey string) apiclient.MarketDataProvider {
+			return apiclient.NewTwelveDataClient(apiKey, conf.Host, conf.Timeout)
+		}
+		return ratelimit.NewProvider(apiKeys, newClient, apiclient.TwelveDataCredits, ratelimit.Budget{PerMinute: conf.RateLimit, PerDay: conf.DailyLimit}, conf.MaxQueue)
+	case apiclient.AlphaVantageProvider:
+		conf := apiConf.AlphaVantage
+		newClient := func(apiKey string) apiclient.MarketDataProvider {
+			return apiclient.NewAlphaVantageClient(apiKey, conf.Host, conf.Timeout)
+		}
+		return ratelimit.NewProvider([]string{conf.ApiKey}, newClient, apiclient.AlphaVantageCredits, ratelimit.Budget{PerMinute: conf.RateLimit, PerDay: conf.DailyLimit}, conf.MaxQueue)
+	}
+	log.Fatal().Msgf("Unknown market data provider %s", name)
+	return nil
+}
+
+// newNotifier returns SMTP notifier if it is configured and file notifier for local development otherwise
+func newNotifier() pkg.Notifier {
+	conf := config.Conf.Notifier
+	switch conf.Type {
+	case "smtp":
+		return pkg.NewSMTPNotifier(conf.SMTP.Host, conf.SMTP.Port, conf.SMTP.Username, conf.SMTP.Password, conf.SMTP.From, conf.SMTP.Timeout)
+	case "file":
+		log.Warn().Msgf("Notifications including password reset links are written to %s file", conf.Path)
+		return pkg.NewFileNotifier(conf.Path)
+	}
+	log.Fatal().Msgf("Unknown notifier type %s. Must be smtp or file", conf.Type)
+	return nil
+}
+
+// newSymbolSearcher returns rate limited TwelveData provider if search fallback is enabled and TwelveData is configured
+func newSymbolSearcher(limited []*ratelimit.Provider) apiclient.SymbolSearcher {
+	if !config.Conf.API.TwelveData.SearchFallback {
+		return nil
+	}
+	for _, p := range limited {
+		if p.Name() == apiclient.TwelveDataProvider {
+			return p
+		}
+	}
+	return nil
+}
+
+func rateLimitReporters(limited []*ratelimit.Provider) []ratelimit.Reporter {
+	result := make([]ratelimit.Reporter, 0, len(limited))
+	for _, p := range limited {
+		result = append(result, p)
+	}
+	return result
+}
+
+func newFreshnessPolicy(tradingDays service.TradingDays) *service.FreshnessPolicy {
+	freshnessConf := config.Conf.Freshness
+	if !freshnessConf.Enabled {
+		return nil
+	}
+	toSession := func(s config.Session) service.TradingSession {
+		return service.TradingSession{Timezone: s.Timezone, SessionClose: s.SessionClose, Weekends: s.Weekends}
+	}
+	return service.NewFreshnessPolicy(map[service.MarketType]service.TradingSession{
+		service.EquityMarket: toSession(freshnessConf.Equity),
+		service.FXMarket:     toSession(freshnessConf.FX),
+		service.CryptoMarket: toSession(freshnessConf.Crypto),
+	}, tradingDays)
+}
+
+func newCalendarService(repo repository.CalendarRepository) service.CalendarService {
+	bundled, err := service.LoadCalendars(config.Conf.Calendar.Path)
+	if err != nil {
+		log.Fatal().Err(err).Msgf("Couldn't load trading calendars from %s", config.Conf.Calendar.Path)
+	}
+	calendarService, err := service.NewCalendarService(context.Background(), repo, bundled)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Couldn't initialize trading calendars")
+	}
+	return calendarService
+}
+
 func closeDb(db *sqlx.DB) {
 	log.Info().Msg("Closing DB connection")
 	err := db.Close()
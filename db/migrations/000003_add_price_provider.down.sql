@@ -0,0 +1,2 @@
+ALTER TABLE PRICE
+    DROP COLUMN PROVIDER;
@@ -0,0 +1,2 @@
+ALTER TABLE PRICE
+    ADD COLUMN PROVIDER VARCHAR;
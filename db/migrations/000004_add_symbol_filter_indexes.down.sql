@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS EXCHANGE_COUNTRY_IDX;
+DROP INDEX IF EXISTS EXCHANGE_CODE_IDX;
+DROP INDEX IF EXISTS SYMBOL_NAME_IDX;
+DROP INDEX IF EXISTS SYMBOL_CURRENCY_IDX;
+DROP INDEX IF EXISTS SYMBOL_TYPE_IDX;
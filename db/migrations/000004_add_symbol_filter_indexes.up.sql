@@ -0,0 +1,5 @@
+CREATE INDEX SYMBOL_TYPE_IDX ON SYMBOL (TYPE);
+CREATE INDEX SYMBOL_CURRENCY_IDX ON SYMBOL (CURRENCY);
+CREATE INDEX SYMBOL_NAME_IDX ON SYMBOL (NAME);
+CREATE INDEX EXCHANGE_CODE_IDX ON EXCHANGE (CODE);
+CREATE INDEX EXCHANGE_COUNTRY_IDX ON EXCHANGE (COUNTRY);
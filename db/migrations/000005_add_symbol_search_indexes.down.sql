@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS SYMBOL_NAME_TRGM_IDX;
+DROP INDEX IF EXISTS SYMBOL_SYMBOL_TRGM_IDX;
@@ -0,0 +1,3 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX SYMBOL_SYMBOL_TRGM_IDX ON SYMBOL USING GIN (SYMBOL gin_trgm_ops);
+CREATE INDEX SYMBOL_NAME_TRGM_IDX ON SYMBOL USING GIN (NAME gin_trgm_ops);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS EXCHANGE_CODE_UNIQUE_IDX;
+CREATE INDEX EXCHANGE_CODE_IDX ON EXCHANGE (CODE);
@@ -0,0 +1,24 @@
+CREATE TEMPORARY TABLE EXCHANGE_DUPLICATE AS
+SELECT E.ID, MIN(E.ID) OVER (PARTITION BY E.CODE) AS SURVIVOR_ID
+FROM EXCHANGE E;
+DELETE FROM EXCHANGE_DUPLICATE WHERE ID = SURVIVOR_ID;
+
+UPDATE EXCHANGE E
+SET COUNTRY  = COALESCE(E.COUNTRY, D.COUNTRY),
+    TIMEZONE = COALESCE(E.TIMEZONE, D.TIMEZONE)
+FROM (SELECT ED.SURVIVOR_ID, MAX(X.COUNTRY) AS COUNTRY, MAX(X.TIMEZONE) AS TIMEZONE
+      FROM EXCHANGE_DUPLICATE ED
+               JOIN EXCHANGE X ON X.ID = ED.ID
+      GROUP BY ED.SURVIVOR_ID) D
+WHERE E.ID = D.SURVIVOR_ID;
+
+INSERT INTO SYMBOL_EXCHANGE (SYMBOL_ID, EXCHANGE_ID)
+SELECT SE.SYMBOL_ID, ED.SURVIVOR_ID
+FROM SYMBOL_EXCHANGE SE
+         JOIN EXCHANGE_DUPLICATE ED ON ED.ID = SE.EXCHANGE_ID
+ON CONFLICT DO NOTHING;
+DELETE FROM EXCHANGE WHERE ID IN (SELECT ID FROM EXCHANGE_DUPLICATE);
+DROP TABLE EXCHANGE_DUPLICATE;
+
+DROP INDEX IF EXISTS EXCHANGE_CODE_IDX;
+CREATE UNIQUE INDEX EXCHANGE_CODE_UNIQUE_IDX ON EXCHANGE (CODE);
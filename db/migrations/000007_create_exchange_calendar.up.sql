@@ -0,0 +1,6 @@
+CREATE TABLE EXCHANGE_CALENDAR
+(
+    CODE       VARCHAR PRIMARY KEY,
+    CALENDAR   JSONB     NOT NULL,
+    UPDATED_AT TIMESTAMP NOT NULL DEFAULT NOW()
+);
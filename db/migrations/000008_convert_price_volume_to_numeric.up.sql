@@ -0,0 +1,54 @@
+DROP VIEW IF EXISTS V_SYMBOL_INFO;
+DROP VIEW IF EXISTS V_LATEST_SYMBOL_INFO;
+
+ALTER TABLE PRICE
+    ALTER COLUMN VOLUME TYPE NUMERIC USING CASE
+        WHEN TRIM(VOLUME) ~ '^[+-]?([0-9]+(\.[0-9]*)?|\.[0-9]+)$' THEN TRIM(VOLUME)::NUMERIC
+        END;
+
+CREATE VIEW V_SYMBOL_INFO AS
+SELECT S.ID,
+       S.SYMBOL,
+       S.NAME,
+       S.TYPE,
+       S.CURRENCY,
+       S.CURRENCY_BASE,
+       S.CURRENCY_QUOTE,
+       P.INTERVAL,
+       P.DATE,
+       P.OPEN,
+       P.CLOSE,
+       P.HIGH,
+       P.LOW,
+       P.VOLUME
+FROM SYMBOL S
+         JOIN PRICE P ON S.ID = P.SYMBOL_ID
+ORDER BY S.SYMBOL;
+
+CREATE VIEW V_LATEST_SYMBOL_INFO AS
+SELECT S.ID,
+       S.SYMBOL,
+       S.NAME,
+       S.TYPE,
+       S.CURRENCY,
+       S.CURRENCY_BASE,
+       S.CURRENCY_QUOTE,
+       LP.DATE,
+       LP.OPEN,
+       LP.CLOSE,
+       LP.HIGH,
+       LP.LOW,
+       LP.VOLUME
+FROM SYMBOL S
+         JOIN (SELECT SYMBOL_ID,
+                      DATE,
+                      OPEN,
+                      CLOSE,
+                      HIGH,
+                      LOW,
+                      VOLUME,
+                      ROW_NUMBER() OVER (PARTITION BY SYMBOL_ID ORDER BY DATE DESC) AS RN
+               FROM PRICE
+               WHERE INTERVAL = '1day') LP ON LP.SYMBOL_ID = S.ID
+WHERE LP.RN = 1
+ORDER BY S.SYMBOL;
@@ -0,0 +1,3 @@
+UPDATE USER_ENTITY
+SET PASSWORD = SUBSTRING(PASSWORD FROM 9)
+WHERE PASSWORD LIKE '$sha256$%';
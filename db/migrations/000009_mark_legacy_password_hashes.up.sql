@@ -0,0 +1,3 @@
+UPDATE USER_ENTITY
+SET PASSWORD = '$sha256$' || PASSWORD
+WHERE PASSWORD NOT LIKE '$%';
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS USER_ROLES_USER_ID_ROLE_IDX;
+DROP TABLE IF EXISTS ROLE_PERMISSIONS;
@@ -0,0 +1,27 @@
+CREATE TABLE ROLE_PERMISSIONS
+(
+    ROLE       VARCHAR NOT NULL,
+    PERMISSION VARCHAR NOT NULL,
+    CREATED_AT TIMESTAMP DEFAULT NOW(),
+    PRIMARY KEY (ROLE, PERMISSION)
+);
+
+INSERT INTO ROLE_PERMISSIONS(ROLE, PERMISSION)
+VALUES ('Client', 'symbols:read'),
+       ('Client', 'exchanges:read'),
+       ('Client', 'analytics:read'),
+       ('FinanceAdminRole', 'symbols:read'),
+       ('FinanceAdminRole', 'symbols:write'),
+       ('FinanceAdminRole', 'exchanges:read'),
+       ('FinanceAdminRole', 'exchanges:write'),
+       ('FinanceAdminRole', 'analytics:read'),
+       ('FinanceAdminRole', 'providers:read'),
+       ('FinanceAdminRole', 'users:admin');
+
+DELETE
+FROM USER_ROLES UR
+    USING USER_ROLES DUPLICATE
+WHERE UR.USER_ID = DUPLICATE.USER_ID
+  AND UR.ROLE = DUPLICATE.ROLE
+  AND UR.ID > DUPLICATE.ID;
+CREATE UNIQUE INDEX USER_ROLES_USER_ID_ROLE_IDX ON USER_ROLES (USER_ID, ROLE);
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS REFRESH_TOKEN_USER_ID_IDX;
+
+ALTER TABLE USER_ENTITY
+    DROP COLUMN IF EXISTS DISABLED;
@@ -0,0 +1,4 @@
+ALTER TABLE USER_ENTITY
+    ADD COLUMN DISABLED BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX REFRESH_TOKEN_USER_ID_IDX ON REFRESH_TOKEN (USER_ID);
@@ -0,0 +1,11 @@
+CREATE TABLE PASSWORD_RESET_TOKEN
+(
+    ID         BIGSERIAL PRIMARY KEY,
+    USER_ID    UUID      NOT NULL REFERENCES USER_ENTITY ON DELETE CASCADE,
+    TOKEN_HASH VARCHAR   NOT NULL,
+    EXPIRES_AT TIMESTAMP NOT NULL,
+    USED_AT    TIMESTAMP,
+    CREATED_AT TIMESTAMP DEFAULT NOW()
+);
+CREATE UNIQUE INDEX PASSWORD_RESET_TOKEN_HASH_IDX ON PASSWORD_RESET_TOKEN (TOKEN_HASH);
+CREATE INDEX PASSWORD_RESET_TOKEN_USER_ID_IDX ON PASSWORD_RESET_TOKEN (USER_ID);
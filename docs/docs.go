@@ -16,32 +16,31 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/api/v1/symbols": {
+        "/api/v1/admin/rate-limits": {
             "get": {
                 "security": [
                     {
                         "ApiKeyAuth": [
-                            "client",
                             "admin"
                         ]
                     }
                 ],
-                "description": "Get all available latest symbols",
+                "description": "Get remaining credits per api key and queued, rejected and cancelled requests of every market data provider",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Symbols"
+                    "Admin"
                 ],
-                "summary": "GetSymbols",
-                "operationId": "get-symbols",
+                "summary": "GetRateLimits",
+                "operationId": "get-rate-limits",
                 "responses": {
                     "200": {
                         "description": "Successful response",
                         "schema": {
                             "type": "array",
                             "items": {
-                                "$ref": "#/definitions/model.Symbol"
+                                "$ref": "#/definitions/ratelimit.Stats"
                             }
                         }
                     },
@@ -50,16 +49,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
-                    },
-                    "404": {
-                        "description": "Data not found",
-                        "schema": {
-                            "$ref": "#/definitions/handler.CommonResponse"
-                        }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/api/v1/admin/users": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": [
@@ -67,38 +62,59 @@ const docTemplate = `{
                         ]
                     }
                 ],
-                "description": "Update symbol data",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get page of users ordered by username. Use next_cursor of the response as cursor to get the next page",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Symbols"
+                    "Users"
                 ],
-                "summary": "UpdateSymbols",
-                "operationId": "update-symbols",
+                "summary": "GetUsers",
+                "operationId": "get-users",
                 "parameters": [
                     {
-                        "description": "Update symbol data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateSymbol"
-                        }
+                        "type": "string",
+                        "description": "Part of username or email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Role of the user",
+                        "name": "role",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Whether the user is disabled",
+                        "name": "disabled",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Max number of users on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Add successfully",
+                        "description": "Successful response",
                         "schema": {
-                            "$ref": "#/definitions/handler.CommonResponse"
+                            "$ref": "#/definitions/model.UserPage"
                         }
                     },
                     "400": {
-                        "description": "Client request errors",
+                        "description": "Client request error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
@@ -110,54 +126,50 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal server errors",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/api/v1/admin/users/{id}": {
+            "get": {
                 "security": [
                     {
                         "ApiKeyAuth": [
-                            "client",
                             "admin"
                         ]
                     }
                 ],
-                "description": "Add new symbol data",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Get user with roles",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Symbols"
+                    "Users"
                 ],
-                "summary": "AddSymbols",
-                "operationId": "add-symbols",
+                "summary": "GetUser",
+                "operationId": "get-user",
                 "parameters": [
                     {
-                        "description": "New symbol data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.Symbol"
-                        }
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Add successfully",
+                        "description": "Successful response",
                         "schema": {
-                            "$ref": "#/definitions/handler.CommonResponse"
+                            "$ref": "#/definitions/model.User"
                         }
                     },
                     "400": {
-                        "description": "Client request errors",
+                        "description": "Client request error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
@@ -168,8 +180,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
                     },
+                    "404": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
                     "500": {
-                        "description": "Internal server errors",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
@@ -177,33 +195,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/api/v1/symbols/{symbol}": {
-            "get": {
+        "/api/v1/admin/users/{id}/disable": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": [
-                            "client",
                             "admin"
                         ]
                     }
                 ],
-                "description": "Get latest data for particular symbol",
+                "description": "Disable user. Disabled user can't sign in nor refresh tokens and its refresh tokens are revoked. Issued jwt tokens stay valid until they expire, so /api/v1/me routes remain usable until then",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Symbols"
+                    "Users"
+                ],
+                "summary": "DisableUser",
+                "operationId": "disable-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "GetSymbol",
-                "operationId": "get-symbol",
                 "responses": {
                     "200": {
-                        "description": "Successful response",
+                        "description": "Disabled user",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/model.Symbol"
-                            }
+                            "$ref": "#/definitions/model.User"
                         }
                     },
                     "400": {
@@ -231,8 +254,10 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/api/v1/admin/users/{id}/enable": {
+            "post": {
                 "security": [
                     {
                         "ApiKeyAuth": [
@@ -240,24 +265,33 @@ const docTemplate = `{
                         ]
                     }
                 ],
-                "description": "Delete data for symbol",
+                "description": "Enable disabled user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Symbols"
+                    "Users"
+                ],
+                "summary": "EnableUser",
+                "operationId": "enable-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "DeleteSymbol",
-                "operationId": "delete-symbol",
                 "responses": {
                     "200": {
-                        "description": "Deleted successfully",
+                        "description": "Enabled user",
                         "schema": {
-                            "$ref": "#/definitions/handler.CommonResponse"
+                            "$ref": "#/definitions/model.User"
                         }
                     },
                     "400": {
-                        "description": "Client request errors",
+                        "description": "Client request error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
@@ -269,13 +303,13 @@ const docTemplate = `{
                         }
                     },
                     "404": {
-                        "description": "Client request errors",
+                        "description": "Client request error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server errors",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
@@ -283,32 +317,60 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/refresh": {
-            "get": {
-                "description": "Refresh auth token",
+        "/api/v1/admin/users/{id}/logout": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Revoke all refresh tokens of the user. Issued jwt tokens stay valid until they expire",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Users"
+                ],
+                "summary": "LogoutUser",
+                "operationId": "logout-user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Refresh",
-                "operationId": "refresh-token",
                 "responses": {
                     "200": {
-                        "description": "Response with jwt token",
+                        "description": "Logged out successfully",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessfulAuthentication"
+                            "$ref": "#/definitions/handler.CommonResponse"
                         }
                     },
                     "400": {
-                        "description": "Wrong refresh token",
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server errors",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
@@ -316,9 +378,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/signin": {
+        "/api/v1/admin/users/{id}/roles": {
             "put": {
-                "description": "Authenticate user",
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Replace roles of the user. New roles are applied to tokens issued after the update",
                 "consumes": [
                     "application/json"
                 ],
@@ -326,42 +395,55 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Users"
                 ],
-                "summary": "SignIn",
-                "operationId": "sign-in",
+                "summary": "UpdateRoles",
+                "operationId": "update-user-roles",
                 "parameters": [
                     {
-                        "description": "Authentication user data",
+                        "type": "string",
+                        "description": "User id",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New roles of the user",
                         "name": "input",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.SignIn"
+                            "$ref": "#/definitions/model.UserRoles"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Response with jwt token",
+                        "description": "Updated user",
                         "schema": {
-                            "$ref": "#/definitions/model.SuccessfulAuthentication"
+                            "$ref": "#/definitions/model.User"
                         }
                     },
                     "400": {
-                        "description": "Wrong user data",
+                        "description": "Client request error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
                     },
                     "401": {
-                        "description": "Wrong credentials",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server errors",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
@@ -369,93 +451,2472 @@ const docTemplate = `{
                 }
             }
         },
-        "/auth/signup": {
-            "put": {
-                "description": "Register new user",
-                "consumes": [
-                    "application/json"
+        "/api/v1/analytics/correlation": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
                 ],
+                "description": "Get matrix of pairwise correlations of daily returns of symbols over the window of days ending on 'to' date.\nCorrelation is null if symbols have less than 3 common daily returns",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Analytics"
                 ],
-                "summary": "SignUp",
-                "operationId": "sign-up",
+                "summary": "GetCorrelation",
+                "operationId": "get-correlation",
                 "parameters": [
                     {
-                        "description": "New user data",
-                        "name": "input",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.SignUp"
-                        }
+                        "type": "string",
+                        "description": "Comma separated symbol names. Use '-' instead of '/' for fx pairs",
+                        "name": "symbols",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 90,
+                        "description": "Number of days in the window",
+                        "name": "window",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (inclusive) in YYYY-MM-DD format. Today by default",
+                        "name": "to",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "New user created successfully",
+                        "description": "Successful response",
                         "schema": {
-                            "$ref": "#/definitions/handler.CommonResponse"
+                            "$ref": "#/definitions/model.Correlation"
                         }
                     },
                     "400": {
-                        "description": "Wrong user data",
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server errors",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/handler.CommonResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "handler.CommonResponse": {
-            "type": "object",
-            "properties": {
-                "authErrors": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/model.AuthError"
-                    }
-                },
-                "code": {
-                    "type": "integer"
-                },
-                "message": {
-                    "type": "string"
-                }
-            }
         },
-        "model.AuthError": {
-            "type": "object",
-            "properties": {
-                "field": {
-                    "type": "string"
-                },
-                "rule": {
-                    "type": "string"
+        "/api/v1/analytics/{symbol}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get returns for 1 day, 1 week, 1 month, year to date and 1 year, annualised volatility and max drawdown\ncomputed from daily prices of the last year up to the latest stored price",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Analytics"
+                ],
+                "summary": "GetAnalytics",
+                "operationId": "get-analytics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol name. Use '-' instead of '/' for fx pairs",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/model.SymbolAnalytics"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Symbol or its prices not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/convert": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Convert amount between currencies with the last rate on or before the date. Rate is resolved from direct\nor inverse fx symbol or triangulated via USD. Fx symbols unknown to the service are loaded from TwelveData",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversion"
+                ],
+                "summary": "Convert",
+                "operationId": "convert",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Currency code of the amount",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Target currency code",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "default": "1",
+                        "description": "Decimal amount to convert",
+                        "name": "amount",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Date of the rate in YYYY-MM-DD format. Today by default",
+                        "name": "date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/model.Conversion"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Market data provider rate limit exceeded or provider is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before retry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exchanges": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get all exchanges ordered by MIC code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "GetExchanges",
+                "operationId": "get-exchanges",
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Exchange"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Add new exchange. MIC code must be unique and timezone, if set, must be an IANA time zone name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "AddExchange",
+                "operationId": "add-exchange",
+                "parameters": [
+                    {
+                        "description": "New exchange data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Exchange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Added successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Exchange already exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exchanges/{mic_code}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get exchange by MIC code",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "GetExchange",
+                "operationId": "get-exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/model.Exchange"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Update name, country or timezone of the exchange. Timezone must be an IANA time zone name",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "UpdateExchange",
+                "operationId": "update-exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update exchange data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateExchange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Delete exchange. Symbols are detached from the exchange but not deleted",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "DeleteExchange",
+                "operationId": "delete-exchange",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exchanges/{mic_code}/calendar": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get regular session, holidays and half-days of the exchange",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "GetCalendar",
+                "operationId": "get-exchange-calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/model.TradingCalendar"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trading calendar not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Create or replace trading calendar of the exchange",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "SaveCalendar",
+                "operationId": "save-exchange-calendar",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Trading calendar",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.TradingCalendar"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Saved successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exchanges/{mic_code}/status": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get whether exchange is open now and its next open and close according to its trading calendar",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "GetExchangeStatus",
+                "operationId": "get-exchange-status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/model.MarketStatus"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Trading calendar not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exchanges/{mic_code}/symbols": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get symbols traded on the exchange ordered by symbol",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "GetExchangeSymbols",
+                "operationId": "get-exchange-symbols",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Symbol"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/exchanges/{mic_code}/symbols/{symbol}": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Attach symbol to the exchange",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "AttachSymbol",
+                "operationId": "attach-exchange-symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Symbol name. Use '-' instead of '/' for fx pairs",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attached successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange or symbol not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Detach symbol from the exchange",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Exchanges"
+                ],
+                "summary": "DetachSymbol",
+                "operationId": "detach-exchange-symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange",
+                        "name": "mic_code",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Symbol name. Use '-' instead of '/' for fx pairs",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Detached successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Exchange or symbol not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get profile of the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "GetAccount",
+                "operationId": "get-account",
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Change username and/or email of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "UpdateProfile",
+                "operationId": "update-profile",
+                "parameters": [
+                    {
+                        "description": "New username and/or email",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateProfile"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated profile",
+                        "schema": {
+                            "$ref": "#/definitions/model.User"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong profile data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Delete account of the authenticated user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "DeleteAccount",
+                "operationId": "delete-account",
+                "parameters": [
+                    {
+                        "description": "Password confirmation",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.DeleteAccount"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong password",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/me/password": {
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Change password of the authenticated user. All refresh tokens of the user are revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Account"
+                ],
+                "summary": "ChangePassword",
+                "operationId": "change-password",
+                "parameters": [
+                    {
+                        "description": "Current and new passwords",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ChangePassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong password data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User is deleted",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/quotes": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get latest data for several symbols at once. Symbols which couldn't be resolved are returned with error",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "GetQuotes",
+                "operationId": "get-quotes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comma separated symbol names. Use '-' instead of '/' for fx pairs",
+                        "name": "symbols",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Quote"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Market data provider rate limit exceeded or provider is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before retry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/symbols": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get page of available latest symbols. Use next_cursor of the response as cursor to get the next page",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "GetSymbols",
+                "operationId": "get-symbols",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Instrument type, e.g. Common Stock",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency of the symbol",
+                        "name": "currency",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "MIC code of the exchange the symbol is traded on",
+                        "name": "mic_code",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Country of the exchange the symbol is traded on",
+                        "name": "country",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "symbol",
+                            "name",
+                            "close",
+                            "volume"
+                        ],
+                        "type": "string",
+                        "default": "symbol",
+                        "description": "Field to sort by",
+                        "name": "sort",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "asc",
+                        "description": "Sort order",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 1000,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Max number of symbols on the page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Cursor of the page",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/model.SymbolPage"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Data not found",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Update symbol data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "UpdateSymbols",
+                "operationId": "update-symbols",
+                "parameters": [
+                    {
+                        "description": "Update symbol data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateSymbol"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Add successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Add new symbol data",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "AddSymbols",
+                "operationId": "add-symbols",
+                "parameters": [
+                    {
+                        "description": "New symbol data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.Symbol"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Add successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/symbols/search": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Find symbols by partial symbol or name. Best matches go first. TwelveData catalogue is searched if no stored symbol matches",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "SearchSymbols",
+                "operationId": "search-symbols",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Part of symbol or name",
+                        "name": "q",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "maximum": 50,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Max number of matches",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.SymbolMatch"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/symbols/{symbol}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get latest data for particular symbol. Symbol is marked as stale if its outdated prices couldn't be refreshed.\nPrices are converted to the currency if it is set",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "GetSymbol",
+                "operationId": "get-symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol name. Use '-' instead of '/' for fx pairs",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code to convert prices to",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Symbol"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Market data provider rate limit exceeded or provider is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before retry"
+                            }
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Delete data for symbol",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "DeleteSymbol",
+                "operationId": "delete-symbol",
+                "responses": {
+                    "200": {
+                        "description": "Deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/symbols/{symbol}/backfill": {
+            "post": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Load historical prices for symbol from api until requested start date is covered",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "Backfill",
+                "operationId": "backfill-symbol",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol name. Use '-' instead of '/' for fx pairs",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "1min",
+                            "5min",
+                            "15min",
+                            "1h",
+                            "1day",
+                            "1week"
+                        ],
+                        "type": "string",
+                        "default": "1day",
+                        "description": "Interval of prices",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Backfilled successfully",
+                        "schema": {
+                            "$ref": "#/definitions/model.BackfillResult"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Market data provider rate limit exceeded or provider is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before retry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/symbols/{symbol}/indicators/{name}": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get technical indicator computed from close prices of the symbol in the range. Prices preceding the range are used\nto warm up the indicator, so values start from the range start if enough prices are stored. Limit is applied to indicator values.\nsma, ema and rsi have a single line named after the indicator, macd has macd, signal and histogram lines,\nbbands has middle, upper and lower lines",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "GetIndicator",
+                "operationId": "get-indicator",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol name. Use '-' instead of '/' for fx pairs",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "sma",
+                            "ema",
+                            "rsi",
+                            "macd",
+                            "bbands"
+                        ],
+                        "type": "string",
+                        "description": "Indicator name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Period of sma, ema, rsi and bbands. 20 by default, 14 for rsi",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 12,
+                        "description": "Fast EMA period of macd",
+                        "name": "fast",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 26,
+                        "description": "Slow EMA period of macd",
+                        "name": "slow",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 9,
+                        "description": "Signal EMA period of macd",
+                        "name": "signal",
+                        "in": "query"
+                    },
+                    {
+                        "type": "number",
+                        "default": 2,
+                        "description": "Width of bbands in standard deviations, not greater than 10",
+                        "name": "stddev",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "1min",
+                            "5min",
+                            "15min",
+                            "1h",
+                            "1day",
+                            "1week",
+                            "1month",
+                            "1quarter"
+                        ],
+                        "type": "string",
+                        "default": "1day",
+                        "description": "Interval of prices",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (inclusive) in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (inclusive) in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of the latest indicator values",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "$ref": "#/definitions/model.Indicator"
+                        }
+                    },
+                    "400": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/api/v1/symbols/{symbol}/prices": {
+            "get": {
+                "security": [
+                    {
+                        "ApiKeyAuth": [
+                            "client",
+                            "admin"
+                        ]
+                    }
+                ],
+                "description": "Get historical prices for particular symbol ordered by date. By default returns prices for the last year.\nMonthly and quarterly prices are aggregated from daily prices in exchange timezone and dated by the first day of the period.\nWeekly prices are aggregated the same way if no weekly prices are stored in the range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Symbols"
+                ],
+                "summary": "GetPrices",
+                "operationId": "get-prices",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Symbol name. Use '-' instead of '/' for fx pairs",
+                        "name": "symbol",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "1min",
+                            "5min",
+                            "15min",
+                            "1h",
+                            "1day",
+                            "1week",
+                            "1month",
+                            "1quarter"
+                        ],
+                        "type": "string",
+                        "default": "1day",
+                        "description": "Interval of prices",
+                        "name": "interval",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (inclusive) in YYYY-MM-DD format",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (inclusive) in YYYY-MM-DD format",
+                        "name": "to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Max number of the latest prices in range",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Currency code to convert prices to",
+                        "name": "currency",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful response",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/model.Price"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Client request error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Market data provider rate limit exceeded or provider is unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before retry"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/forgot": {
+            "post": {
+                "description": "Send password reset token to the email of the user. Response is the same whether the user exists or not",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "ForgotPassword",
+                "operationId": "forgot-password",
+                "parameters": [
+                    {
+                        "description": "Email of the user",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ForgotPassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Reset token is sent if the user exists",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong email",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/password/reset": {
+            "post": {
+                "description": "Set new password using reset token. The token can be used once and all refresh tokens of the user are revoked",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "ResetPassword",
+                "operationId": "reset-password",
+                "parameters": [
+                    {
+                        "description": "Reset token and new password",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.ResetPassword"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Password changed successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong or expired reset token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/refresh": {
+            "get": {
+                "description": "Refresh auth token",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh",
+                "operationId": "refresh-token",
+                "responses": {
+                    "200": {
+                        "description": "Response with jwt token",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessfulAuthentication"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/signin": {
+            "put": {
+                "description": "Authenticate user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "SignIn",
+                "operationId": "sign-in",
+                "parameters": [
+                    {
+                        "description": "Authentication user data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SignIn"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Response with jwt token",
+                        "schema": {
+                            "$ref": "#/definitions/model.SuccessfulAuthentication"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong user data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Wrong credentials",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "User is disabled",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/signup": {
+            "put": {
+                "description": "Register new user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "SignUp",
+                "operationId": "sign-up",
+                "parameters": [
+                    {
+                        "description": "New user data",
+                        "name": "input",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.SignUp"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "New user created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Wrong user data",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server errors",
+                        "schema": {
+                            "$ref": "#/definitions/handler.CommonResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "handler.CommonResponse": {
+            "type": "object",
+            "properties": {
+                "authErrors": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AuthError"
+                    }
+                },
+                "code": {
+                    "type": "integer"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "indicators.Params": {
+            "type": "object",
+            "properties": {
+                "fast": {
+                    "type": "integer"
+                },
+                "period": {
+                    "type": "integer"
+                },
+                "signal": {
+                    "type": "integer"
+                },
+                "slow": {
+                    "type": "integer"
+                },
+                "stddev": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.AuthError": {
+            "type": "object",
+            "properties": {
+                "field": {
+                    "type": "string"
+                },
+                "rule": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BackfillResult": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "interval": {
+                    "$ref": "#/definitions/model.Interval"
+                },
+                "prices": {
+                    "type": "integer"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ChangePassword": {
+            "type": "object",
+            "required": [
+                "current_password",
+                "new_password"
+            ],
+            "properties": {
+                "current_password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 6
+                },
+                "new_password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 6
+                }
+            }
+        },
+        "model.Conversion": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "string",
+                    "example": "100"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "from": {
+                    "type": "string"
+                },
+                "path": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rate": {
+                    "type": "string",
+                    "example": "157.2315"
+                },
+                "result": {
+                    "type": "string",
+                    "example": "15723.15"
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Correlation": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "matrix": {
+                    "type": "array",
+                    "items": {
+                        "type": "array",
+                        "items": {
+                            "type": "number"
+                        }
+                    }
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "to": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.DeleteAccount": {
+            "type": "object",
+            "required": [
+                "password"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 6
+                }
+            }
+        },
+        "model.Exchange": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "mic_code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ForgotPassword": {
+            "type": "object",
+            "required": [
+                "email"
+            ],
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                }
+            }
+        },
+        "model.Indicator": {
+            "type": "object",
+            "properties": {
+                "interval": {
+                    "$ref": "#/definitions/model.Interval"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "params": {
+                    "$ref": "#/definitions/indicators.Params"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.IndicatorValue"
+                    }
                 }
             }
         },
-        "model.Exchange": {
+        "model.IndicatorValue": {
             "type": "object",
             "properties": {
-                "country": {
+                "date": {
                     "type": "string"
                 },
+                "lines": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "model.Interval": {
+            "type": "string",
+            "enum": [
+                "1min",
+                "5min",
+                "15min",
+                "1h",
+                "1day",
+                "1week",
+                "1month",
+                "1quarter"
+            ],
+            "x-enum-varnames": [
+                "Interval1Min",
+                "Interval5Min",
+                "Interval15Min",
+                "Interval1Hour",
+                "Interval1Day",
+                "Interval1Week",
+                "Interval1Month",
+                "Interval1Quarter"
+            ]
+        },
+        "model.MarketStatus": {
+            "type": "object",
+            "properties": {
+                "is_open": {
+                    "type": "boolean"
+                },
                 "mic_code": {
                     "type": "string"
                 },
-                "name": {
+                "next_close": {
+                    "type": "string"
+                },
+                "next_open": {
                     "type": "string"
                 },
                 "timezone": {
@@ -467,25 +2928,98 @@ const docTemplate = `{
             "type": "object",
             "properties": {
                 "close": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "150.95"
                 },
                 "date": {
                     "type": "string"
                 },
                 "high": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "151.10"
+                },
+                "interval": {
+                    "$ref": "#/definitions/model.Interval"
                 },
                 "low": {
-                    "type": "string"
+                    "type": "string",
+                    "example": "149.87"
                 },
                 "open": {
+                    "type": "string",
+                    "example": "150.23"
+                },
+                "provider": {
                     "type": "string"
                 },
                 "volume": {
+                    "type": "string",
+                    "example": "53472100"
+                }
+            }
+        },
+        "model.Quote": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "$ref": "#/definitions/model.Symbol"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.ResetPassword": {
+            "type": "object",
+            "required": [
+                "password",
+                "token"
+            ],
+            "properties": {
+                "password": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 6
+                },
+                "token": {
                     "type": "string"
                 }
             }
         },
+        "model.Returns": {
+            "type": "object",
+            "properties": {
+                "1d": {
+                    "type": "number"
+                },
+                "1m": {
+                    "type": "number"
+                },
+                "1w": {
+                    "type": "number"
+                },
+                "1y": {
+                    "type": "number"
+                },
+                "ytd": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.Role": {
+            "type": "string",
+            "enum": [
+                "FinanceAdminRole",
+                "Client"
+            ],
+            "x-enum-varnames": [
+                "AdminRole",
+                "ClientRole"
+            ]
+        },
         "model.SignIn": {
             "type": "object",
             "required": [
@@ -561,6 +3095,9 @@ const docTemplate = `{
                 "name": {
                     "type": "string"
                 },
+                "stale": {
+                    "type": "boolean"
+                },
                 "symbol": {
                     "type": "string"
                 },
@@ -575,6 +3112,139 @@ const docTemplate = `{
                 }
             }
         },
+        "model.SymbolAnalytics": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "string",
+                    "example": "180.95"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "max_drawdown": {
+                    "type": "number"
+                },
+                "returns": {
+                    "$ref": "#/definitions/model.Returns"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "volatility": {
+                    "type": "number"
+                }
+            }
+        },
+        "model.SymbolMatch": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "currency": {
+                    "type": "string"
+                },
+                "exchange": {
+                    "type": "string"
+                },
+                "mic_code": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                },
+                "source": {
+                    "type": "string"
+                },
+                "symbol": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SymbolPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "symbols": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Symbol"
+                    }
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TradingCalendar": {
+            "type": "object",
+            "properties": {
+                "close": {
+                    "type": "string"
+                },
+                "early_close": {
+                    "type": "string"
+                },
+                "half_days": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "holidays": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "mic_code": {
+                    "type": "string"
+                },
+                "open": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UpdateExchange": {
+            "type": "object",
+            "properties": {
+                "country": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "timezone": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UpdateProfile": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "username": {
+                    "type": "string",
+                    "maxLength": 32,
+                    "minLength": 3
+                }
+            }
+        },
         "model.UpdateSymbol": {
             "type": "object",
             "required": [
@@ -612,6 +3282,115 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "model.User": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "disabled": {
+                    "type": "boolean"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Role"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.UserPage": {
+            "type": "object",
+            "properties": {
+                "next_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "users": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.User"
+                    }
+                }
+            }
+        },
+        "model.UserRoles": {
+            "type": "object",
+            "properties": {
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.Role"
+                    }
+                }
+            }
+        },
+        "ratelimit.KeyStats": {
+            "type": "object",
+            "properties": {
+                "disabledUntil": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "remainingPerDay": {
+                    "type": "integer"
+                },
+                "remainingPerMinute": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ratelimit.Stats": {
+            "type": "object",
+            "properties": {
+                "acquired": {
+                    "type": "integer"
+                },
+                "cancelled": {
+                    "type": "integer"
+                },
+                "delayed": {
+                    "type": "integer"
+                },
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ratelimit.KeyStats"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "queued": {
+                    "type": "integer"
+                },
+                "rejected": {
+                    "type": "integer"
+                },
+                "remainingPerDay": {
+                    "type": "integer"
+                },
+                "remainingPerMinute": {
+                    "type": "integer"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -19,11 +19,30 @@ type Config struct {
 	} `yaml:"database"`
 	API struct {
 		TwelveData struct {
-			Host      string        `yaml:"host" env:"TWELVE_DATA_HOST" env-default:"https://api.twelvedata.com"`
-			Timeout   time.Duration `yaml:"timeout" env:"TWELVE_DATA_TIMEOUT" env-default:"2m"`
-			RateLimit int           `yaml:"rateLimit" env:"TWELVE_DATA_RATE_LIMIT" env-default:"8"`
-			ApiKey    string        `yaml:"apiKey" env:"TWELVE_DATA_API_KEY"`
+			Host           string        `yaml:"host" env:"TWELVE_DATA_HOST" env-default:"https://api.twelvedata.com"`
+			Timeout        time.Duration `yaml:"timeout" env:"TWELVE_DATA_TIMEOUT" env-default:"2m"`
+			RateLimit      int           `yaml:"rateLimit" env:"TWELVE_DATA_RATE_LIMIT" env-default:"8"`
+			DailyLimit     int           `yaml:"dailyLimit" env:"TWELVE_DATA_DAILY_LIMIT" env-default:"800"`
+			MaxQueue       int           `yaml:"maxQueue" env:"TWELVE_DATA_MAX_QUEUE" env-default:"16"`
+			HistoryDepth   int           `yaml:"historyDepth" env:"TWELVE_DATA_HISTORY_DEPTH" env-default:"365"`
+			SearchFallback bool          `yaml:"searchFallback" env:"TWELVE_DATA_SEARCH_FALLBACK" env-default:"true"`
+			ApiKey         string        `yaml:"apiKey" env:"TWELVE_DATA_API_KEY"`
+			ApiKeys        []string      `yaml:"apiKeys" env:"TWELVE_DATA_API_KEYS" env-separator:","`
 		} `yaml:"twelveData"`
+		AlphaVantage struct {
+			Host       string        `yaml:"host" env:"ALPHA_VANTAGE_HOST" env-default:"https://www.alphavantage.co"`
+			Timeout    time.Duration `yaml:"timeout" env:"ALPHA_VANTAGE_TIMEOUT" env-default:"2m"`
+			RateLimit  int           `yaml:"rateLimit" env:"ALPHA_VANTAGE_RATE_LIMIT" env-default:"5"`
+			DailyLimit int           `yaml:"dailyLimit" env:"ALPHA_VANTAGE_DAILY_LIMIT" env-default:"25"`
+			MaxQueue   int           `yaml:"maxQueue" env:"ALPHA_VANTAGE_MAX_QUEUE" env-default:"16"`
+			ApiKey     string        `yaml:"apiKey" env:"ALPHA_VANTAGE_API_KEY"`
+		} `yaml:"alphaVantage"`
+		Providers     []string      `yaml:"providers" env:"API_PROVIDERS" env-separator:"," env-default:"twelveData"`
+		LookupTimeout time.Duration `yaml:"lookupTimeout" env:"API_LOOKUP_TIMEOUT" env-default:"3m"`
+		Failover      struct {
+			FailureThreshold int           `yaml:"failureThreshold" env:"API_FAILOVER_FAILURE_THRESHOLD" env-default:"3"`
+			OpenTimeout      time.Duration `yaml:"openTimeout" env:"API_FAILOVER_OPEN_TIMEOUT" env-default:"5m"`
+		} `yaml:"failover"`
 	} `yaml:"api"`
 	Logs struct {
 		Level string `yaml:"level" env:"LOGS_LEVEL" env-default:"INFO"`
@@ -37,8 +56,22 @@ type Config struct {
 		WriteTimeout time.Duration `yaml:"writeTimeout" env:"SERVER_WRITE_TIMEOUT" env-default:"10s"`
 	} `yaml:"server"`
 	Cache struct {
-		SymbolTTL time.Duration `yaml:"symbolTtl" env:"CACHE_SYMBOL_TTL" env-default:"1h"`
+		SymbolTTL    time.Duration `yaml:"symbolTtl" env:"CACHE_SYMBOL_TTL" env-default:"1h"`
+		AnalyticsTTL time.Duration `yaml:"analyticsTtl" env:"CACHE_ANALYTICS_TTL" env-default:"1h"`
 	} `yaml:"cache"`
+	Freshness struct {
+		Enabled bool    `yaml:"enabled" env:"FRESHNESS_ENABLED" env-default:"false"`
+		Equity  Session `yaml:"equity" env-prefix:"FRESHNESS_EQUITY_"`
+		FX      Session `yaml:"fx" env-prefix:"FRESHNESS_FX_"`
+		Crypto  Session `yaml:"crypto" env-prefix:"FRESHNESS_CRYPTO_"`
+	} `yaml:"freshness"`
+	Calendar struct {
+		Path string `yaml:"path" env:"CALENDAR_PATH" env-default:"config/calendars.yaml"`
+	} `yaml:"calendar"`
+	Refresh struct {
+		Enabled bool          `yaml:"enabled" env:"REFRESH_ENABLED" env-default:"false"`
+		Period  time.Duration `yaml:"period" env:"REFRESH_PERIOD" env-default:"1h"`
+	} `yaml:"refresh"`
 	JWT struct {
 		HMACSecret         string        `yaml:"hmac_secret" env:"JWT_HMAC_SECRET"`
 		ExpiryTimeout      time.Duration `yaml:"expiry_timeout" env:"JWT_EXPIRY_TIMEOUT" env-default:"15m"`
@@ -51,6 +84,28 @@ type Config struct {
 		MQUri       string `yaml:"mq_uri" env:"AUDIT_MQ_URI"`
 		QueueName   string `yaml:"queue_name" env:"AUDIT_QUEUE_NAME" env-default:"audit"`
 	} `yaml:"audit"`
+	Notifier struct {
+		Type string `yaml:"type" env:"NOTIFIER_TYPE" env-required:"true"`
+		Path string `yaml:"path" env:"NOTIFIER_PATH" env-default:"notifications.txt"`
+		SMTP struct {
+			Host     string        `yaml:"host" env:"SMTP_HOST" env-default:"localhost"`
+			Port     int           `yaml:"port" env:"SMTP_PORT" env-default:"587"`
+			Username string        `yaml:"username" env:"SMTP_USERNAME"`
+			Password string        `yaml:"password" env:"SMTP_PASSWORD"`
+			From     string        `yaml:"from" env:"SMTP_FROM" env-default:"no-reply@localhost"`
+			Timeout  time.Duration `yaml:"timeout" env:"SMTP_TIMEOUT" env-default:"30s"`
+		} `yaml:"smtp"`
+	} `yaml:"notifier"`
+	PasswordReset struct {
+		TokenTTL time.Duration `yaml:"token_ttl" env:"PASSWORD_RESET_TOKEN_TTL" env-default:"1h"`
+		URL      string        `yaml:"url" env:"PASSWORD_RESET_URL"`
+	} `yaml:"password_reset"`
+}
+
+type Session struct {
+	Timezone     string        `yaml:"timezone" env:"TIMEZONE" env-default:"UTC"`
+	SessionClose time.Duration `yaml:"sessionClose" env:"SESSION_CLOSE" env-default:"24h"`
+	Weekends     bool          `yaml:"weekends" env:"WEEKENDS" env-default:"false"`
 }
 
 var Conf Config
@@ -64,4 +119,7 @@ func Init() {
 	if err != nil {
 		log.Fatal("Error on reading config!", err)
 	}
+	if Conf.PasswordReset.URL == "" {
+		Conf.PasswordReset.URL = "http://localhost:" + Conf.Server.Port + "/reset-password"
+	}
 }
@@ -0,0 +1,158 @@
+package handler
+
+import (
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"strings"
+)
+
+type accountHandler struct {
+	service service.AccountService
+}
+
+var acLog zerolog.Logger
+
+func (h *accountHandler) errorErrorResponse(c *fiber.Ctx, err error, statusCode int, message string) error {
+	return errorErrorResponse(c, &acLog, err, statusCode, message)
+}
+
+func (h *accountHandler) infoErrorResponse(c *fiber.Ctx, err error, statusCode int, message string, authErrors ...[]*model.AuthError) error {
+	return infoErrorResponse(c, &acLog, err, statusCode, message, authErrors...)
+}
+
+// GetAccount godoc
+//
+//	@Summary		GetAccount
+//	@Tags			Account
+//	@Description	Get profile of the authenticated user
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-account
+//	@Produce		json
+//	@Success		200	{object}	model.User		"Successful response"
+//	@Failure		401	{object}	CommonResponse	"Unauthorized"
+//	@Failure		404	{object}	CommonResponse	"User is deleted"
+//	@Failure		500	{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/me [get]
+func (h *accountHandler) GetAccount(c *fiber.Ctx) error {
+	user, err := h.service.Get(c.Context(), principalOf(c).ID)
+	if err != nil {
+		return h.accountErrorResponse(c, err, "Failed to get account")
+	}
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// UpdateProfile godoc
+//
+//	@Summary		UpdateProfile
+//	@Tags			Account
+//	@Description	Change username and/or email of the authenticated user
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				update-profile
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		model.UpdateProfile	true	"New username and/or email"
+//	@Success		200		{object}	model.User			"Updated profile"
+//	@Failure		400		{object}	CommonResponse		"Wrong profile data"
+//	@Failure		401		{object}	CommonResponse		"Unauthorized"
+//	@Failure		404		{object}	CommonResponse		"User is deleted"
+//	@Failure		500		{object}	CommonResponse		"Internal server error"
+//	@Router			/api/v1/me [put]
+func (h *accountHandler) UpdateProfile(c *fiber.Ctx) error {
+	var profile model.UpdateProfile
+	if err := c.BodyParser(&profile); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	profile.Username = strings.ToLower(profile.Username)
+	profile.Email = strings.ToLower(profile.Email)
+	if profile.Username == "" && profile.Email == "" {
+		return h.infoErrorResponse(c, errors.New("empty profile"), fiber.StatusBadRequest, "'username' or 'email' must be set")
+	}
+	authErrors := model.Validate(profile)
+	if len(authErrors) > 0 {
+		return h.infoErrorResponse(c, errors.New("invalid profile body"), fiber.StatusBadRequest, "Wrong body", authErrors)
+	}
+	user, err := h.service.UpdateProfile(c.Context(), principalOf(c).ID, profile)
+	if err == model.UserAlreadyExists {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "User with such username or email already exists")
+	} else if err != nil {
+		return h.accountErrorResponse(c, err, "Failed to update profile")
+	}
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// ChangePassword godoc
+//
+//	@Summary		ChangePassword
+//	@Tags			Account
+//	@Description	Change password of the authenticated user. All refresh tokens of the user are revoked
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				change-password
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		model.ChangePassword	true	"Current and new passwords"
+//	@Success		200		{object}	CommonResponse			"Password changed successfully"
+//	@Failure		400		{object}	CommonResponse			"Wrong password data"
+//	@Failure		401		{object}	CommonResponse			"Unauthorized"
+//	@Failure		404		{object}	CommonResponse			"User is deleted"
+//	@Failure		500		{object}	CommonResponse			"Internal server error"
+//	@Router			/api/v1/me/password [put]
+func (h *accountHandler) ChangePassword(c *fiber.Ctx) error {
+	var change model.ChangePassword
+	if err := c.BodyParser(&change); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	authErrors := model.Validate(change)
+	if len(authErrors) > 0 {
+		return h.infoErrorResponse(c, errors.New("invalid password body"), fiber.StatusBadRequest, "Wrong body", authErrors)
+	}
+	if err := h.service.ChangePassword(c.Context(), principalOf(c).ID, change); err != nil {
+		return h.accountErrorResponse(c, err, "Failed to change password")
+	}
+	c.ClearCookie(refreshTokenCookie)
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
+
+// DeleteAccount godoc
+//
+//	@Summary		DeleteAccount
+//	@Tags			Account
+//	@Description	Delete account of the authenticated user
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				delete-account
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		model.DeleteAccount	true	"Password confirmation"
+//	@Success		200		{object}	CommonResponse		"Deleted successfully"
+//	@Failure		400		{object}	CommonResponse		"Wrong password"
+//	@Failure		401		{object}	CommonResponse		"Unauthorized"
+//	@Failure		404		{object}	CommonResponse		"User is deleted"
+//	@Failure		500		{object}	CommonResponse		"Internal server error"
+//	@Router			/api/v1/me [delete]
+func (h *accountHandler) DeleteAccount(c *fiber.Ctx) error {
+	var deletion model.DeleteAccount
+	if err := c.BodyParser(&deletion); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	authErrors := model.Validate(deletion)
+	if len(authErrors) > 0 {
+		return h.infoErrorResponse(c, errors.New("invalid deletion body"), fiber.StatusBadRequest, "Wrong body", authErrors)
+	}
+	if err := h.service.Delete(c.Context(), principalOf(c).ID, deletion.Password); err != nil {
+		return h.accountErrorResponse(c, err, "Failed to delete account")
+	}
+	c.ClearCookie(refreshTokenCookie)
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
+
+// accountErrorResponse returns 400 on wrong password, 404 if the user is already deleted and 500 otherwise
+func (h *accountHandler) accountErrorResponse(c *fiber.Ctx, err error, message string) error {
+	if err == model.WrongPassword {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong password")
+	} else if err == model.UserNotFound {
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, "user not found")
+	}
+	return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, message)
+}
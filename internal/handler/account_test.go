@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/account_service_mock.go -source=../service/account_service.go AccountService
+
+var me = map[string]string{"User-Id": userID}
+
+func TestGetAccount(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockAccountService(controller)
+	app := setupFiberTest(&Handler{ach: accountHandler{service: mockService}})
+
+	mockService.EXPECT().Get(gomock.Any(), userID).Return(user, nil)
+	response, err := app.Test(utils.GetRequest("/api/v1/me", me))
+	utils.CommonResponseAssertions(t, response, err, 200, user)
+
+	mockService.EXPECT().Get(gomock.Any(), userID).Return(model.User{}, model.UserNotFound)
+	response, err = app.Test(utils.GetRequest("/api/v1/me", me))
+	utils.CommonResponseAssertions(t, response, err, 404, CommonResponse{Code: 404, Message: "user not found"})
+}
+
+func TestUpdateProfile(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockAccountService(controller)
+	app := setupFiberTest(&Handler{ach: accountHandler{service: mockService}})
+	for _, td := range updateProfileTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedProfile != nil {
+				mockService.EXPECT().UpdateProfile(gomock.Any(), userID, *td.expectedProfile).Return(td.user, td.serviceError)
+			}
+			response, err := app.Test(utils.PutRequest("/api/v1/me", td.body, false, me))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var updateProfileTests = []struct {
+	name             string
+	body             model.UpdateProfile
+	expectedProfile  *model.UpdateProfile
+	user             model.User
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("update profile successfully"),
+		body:             model.UpdateProfile{Username: "New_User", Email: "New@Test.com"},
+		expectedProfile:  &model.UpdateProfile{Username: "new_user", Email: "new@test.com"},
+		user:             model.User{ID: userID, Username: "new_user", Email: "new@test.com"},
+		expectedCode:     200,
+		expectedResponse: model.User{ID: userID, Username: "new_user", Email: "new@test.com"},
+	},
+	{
+		name:             utils.TestName("username is taken"),
+		body:             model.UpdateProfile{Username: "admin"},
+		expectedProfile:  &model.UpdateProfile{Username: "admin"},
+		serviceError:     model.UserAlreadyExists,
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "User with such username or email already exists"},
+	},
+	{
+		name:             utils.TestName("invalid email"),
+		body:             model.UpdateProfile{Email: "not an email"},
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "Wrong body", AuthErrors: []*model.AuthError{{Field: "Email", Rule: "email"}}},
+	},
+	{
+		name:             utils.TestName("empty profile"),
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'username' or 'email' must be set"},
+	},
+}
+
+func TestChangePassword(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockAccountService(controller)
+	app := setupFiberTest(&Handler{ach: accountHandler{service: mockService}})
+	change := model.ChangePassword{CurrentPassword: "password", NewPassword: "new_password"}
+
+	mockService.EXPECT().ChangePassword(gomock.Any(), userID, change).Return(nil)
+	response, err := app.Test(utils.PutRequest("/api/v1/me/password", change, false, me))
+	utils.CommonResponseAssertions(t, response, err, 200, CommonResponse{Code: 200, Message: "successful"})
+
+	mockService.EXPECT().ChangePassword(gomock.Any(), userID, change).Return(model.WrongPassword)
+	response, err = app.Test(utils.PutRequest("/api/v1/me/password", change, false, me))
+	utils.CommonResponseAssertions(t, response, err, 400, CommonResponse{Code: 400, Message: "Wrong password"})
+
+	response, err = app.Test(utils.PutRequest("/api/v1/me/password", model.ChangePassword{CurrentPassword: "password", NewPassword: "new"}, false, me))
+	utils.CommonResponseAssertions(t, response, err, 400, CommonResponse{Code: 400, Message: "Wrong body", AuthErrors: []*model.AuthError{{Field: "NewPassword", Rule: "min"}}})
+}
+
+func TestDeleteAccount(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockAccountService(controller)
+	app := setupFiberTest(&Handler{ach: accountHandler{service: mockService}})
+	deletion := model.DeleteAccount{Password: "password"}
+	headers := map[string]string{"User-Id": userID, fiber.HeaderContentType: fiber.MIMEApplicationJSON}
+
+	mockService.EXPECT().Delete(gomock.Any(), userID, "password").Return(nil)
+	response, err := app.Test(utils.DeleteRequest("/api/v1/me", deletion, false, headers))
+	utils.CommonResponseAssertions(t, response, err, 200, CommonResponse{Code: 200, Message: "successful"})
+
+	mockService.EXPECT().Delete(gomock.Any(), userID, "password").Return(errors.New("db is down"))
+	response, err = app.Test(utils.DeleteRequest("/api/v1/me", deletion, false, headers))
+	utils.CommonResponseAssertions(t, response, err, 500, CommonResponse{Code: 500, Message: "Failed to delete account"})
+}
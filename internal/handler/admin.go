@@ -0,0 +1,29 @@
+package handler
+
+import (
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
+	"github.com/gofiber/fiber/v2"
+)
+
+type adminHandler struct {
+	rateLimits []ratelimit.Reporter
+}
+
+// GetRateLimits godoc
+//
+//	@Summary		GetRateLimits
+//	@Tags			Admin
+//	@Description	Get remaining credits per api key and queued, rejected and cancelled requests of every market data provider
+//	@Security		ApiKeyAuth[admin]
+//	@ID				get-rate-limits
+//	@Produce		json
+//	@Success		200	{array}		ratelimit.Stats	"Successful response"
+//	@Failure		401	{object}	CommonResponse	"Unauthorized"
+//	@Router			/api/v1/admin/rate-limits [get]
+func (h *adminHandler) GetRateLimits(c *fiber.Ctx) error {
+	result := make([]ratelimit.Stats, 0, len(h.rateLimits))
+	for _, reporter := range h.rateLimits {
+		result = append(result, reporter.Stats())
+	}
+	return c.Status(fiber.StatusOK).JSON(result)
+}
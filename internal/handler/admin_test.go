@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"testing"
+)
+
+func TestGetRateLimits(t *testing.T) {
+	limiter := ratelimit.NewLimiter("twelveData", ratelimit.Budget{PerMinute: 8, PerDay: 800}, 1)
+	utils.PanicOnError(limiter.Wait(context.TODO(), 1))
+	app := setupFiberTest(&Handler{adm: adminHandler{rateLimits: []ratelimit.Reporter{limiter}}}, utils.TestAuthMiddleware)
+	for _, td := range getRateLimitsTests {
+		t.Run(td.name, func(t *testing.T) {
+			response, err := app.Test(utils.GetRequest("/api/v1/admin/rate-limits", map[string]string{"Role": string(td.role)}))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var getRateLimitsTests = []struct {
+	name             string
+	role             model.Role
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("get rate limits successfully"),
+		role:             model.AdminRole,
+		expectedCode:     200,
+		expectedResponse: []ratelimit.Stats{{Name: "twelveData", RemainingPerMinute: 7, RemainingPerDay: 799, Acquired: 1}},
+	},
+	{
+		name:             utils.TestName("get rate limits by client"),
+		role:             model.ClientRole,
+		expectedCode:     401,
+		expectedResponse: CommonResponse{Code: 401, Message: "you don't have permissions for this endpoint"},
+	},
+}
@@ -0,0 +1,117 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GalushkoArt/simpleCache"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"strings"
+	"time"
+)
+
+type analyticsHandler struct {
+	service      service.AnalyticsService
+	cache        simpleCache.GenericCache[model.SymbolAnalytics]
+	correlations simpleCache.GenericCache[model.Correlation]
+}
+
+var anLog zerolog.Logger
+
+func (h *analyticsHandler) errorErrorResponse(c *fiber.Ctx, err error, statusCode int, message string) error {
+	return errorErrorResponse(c, &anLog, err, statusCode, message)
+}
+
+func (h *analyticsHandler) infoErrorResponse(c *fiber.Ctx, err error, statusCode int, message string) error {
+	return infoErrorResponse(c, &anLog, err, statusCode, message)
+}
+
+const (
+	defaultCorrelationWindow = 90
+	maxCorrelationWindow     = 1095
+	maxCorrelationSymbols    = 20
+)
+
+// GetAnalytics godoc
+//
+//	@Summary		GetAnalytics
+//	@Tags			Analytics
+//	@Description	Get returns for 1 day, 1 week, 1 month, year to date and 1 year, annualised volatility and max drawdown
+//	@Description	computed from daily prices of the last year up to the latest stored price
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-analytics
+//	@Produce		json
+//	@Param			symbol	path		string					true	"Symbol name. Use '-' instead of '/' for fx pairs"
+//	@Success		200		{object}	model.SymbolAnalytics	"Successful response"
+//	@Failure		401		{object}	CommonResponse			"Unauthorized"
+//	@Failure		404		{object}	CommonResponse			"Symbol or its prices not found"
+//	@Failure		500		{object}	CommonResponse			"Internal server error"
+//	@Router			/api/v1/analytics/{symbol} [get]
+func (h *analyticsHandler) GetAnalytics(c *fiber.Ctx) error {
+	symbol := strings.Replace(c.Params("symbol"), "-", "/", 1)
+	if cached := h.cache.Get(symbol); cached != nil {
+		return c.Status(fiber.StatusOK).JSON(cached)
+	}
+	analytics, err := h.service.Get(c.Context(), symbol, time.Now().UTC())
+	if errors.Is(err, model.SymbolNotFound) {
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, fmt.Sprintf("symbol %s not found", symbol))
+	} else if err == model.NotEnoughPrices {
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, fmt.Sprintf("no prices of %s symbol for the last year", symbol))
+	} else if err != nil {
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get analytics for %s symbol", symbol))
+	}
+	h.cache.Set(symbol, analytics)
+	return c.Status(fiber.StatusOK).JSON(analytics)
+}
+
+// GetCorrelation godoc
+//
+//	@Summary		GetCorrelation
+//	@Tags			Analytics
+//	@Description	Get matrix of pairwise correlations of daily returns of symbols over the window of days ending on 'to' date.
+//	@Description	Correlation is null if symbols have less than 3 common daily returns
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-correlation
+//	@Produce		json
+//	@Param			symbols	query		string				true	"Comma separated symbol names. Use '-' instead of '/' for fx pairs"
+//	@Param			window	query		int					false	"Number of days in the window"	default(90)
+//	@Param			to		query		string				false	"End date (inclusive) in YYYY-MM-DD format. Today by default"
+//	@Success		200		{object}	model.Correlation	"Successful response"
+//	@Failure		400,404	{object}	CommonResponse		"Client request error"
+//	@Failure		401		{object}	CommonResponse		"Unauthorized"
+//	@Failure		500		{object}	CommonResponse		"Internal server error"
+//	@Router			/api/v1/analytics/correlation [get]
+func (h *analyticsHandler) GetCorrelation(c *fiber.Ctx) error {
+	symbols := parseSymbols(c.Query("symbols"))
+	if len(symbols) < 2 || len(symbols) > maxCorrelationSymbols {
+		return h.infoErrorResponse(c, errors.New("invalid symbols"), fiber.StatusBadRequest, fmt.Sprintf("'symbols' must contain from 2 to %d symbols", maxCorrelationSymbols))
+	}
+	window := c.QueryInt("window", 0)
+	if c.Query("window") == "" {
+		window = defaultCorrelationWindow
+	} else if window < 2 || window > maxCorrelationWindow {
+		return h.infoErrorResponse(c, errors.New("invalid window"), fiber.StatusBadRequest, fmt.Sprintf("'window' must be a number between 2 and %d", maxCorrelationWindow))
+	}
+	to := time.Now().UTC().Truncate(24 * time.Hour)
+	if c.Query("to") != "" {
+		var err error
+		if to, err = time.Parse(model.DateLayout, c.Query("to")); err != nil {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "'to' must be a date in YYYY-MM-DD format")
+		}
+	}
+	from := to.AddDate(0, 0, 1-window)
+	key := fmt.Sprintf("%s/%s/%s", strings.Join(symbols, ","), from.Format(model.DateLayout), to.Format(model.DateLayout))
+	if cached := h.correlations.Get(key); cached != nil {
+		return c.Status(fiber.StatusOK).JSON(cached)
+	}
+	correlation, err := h.service.Correlation(c.Context(), symbols, from, to)
+	if errors.Is(err, model.SymbolNotFound) {
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, err.Error())
+	} else if err != nil {
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to get correlation")
+	}
+	h.correlations.Set(key, correlation)
+	return c.Status(fiber.StatusOK).JSON(correlation)
+}
@@ -0,0 +1,156 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/analytics_service_mock.go -source=../service/analytics_service.go AnalyticsService
+
+func TestGetAnalytics(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockAnalyticsService(controller)
+	mockCache := mock.NewMockGenericCache[model.SymbolAnalytics](controller)
+	app := setupFiberTest(&Handler{anh: analyticsHandler{service: mockService, cache: mockCache}})
+	for _, td := range getAnalyticsTests {
+		t.Run(td.name, func(t *testing.T) {
+			mockCache.EXPECT().Get(td.symbol).Return(td.cached)
+			if td.cached == nil {
+				mockService.EXPECT().Get(gomock.Any(), td.symbol, gomock.Any()).Return(td.analytics, td.serviceError)
+			}
+			if td.cached == nil && td.expectedCode == 200 {
+				mockCache.EXPECT().Set(td.symbol, td.analytics)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/analytics/" + td.requestedSymbol))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var dayReturn, volatility = 0.0064, 0.2817
+
+var getAnalyticsTests = []struct {
+	name             string
+	requestedSymbol  string
+	symbol           string
+	cached           *model.SymbolAnalytics
+	analytics        model.SymbolAnalytics
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("get analytics successfully"),
+		requestedSymbol:  "EUR-USD",
+		symbol:           "EUR/USD",
+		analytics:        model.SymbolAnalytics{Symbol: "EUR/USD", Date: "2023-06-02", Close: "1.0708", Returns: model.Returns{Day: &dayReturn}, Volatility: &volatility, MaxDrawdown: -0.0712},
+		expectedCode:     200,
+		expectedResponse: model.SymbolAnalytics{Symbol: "EUR/USD", Date: "2023-06-02", Close: "1.0708", Returns: model.Returns{Day: &dayReturn}, Volatility: &volatility, MaxDrawdown: -0.0712},
+	},
+	{
+		name:             utils.TestName("get cached analytics"),
+		requestedSymbol:  "AAPL",
+		symbol:           "AAPL",
+		cached:           &model.SymbolAnalytics{Symbol: "AAPL", Date: "2023-06-02", Close: "180.95"},
+		expectedCode:     200,
+		expectedResponse: model.SymbolAnalytics{Symbol: "AAPL", Date: "2023-06-02", Close: "180.95"},
+	},
+	{
+		name:             utils.TestName("symbol not found"),
+		requestedSymbol:  "UNKNOWN",
+		symbol:           "UNKNOWN",
+		serviceError:     fmt.Errorf("%w: UNKNOWN", model.SymbolNotFound),
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "symbol UNKNOWN not found"},
+	},
+	{
+		name:             utils.TestName("symbol without prices"),
+		requestedSymbol:  "AAPL",
+		symbol:           "AAPL",
+		serviceError:     model.NotEnoughPrices,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "no prices of AAPL symbol for the last year"},
+	},
+	{
+		name:             utils.TestName("get analytics failed"),
+		requestedSymbol:  "AAPL",
+		symbol:           "AAPL",
+		serviceError:     errors.New("db is down"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to get analytics for AAPL symbol"},
+	},
+}
+
+func TestGetCorrelation(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockAnalyticsService(controller)
+	mockCache := mock.NewMockGenericCache[model.Correlation](controller)
+	app := setupFiberTest(&Handler{anh: analyticsHandler{service: mockService, correlations: mockCache}})
+	for _, td := range getCorrelationTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedCode != 400 {
+				key := fmt.Sprintf("%s/%s/%s", "AAPL,MSFT", td.from.Format(model.DateLayout), td.to.Format(model.DateLayout))
+				mockCache.EXPECT().Get(key).Return(nil)
+				mockService.EXPECT().Correlation(gomock.Any(), []string{"AAPL", "MSFT"}, td.from, td.to).Return(td.correlation, td.serviceError)
+				if td.expectedCode == 200 {
+					mockCache.EXPECT().Set(key, td.correlation)
+				}
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/analytics/correlation?" + td.query))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var one, correlated = 1.0, 0.63
+
+var getCorrelationTests = []struct {
+	name             string
+	query            string
+	from             time.Time
+	to               time.Time
+	correlation      model.Correlation
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:  utils.TestName("get correlation successfully"),
+		query: "symbols=AAPL,MSFT&window=30&to=2023-06-02",
+		from:  time.Date(2023, 5, 4, 0, 0, 0, 0, time.UTC),
+		to:    time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC),
+		correlation: model.Correlation{Symbols: []string{"AAPL", "MSFT"}, From: "2023-05-04", To: "2023-06-02",
+			Matrix: [][]*float64{{&one, &correlated}, {&correlated, &one}}},
+		expectedCode: 200,
+		expectedResponse: model.Correlation{Symbols: []string{"AAPL", "MSFT"}, From: "2023-05-04", To: "2023-06-02",
+			Matrix: [][]*float64{{&one, &correlated}, {&correlated, &one}}},
+	},
+	{
+		name:             utils.TestName("unknown symbol"),
+		query:            "symbols=AAPL,MSFT&to=2023-06-02",
+		from:             time.Date(2023, 3, 5, 0, 0, 0, 0, time.UTC),
+		to:               time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC),
+		serviceError:     fmt.Errorf("%w: MSFT", model.SymbolNotFound),
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "symbol not found: MSFT"},
+	},
+	{
+		name:             utils.TestName("single symbol"),
+		query:            "symbols=AAPL",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'symbols' must contain from 2 to 20 symbols"},
+	},
+	{
+		name:             utils.TestName("invalid window"),
+		query:            "symbols=AAPL,MSFT&window=1",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'window' must be a number between 2 and 1095"},
+	},
+}
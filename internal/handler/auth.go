@@ -54,7 +54,7 @@ func (h *authHandler) SignUp(c *fiber.Ctx) error {
 		return h.infoErrorResponse(c, errors.New("invalid sign-up body"), fiber.StatusBadRequest, "Wrong body", authErrors)
 	}
 	if err := h.service.SignUp(c.Context(), signUp); err != nil {
-		if err == service.UserAlreadyExists {
+		if err == model.UserAlreadyExists {
 			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "User with such username or email already exists")
 		}
 		return h.warnErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to register")
@@ -74,6 +74,7 @@ func (h *authHandler) SignUp(c *fiber.Ctx) error {
 //	@Success		200		{object}	model.SuccessfulAuthentication	"Response with jwt token"
 //	@Failure		400		{object}	CommonResponse					"Wrong user data"
 //	@Failure		401		{object}	CommonResponse					"Wrong credentials"
+//	@Failure		403		{object}	CommonResponse					"User is disabled"
 //	@Failure		500		{object}	CommonResponse					"Internal server errors"
 //	@Router			/auth/signin [put]
 func (h *authHandler) SignIn(c *fiber.Ctx) error {
@@ -90,6 +91,8 @@ func (h *authHandler) SignIn(c *fiber.Ctx) error {
 	if err != nil {
 		if err == model.UserNotFound {
 			return h.infoErrorResponse(c, errors.New("wrong credentials"), fiber.StatusUnauthorized, "Wrong credentials", authErrors)
+		} else if err == model.UserDisabled {
+			return h.infoErrorResponse(c, err, fiber.StatusForbidden, "User is disabled")
 		}
 		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to sign in")
 	}
@@ -106,6 +109,7 @@ func (h *authHandler) SignIn(c *fiber.Ctx) error {
 //	@Produce		json
 //	@Success		200	{object}	model.SuccessfulAuthentication	"Response with jwt token"
 //	@Failure		400	{object}	CommonResponse					"Wrong refresh token"
+//	@Failure		403	{object}	CommonResponse					"User is disabled"
 //	@Failure		500	{object}	CommonResponse					"Internal server errors"
 //	@Router			/auth/refresh [get]
 func (h *authHandler) Refresh(c *fiber.Ctx) error {
@@ -115,11 +119,78 @@ func (h *authHandler) Refresh(c *fiber.Ctx) error {
 	}
 	jwtToken, refreshToken, expiryTime, err := h.service.RefreshToken(c.Context(), refreshToken)
 	if err != nil {
-		if err == model.TokenExpired || err == model.TokenNotFound {
+		if err == model.TokenExpired || err == model.TokenNotFound || err == model.UserNotFound {
 			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Active refresh token not found. Please sign-in")
+		} else if err == model.UserDisabled {
+			return h.infoErrorResponse(c, err, fiber.StatusForbidden, "User is disabled")
 		}
 		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to refresh token. Please sign-in")
 	}
 	c.Cookie(&fiber.Cookie{Name: refreshTokenCookie, Value: refreshToken, Expires: expiryTime, HTTPOnly: true})
 	return c.Status(fiber.StatusOK).JSON(model.SuccessfulAuthentication{Token: jwtToken})
 }
+
+// ForgotPassword godoc
+//
+//	@Summary		ForgotPassword
+//	@Tags			Auth
+//	@Description	Send password reset token to the email of the user. Response is the same whether the user exists or not
+//	@ID				forgot-password
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		model.ForgotPassword	true	"Email of the user"
+//	@Success		200		{object}	CommonResponse			"Reset token is sent if the user exists"
+//	@Failure		400		{object}	CommonResponse			"Wrong email"
+//	@Failure		500		{object}	CommonResponse			"Internal server errors"
+//	@Router			/auth/password/forgot [post]
+func (h *authHandler) ForgotPassword(c *fiber.Ctx) error {
+	var forgot model.ForgotPassword
+	if err := c.BodyParser(&forgot); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	forgot.Email = strings.ToLower(forgot.Email)
+	authErrors := model.Validate(forgot)
+	if len(authErrors) > 0 {
+		return h.infoErrorResponse(c, errors.New("invalid forgot password body"), fiber.StatusBadRequest, "Wrong body", authErrors)
+	}
+	if err := h.service.ForgotPassword(c.Context(), forgot.Email); err != nil {
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to request password reset")
+	}
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "If the email is registered, password reset instructions are sent to it"})
+}
+
+// ResetPassword godoc
+//
+//	@Summary		ResetPassword
+//	@Tags			Auth
+//	@Description	Set new password using reset token. The token can be used once and all refresh tokens of the user are revoked
+//	@ID				reset-password
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		model.ResetPassword	true	"Reset token and new password"
+//	@Success		200		{object}	CommonResponse		"Password changed successfully"
+//	@Failure		400		{object}	CommonResponse		"Wrong or expired reset token"
+//	@Failure		403		{object}	CommonResponse		"User is disabled"
+//	@Failure		500		{object}	CommonResponse		"Internal server errors"
+//	@Router			/auth/password/reset [post]
+func (h *authHandler) ResetPassword(c *fiber.Ctx) error {
+	var reset model.ResetPassword
+	if err := c.BodyParser(&reset); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	reset.Token = strings.ToLower(reset.Token)
+	authErrors := model.Validate(reset)
+	if len(authErrors) > 0 {
+		return h.infoErrorResponse(c, errors.New("invalid reset password body"), fiber.StatusBadRequest, "Wrong body", authErrors)
+	}
+	if err := h.service.ResetPassword(c.Context(), reset); err != nil {
+		if err == model.TokenExpired || err == model.TokenNotFound || err == model.UserNotFound {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Reset token is invalid or expired")
+		} else if err == model.UserDisabled {
+			return h.infoErrorResponse(c, err, fiber.StatusForbidden, "User is disabled")
+		}
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to reset password")
+	}
+	c.ClearCookie(refreshTokenCookie)
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
@@ -3,7 +3,6 @@ package handler
 import (
 	"errors"
 	"github.com/galushkoart/finance-api/internal/model"
-	"github.com/galushkoart/finance-api/internal/service"
 	"github.com/galushkoart/finance-api/mock"
 	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/golang/mock/gomock"
@@ -63,7 +62,7 @@ var signUpTestData = []struct {
 		name:             utils.TestName("user already exists"),
 		body:             model.SignUp{Username: "test", Email: "test@email.com", Password: "qwerty"},
 		expectedCode:     400,
-		serviceError:     service.UserAlreadyExists,
+		serviceError:     model.UserAlreadyExists,
 		expectedResponse: CommonResponse{Message: "User with such username or email already exists", Code: 400},
 	},
 	{
@@ -217,6 +216,13 @@ var refreshTestData = []struct {
 		serviceError:     model.TokenExpired,
 		expectedResponse: CommonResponse{Message: "Active refresh token not found. Please sign-in", Code: 400},
 	},
+	{
+		name:             utils.TestName("user is disabled"),
+		refreshToken:     "refresh_token",
+		expectedCode:     403,
+		serviceError:     model.UserDisabled,
+		expectedResponse: CommonResponse{Message: "User is disabled", Code: 403},
+	},
 	{
 		name:             utils.TestName("failed to refresh token"),
 		refreshToken:     "refresh_token",
@@ -225,3 +231,125 @@ var refreshTestData = []struct {
 		expectedResponse: CommonResponse{Message: "Failed to refresh token. Please sign-in", Code: 500},
 	},
 }
+
+func TestForgotPassword(t *testing.T) {
+	mockService := mock.NewMockAuthService(gomock.NewController(t))
+	app := setupFiberTest(&Handler{ah: authHandler{service: mockService}})
+	for _, td := range forgotPasswordTestData {
+		t.Run(td.name, func(t *testing.T) {
+			if !td.wrongBody && !td.wrongContentType {
+				mockService.EXPECT().ForgotPassword(gomock.Any(), td.expectedEmail).Return(td.serviceError)
+			}
+			response, err := app.Test(utils.PostRequest("/auth/password/forgot", td.body, td.wrongContentType))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var forgotPasswordTestData = []struct {
+	name             string
+	body             model.ForgotPassword
+	expectedEmail    string
+	expectedCode     int
+	serviceError     error
+	wrongContentType bool
+	wrongBody        bool
+	expectedResponse CommonResponse
+}{
+	{
+		name:             utils.TestName("successful request"),
+		body:             model.ForgotPassword{Email: "Test@Email.com"},
+		expectedEmail:    "test@email.com",
+		expectedCode:     200,
+		expectedResponse: CommonResponse{Message: "If the email is registered, password reset instructions are sent to it", Code: 200},
+	},
+	{
+		name:             utils.TestName("wrong content type"),
+		body:             model.ForgotPassword{Email: "test@email.com"},
+		expectedCode:     400,
+		wrongContentType: true,
+		expectedResponse: CommonResponse{Message: "Wrong content type", Code: 400},
+	},
+	{
+		name:             utils.TestName("wrong body"),
+		body:             model.ForgotPassword{Email: "not@an_email"},
+		expectedCode:     400,
+		wrongBody:        true,
+		expectedResponse: CommonResponse{Message: "Wrong body", Code: 400, AuthErrors: []*model.AuthError{{Field: "Email", Rule: "email"}}},
+	},
+	{
+		name:             utils.TestName("failed request"),
+		body:             model.ForgotPassword{Email: "test@email.com"},
+		expectedEmail:    "test@email.com",
+		expectedCode:     500,
+		serviceError:     errors.New("failed to insert token"),
+		expectedResponse: CommonResponse{Message: "Failed to request password reset", Code: 500},
+	},
+}
+
+func TestResetPassword(t *testing.T) {
+	mockService := mock.NewMockAuthService(gomock.NewController(t))
+	app := setupFiberTest(&Handler{ah: authHandler{service: mockService}})
+	for _, td := range resetPasswordTestData {
+		t.Run(td.name, func(t *testing.T) {
+			if !td.wrongBody {
+				mockService.EXPECT().ResetPassword(gomock.Any(), td.body).Return(td.serviceError)
+			}
+			response, err := app.Test(utils.PostRequest("/auth/password/reset", td.body, false))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+const resetToken = "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+
+var resetPasswordTestData = []struct {
+	name             string
+	body             model.ResetPassword
+	expectedCode     int
+	serviceError     error
+	wrongBody        bool
+	expectedResponse CommonResponse
+}{
+	{
+		name:             utils.TestName("successful reset"),
+		body:             model.ResetPassword{Token: resetToken, Password: "qwerty"},
+		expectedCode:     200,
+		expectedResponse: CommonResponse{Message: "successful", Code: 200},
+	},
+	{
+		name:             utils.TestName("wrong body"),
+		body:             model.ResetPassword{Token: "token", Password: "qwe"},
+		expectedCode:     400,
+		wrongBody:        true,
+		expectedResponse: CommonResponse{Message: "Wrong body", Code: 400, AuthErrors: []*model.AuthError{{Field: "Token", Rule: "len"}, {Field: "Password", Rule: "min"}}},
+	},
+	{
+		name:             utils.TestName("used token"),
+		body:             model.ResetPassword{Token: resetToken, Password: "qwerty"},
+		expectedCode:     400,
+		serviceError:     model.TokenNotFound,
+		expectedResponse: CommonResponse{Message: "Reset token is invalid or expired", Code: 400},
+	},
+	{
+		name:             utils.TestName("expired token"),
+		body:             model.ResetPassword{Token: resetToken, Password: "qwerty"},
+		expectedCode:     400,
+		serviceError:     model.TokenExpired,
+		expectedResponse: CommonResponse{Message: "Reset token is invalid or expired", Code: 400},
+	},
+	{
+		name:             utils.TestName("disabled user"),
+		body:             model.ResetPassword{Token: resetToken, Password: "qwerty"},
+		expectedCode:     403,
+		serviceError:     model.UserDisabled,
+		expectedResponse: CommonResponse{Message: "User is disabled", Code: 403},
+	},
+	{
+		name:             utils.TestName("failed reset"),
+		body:             model.ResetPassword{Token: resetToken, Password: "qwerty"},
+		expectedCode:     500,
+		serviceError:     errors.New("failed to update user"),
+		expectedResponse: CommonResponse{Message: "Failed to reset password", Code: 500},
+	},
+}
@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"regexp"
+	"strings"
+	"time"
+)
+
+type conversionHandler struct {
+	service service.ConversionService
+}
+
+var chLog zerolog.Logger
+
+var currencyPattern = regexp.MustCompile(`^[A-Z]{3,5}$`)
+
+func (h *conversionHandler) infoErrorResponse(c *fiber.Ctx, err error, statusCode int, message string) error {
+	return infoErrorResponse(c, &chLog, err, statusCode, message)
+}
+
+// Convert godoc
+//
+//	@Summary		Convert
+//	@Tags			Conversion
+//	@Description	Convert amount between currencies with the last rate on or before the date. Rate is resolved from direct
+//	@Description	or inverse fx symbol or triangulated via USD. Fx symbols unknown to the service are loaded from TwelveData
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				convert
+//	@Produce		json
+//	@Param			from	query		string				true	"Currency code of the amount"
+//	@Param			to		query		string				true	"Target currency code"
+//	@Param			amount	query		string				false	"Decimal amount to convert"	default(1)
+//	@Param			date	query		string				false	"Date of the rate in YYYY-MM-DD format. Today by default"
+//	@Success		200		{object}	model.Conversion	"Successful response"
+//	@Failure		400,404	{object}	CommonResponse		"Client request error"
+//	@Failure		401		{object}	CommonResponse		"Unauthorized"
+//	@Failure		500		{object}	CommonResponse		"Internal server error"
+//	@Failure		503		{object}	CommonResponse		"Market data provider rate limit exceeded or provider is unavailable"
+//	@Header			503		{integer}	Retry-After			"Seconds to wait before retry"
+//	@Router			/api/v1/convert [get]
+func (h *conversionHandler) Convert(c *fiber.Ctx) error {
+	from, to := strings.ToUpper(c.Query("from")), strings.ToUpper(c.Query("to"))
+	if !currencyPattern.MatchString(from) || !currencyPattern.MatchString(to) {
+		return h.infoErrorResponse(c, errors.New("invalid currency"), fiber.StatusBadRequest, "'from' and 'to' must be currency codes")
+	}
+	amount := model.Decimal("1")
+	if c.Query("amount") != "" {
+		var err error
+		if amount, err = model.ParseDecimal(c.Query("amount")); err != nil {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "'amount' must be a decimal number")
+		}
+	}
+	date := time.Now().UTC().Truncate(24 * time.Hour)
+	if c.Query("date") != "" {
+		var err error
+		if date, err = time.Parse(model.DateLayout, c.Query("date")); err != nil {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "'date' must be a date in YYYY-MM-DD format")
+		}
+	}
+	conversion, err := h.service.Convert(c.Context(), from, to, amount, date)
+	if err != nil {
+		return conversionErrorResponse(c, &chLog, err, from, to)
+	}
+	return c.Status(fiber.StatusOK).JSON(conversion)
+}
+
+// conversionErrorResponse returns 404 if there is no rate, 503 if rate couldn't be loaded from market data provider and 500 otherwise
+func conversionErrorResponse(c *fiber.Ctx, logger *zerolog.Logger, err error, from string, to string) error {
+	if err == model.RateNotFound {
+		return infoErrorResponse(c, logger, err, fiber.StatusNotFound, fmt.Sprintf("rate of %s to %s not found", from, to))
+	} else if isProviderError(err) {
+		return providerErrorResponse(c, logger, err)
+	}
+	return errorErrorResponse(c, logger, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to convert %s to %s", from, to))
+}
@@ -0,0 +1,97 @@
+package handler
+
+import (
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/conversion_service_mock.go -source=../service/conversion_service.go ConversionService
+
+func TestConvert(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockConversionService(controller)
+	app := setupFiberTest(&Handler{ch: conversionHandler{service: mockService}})
+	for _, td := range convertTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedCode != 400 {
+				mockService.EXPECT().Convert(gomock.Any(), "EUR", "JPY", td.amount, time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)).Return(td.conversion, td.serviceError)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/convert?" + td.query))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var eurJpy = model.Conversion{
+	Rate:   model.Rate{From: "EUR", To: "JPY", Date: "2023-06-02", Value: "150.0357", Path: []string{"EUR/USD", "USD/JPY"}},
+	Amount: "10",
+	Result: "1500.357",
+}
+
+var convertTests = []struct {
+	name             string
+	query            string
+	amount           model.Decimal
+	conversion       model.Conversion
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("convert successfully"),
+		query:            "from=eur&to=JPY&amount=10&date=2023-06-02",
+		amount:           "10",
+		conversion:       eurJpy,
+		expectedCode:     200,
+		expectedResponse: eurJpy,
+	},
+	{
+		name:             utils.TestName("rate not found"),
+		query:            "from=EUR&to=JPY&date=2023-06-02",
+		amount:           "1",
+		serviceError:     model.RateNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "rate of EUR to JPY not found"},
+	},
+	{
+		name:             utils.TestName("provider rate limit exceeded"),
+		query:            "from=EUR&to=JPY&date=2023-06-02",
+		amount:           "1",
+		serviceError:     &ratelimit.LimitExceededError{Name: "twelveData", RetryAfter: 1500 * time.Millisecond},
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is busy. Retry after 2 seconds"},
+	},
+	{
+		name:             utils.TestName("convert failed"),
+		query:            "from=EUR&to=JPY&date=2023-06-02",
+		amount:           "1",
+		serviceError:     errors.New("db is down"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to convert EUR to JPY"},
+	},
+	{
+		name:             utils.TestName("missing currency"),
+		query:            "from=EUR",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'from' and 'to' must be currency codes"},
+	},
+	{
+		name:             utils.TestName("invalid amount"),
+		query:            "from=EUR&to=JPY&amount=ten",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'amount' must be a decimal number"},
+	},
+	{
+		name:             utils.TestName("invalid date"),
+		query:            "from=EUR&to=JPY&date=02.06.2023",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'date' must be a date in YYYY-MM-DD format"},
+	},
+}
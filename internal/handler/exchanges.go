@@ -0,0 +1,351 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/GalushkoArt/simpleCache"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/rs/zerolog"
+	"strings"
+	"time"
+)
+
+type exchangeHandler struct {
+	service     service.ExchangeService
+	calendars   service.CalendarService
+	symbolCache simpleCache.GenericCache[model.Symbol]
+}
+
+var ehLog zerolog.Logger
+
+func (h *exchangeHandler) warnErrorResponse(c *fiber.Ctx, err error, statusCode int, message string) error {
+	return warnErrorResponse(c, &ehLog, err, statusCode, message)
+}
+
+func (h *exchangeHandler) infoErrorResponse(c *fiber.Ctx, err error, statusCode int, message string) error {
+	return infoErrorResponse(c, &ehLog, err, statusCode, message)
+}
+
+// evictSymbols removes cached symbols, which embed exchange data
+func (h *exchangeHandler) evictSymbols(symbols []model.Symbol) {
+	for _, symbol := range symbols {
+		h.symbolCache.Delete(symbol.Symbol)
+	}
+}
+
+// notFoundOr returns 404 if exchange, symbol or calendar is not found and statusCode with message otherwise
+func (h *exchangeHandler) notFoundOr(c *fiber.Ctx, err error, statusCode int, message string) error {
+	if err == model.ExchangeNotFound || err == model.SymbolNotFound || err == model.CalendarNotFound {
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, err.Error())
+	}
+	return h.warnErrorResponse(c, err, statusCode, message)
+}
+
+// GetExchanges godoc
+//
+//	@Summary		GetExchanges
+//	@Tags			Exchanges
+//	@Description	Get all exchanges ordered by MIC code
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-exchanges
+//	@Produce		json
+//	@Success		200	{array}		model.Exchange	"Successful response"
+//	@Failure		401	{object}	CommonResponse	"Unauthorized"
+//	@Failure		500	{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/exchanges [get]
+func (h *exchangeHandler) GetExchanges(c *fiber.Ctx) error {
+	exchanges, err := h.service.GetAll(c.Context())
+	if err != nil {
+		return h.warnErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to get exchanges")
+	}
+	return c.Status(fiber.StatusOK).JSON(exchanges)
+}
+
+// GetExchange godoc
+//
+//	@Summary		GetExchange
+//	@Tags			Exchanges
+//	@Description	Get exchange by MIC code
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-exchange
+//	@Produce		json
+//	@Param			mic_code	path		string			true	"MIC code of the exchange"
+//	@Success		200			{object}	model.Exchange	"Successful response"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		404			{object}	CommonResponse	"Exchange not found"
+//	@Failure		500			{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/exchanges/{mic_code} [get]
+func (h *exchangeHandler) GetExchange(c *fiber.Ctx) error {
+	micCode := c.Params("mic_code")
+	exchange, err := h.service.GetByMicCode(c.Context(), micCode)
+	if err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get %s exchange", micCode))
+	}
+	return c.Status(fiber.StatusOK).JSON(exchange)
+}
+
+// AddExchange godoc
+//
+//	@Summary		AddExchange
+//	@Tags			Exchanges
+//	@Description	Add new exchange. MIC code must be unique and timezone, if set, must be an IANA time zone name
+//	@Security		ApiKeyAuth[admin]
+//	@ID				add-exchange
+//	@Accept			json
+//	@Produce		json
+//	@Param			input	body		model.Exchange	true	"New exchange data"
+//	@Success		201		{object}	CommonResponse	"Added successfully"
+//	@Failure		400		{object}	CommonResponse	"Client request errors"
+//	@Failure		401		{object}	CommonResponse	"Unauthorized"
+//	@Failure		409		{object}	CommonResponse	"Exchange already exists"
+//	@Failure		500		{object}	CommonResponse	"Internal server errors"
+//	@Router			/api/v1/exchanges [post]
+func (h *exchangeHandler) AddExchange(c *fiber.Ctx) error {
+	var exchange model.Exchange
+	if err := c.BodyParser(&exchange); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	exchange.Name = strings.TrimSpace(exchange.Name)
+	exchange.MicCode = strings.TrimSpace(exchange.MicCode)
+	if exchange.Name == "" || exchange.MicCode == "" {
+		return h.infoErrorResponse(c, errors.New("name or mic code is empty"), fiber.StatusBadRequest, "'name' and 'mic_code' must not be empty")
+	}
+	if exchange.Timezone != "" {
+		if err := validateTimezone(exchange.Timezone); err != nil {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, invalidTimezoneMessage)
+		}
+	}
+	if err := h.service.Add(c.Context(), exchange); err != nil {
+		if err == model.ExchangeAlreadyExists {
+			return h.infoErrorResponse(c, err, fiber.StatusConflict, fmt.Sprintf("exchange %s already exists", exchange.MicCode))
+		}
+		return h.warnErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to add %s exchange", exchange.MicCode))
+	}
+	return c.Status(fiber.StatusCreated).JSON(CommonResponse{Code: fiber.StatusCreated, Message: "successful"})
+}
+
+const invalidTimezoneMessage = "'timezone' must be an IANA time zone name"
+
+// validateTimezone checks that timezone is known IANA time zone name. Empty and "Local" are rejected as they aren't zone names
+func validateTimezone(timezone string) error {
+	if timezone == "" || timezone == "Local" {
+		return fmt.Errorf("unknown timezone %q", timezone)
+	}
+	_, err := time.LoadLocation(timezone)
+	return err
+}
+
+// UpdateExchange godoc
+//
+//	@Summary		UpdateExchange
+//	@Tags			Exchanges
+//	@Description	Update name, country or timezone of the exchange. Timezone must be an IANA time zone name
+//	@Security		ApiKeyAuth[admin]
+//	@ID				update-exchange
+//	@Accept			json
+//	@Produce		json
+//	@Param			mic_code	path		string					true	"MIC code of the exchange"
+//	@Param			input		body		model.UpdateExchange	true	"Update exchange data"
+//	@Success		200			{object}	CommonResponse			"Updated successfully"
+//	@Failure		400,404		{object}	CommonResponse			"Client request errors"
+//	@Failure		401			{object}	CommonResponse			"Unauthorized"
+//	@Failure		500			{object}	CommonResponse			"Internal server errors"
+//	@Router			/api/v1/exchanges/{mic_code} [put]
+func (h *exchangeHandler) UpdateExchange(c *fiber.Ctx) error {
+	micCode := c.Params("mic_code")
+	var exchange model.UpdateExchange
+	if err := c.BodyParser(&exchange); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	if exchange.Timezone != nil {
+		if err := validateTimezone(*exchange.Timezone); err != nil {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, invalidTimezoneMessage)
+		}
+	}
+	symbols, err := h.service.GetSymbols(c.Context(), micCode)
+	if err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to update %s exchange", micCode))
+	}
+	if err := h.service.Update(c.Context(), micCode, exchange); err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to update %s exchange", micCode))
+	}
+	h.evictSymbols(symbols)
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
+
+// DeleteExchange godoc
+//
+//	@Summary		DeleteExchange
+//	@Tags			Exchanges
+//	@Description	Delete exchange. Symbols are detached from the exchange but not deleted
+//	@Security		ApiKeyAuth[admin]
+//	@ID				delete-exchange
+//	@Produce		json
+//	@Param			mic_code	path		string			true	"MIC code of the exchange"
+//	@Success		200			{object}	CommonResponse	"Deleted successfully"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		404			{object}	CommonResponse	"Exchange not found"
+//	@Failure		500			{object}	CommonResponse	"Internal server errors"
+//	@Router			/api/v1/exchanges/{mic_code} [delete]
+func (h *exchangeHandler) DeleteExchange(c *fiber.Ctx) error {
+	micCode := c.Params("mic_code")
+	symbols, err := h.service.GetSymbols(c.Context(), micCode)
+	if err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to delete %s exchange", micCode))
+	}
+	if err := h.service.Delete(c.Context(), micCode); err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to delete %s exchange", micCode))
+	}
+	h.evictSymbols(symbols)
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
+
+// GetExchangeStatus godoc
+//
+//	@Summary		GetExchangeStatus
+//	@Tags			Exchanges
+//	@Description	Get whether exchange is open now and its next open and close according to its trading calendar
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-exchange-status
+//	@Produce		json
+//	@Param			mic_code	path		string				true	"MIC code of the exchange"
+//	@Success		200			{object}	model.MarketStatus	"Successful response"
+//	@Failure		401			{object}	CommonResponse		"Unauthorized"
+//	@Failure		404			{object}	CommonResponse		"Trading calendar not found"
+//	@Router			/api/v1/exchanges/{mic_code}/status [get]
+func (h *exchangeHandler) GetExchangeStatus(c *fiber.Ctx) error {
+	micCode := c.Params("mic_code")
+	status, err := h.calendars.Status(micCode, time.Now())
+	if err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get status of %s exchange", micCode))
+	}
+	return c.Status(fiber.StatusOK).JSON(status)
+}
+
+// GetCalendar godoc
+//
+//	@Summary		GetCalendar
+//	@Tags			Exchanges
+//	@Description	Get regular session, holidays and half-days of the exchange
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-exchange-calendar
+//	@Produce		json
+//	@Param			mic_code	path		string					true	"MIC code of the exchange"
+//	@Success		200			{object}	model.TradingCalendar	"Successful response"
+//	@Failure		401			{object}	CommonResponse			"Unauthorized"
+//	@Failure		404			{object}	CommonResponse			"Trading calendar not found"
+//	@Router			/api/v1/exchanges/{mic_code}/calendar [get]
+func (h *exchangeHandler) GetCalendar(c *fiber.Ctx) error {
+	micCode := c.Params("mic_code")
+	calendar, err := h.calendars.Get(micCode)
+	if err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get calendar of %s exchange", micCode))
+	}
+	return c.Status(fiber.StatusOK).JSON(calendar)
+}
+
+// SaveCalendar godoc
+//
+//	@Summary		SaveCalendar
+//	@Tags			Exchanges
+//	@Description	Create or replace trading calendar of the exchange
+//	@Security		ApiKeyAuth[admin]
+//	@ID				save-exchange-calendar
+//	@Accept			json
+//	@Produce		json
+//	@Param			mic_code	path		string					true	"MIC code of the exchange"
+//	@Param			input		body		model.TradingCalendar	true	"Trading calendar"
+//	@Success		200			{object}	CommonResponse			"Saved successfully"
+//	@Failure		400			{object}	CommonResponse			"Client request errors"
+//	@Failure		401			{object}	CommonResponse			"Unauthorized"
+//	@Failure		500			{object}	CommonResponse			"Internal server errors"
+//	@Router			/api/v1/exchanges/{mic_code}/calendar [put]
+func (h *exchangeHandler) SaveCalendar(c *fiber.Ctx) error {
+	var calendar model.TradingCalendar
+	if err := c.BodyParser(&calendar); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	calendar.MicCode = c.Params("mic_code")
+	if err := h.calendars.Save(c.Context(), calendar); err != nil {
+		if errors.Is(err, model.InvalidCalendar) {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+		}
+		return h.warnErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to save calendar of %s exchange", calendar.MicCode))
+	}
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
+
+// GetExchangeSymbols godoc
+//
+//	@Summary		GetExchangeSymbols
+//	@Tags			Exchanges
+//	@Description	Get symbols traded on the exchange ordered by symbol
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-exchange-symbols
+//	@Produce		json
+//	@Param			mic_code	path		string			true	"MIC code of the exchange"
+//	@Success		200			{array}		model.Symbol	"Successful response"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		404			{object}	CommonResponse	"Exchange not found"
+//	@Failure		500			{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/exchanges/{mic_code}/symbols [get]
+func (h *exchangeHandler) GetExchangeSymbols(c *fiber.Ctx) error {
+	micCode := c.Params("mic_code")
+	symbols, err := h.service.GetSymbols(c.Context(), micCode)
+	if err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get symbols of %s exchange", micCode))
+	}
+	return c.Status(fiber.StatusOK).JSON(symbols)
+}
+
+// AttachSymbol godoc
+//
+//	@Summary		AttachSymbol
+//	@Tags			Exchanges
+//	@Description	Attach symbol to the exchange
+//	@Security		ApiKeyAuth[admin]
+//	@ID				attach-exchange-symbol
+//	@Produce		json
+//	@Param			mic_code	path		string			true	"MIC code of the exchange"
+//	@Param			symbol		path		string			true	"Symbol name. Use '-' instead of '/' for fx pairs"
+//	@Success		200			{object}	CommonResponse	"Attached successfully"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		404			{object}	CommonResponse	"Exchange or symbol not found"
+//	@Failure		500			{object}	CommonResponse	"Internal server errors"
+//	@Router			/api/v1/exchanges/{mic_code}/symbols/{symbol} [put]
+func (h *exchangeHandler) AttachSymbol(c *fiber.Ctx) error {
+	micCode := c.Params("mic_code")
+	symbol := strings.Replace(c.Params("symbol"), "-", "/", 1)
+	if err := h.service.AttachSymbol(c.Context(), micCode, symbol); err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to attach %s symbol to %s exchange", symbol, micCode))
+	}
+	h.symbolCache.Delete(symbol)
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
+
+// DetachSymbol godoc
+//
+//	@Summary		DetachSymbol
+//	@Tags			Exchanges
+//	@Description	Detach symbol from the exchange
+//	@Security		ApiKeyAuth[admin]
+//	@ID				detach-exchange-symbol
+//	@Produce		json
+//	@Param			mic_code	path		string			true	"MIC code of the exchange"
+//	@Param			symbol		path		string			true	"Symbol name. Use '-' instead of '/' for fx pairs"
+//	@Success		200			{object}	CommonResponse	"Detached successfully"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		404			{object}	CommonResponse	"Exchange or symbol not found"
+//	@Failure		500			{object}	CommonResponse	"Internal server errors"
+//	@Router			/api/v1/exchanges/{mic_code}/symbols/{symbol} [delete]
+func (h *exchangeHandler) DetachSymbol(c *fiber.Ctx) error {
+	micCode := c.Params("mic_code")
+	symbol := strings.Replace(c.Params("symbol"), "-", "/", 1)
+	if err := h.service.DetachSymbol(c.Context(), micCode, symbol); err != nil {
+		return h.notFoundOr(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to detach %s symbol from %s exchange", symbol, micCode))
+	}
+	h.symbolCache.Delete(symbol)
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
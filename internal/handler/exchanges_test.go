@@ -0,0 +1,357 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/exchange_service_mock.go -source=../service/exchange_service.go ExchangeService
+//go:generate mockgen -package mock -destination ../../mock/calendar_service_mock.go -source=../service/calendar_service.go CalendarService
+
+func TestGetExchange(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockExchangeService(controller)
+	app := setupFiberTest(&Handler{eh: exchangeHandler{service: mockService}}, utils.TestAuthMiddleware)
+	for _, td := range getExchangeTests {
+		t.Run(td.name, func(t *testing.T) {
+			mockService.EXPECT().GetByMicCode(gomock.Any(), td.micCode).Return(td.exchange, td.serviceError)
+			response, err := app.Test(utils.GetRequest("/api/v1/exchanges/"+td.micCode, map[string]string{"Role": string(model.ClientRole)}))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var getExchangeTests = []struct {
+	name             string
+	micCode          string
+	exchange         model.Exchange
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("get exchange successfully"),
+		micCode:          "XNGS",
+		exchange:         model.Exchange{Name: "NASDAQ", MicCode: "XNGS", Country: "United States", Timezone: "America/New_York"},
+		expectedCode:     200,
+		expectedResponse: model.Exchange{Name: "NASDAQ", MicCode: "XNGS", Country: "United States", Timezone: "America/New_York"},
+	},
+	{
+		name:             utils.TestName("exchange not found"),
+		micCode:          "XXXX",
+		serviceError:     model.ExchangeNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "exchange not found"},
+	},
+	{
+		name:             utils.TestName("get exchange failed"),
+		micCode:          "XNGS",
+		serviceError:     errors.New("db is down"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to get XNGS exchange"},
+	},
+}
+
+func TestAddExchange(t *testing.T) {
+	mockService := mock.NewMockExchangeService(gomock.NewController(t))
+	app := setupFiberTest(&Handler{eh: exchangeHandler{service: mockService}}, utils.TestAuthMiddleware)
+	for _, td := range addExchangeTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedCode != 400 && td.role == model.AdminRole {
+				mockService.EXPECT().Add(gomock.Any(), td.exchange).Return(td.serviceError)
+			}
+			response, err := app.Test(utils.PostRequest("/api/v1/exchanges", td.exchange, td.wrongContentType, map[string]string{"Role": string(td.role)}))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var addExchangeTests = []struct {
+	name             string
+	role             model.Role
+	exchange         model.Exchange
+	serviceError     error
+	wrongContentType bool
+	expectedCode     int
+	expectedResponse CommonResponse
+}{
+	{
+		name:             utils.TestName("add exchange successfully"),
+		role:             model.AdminRole,
+		exchange:         model.Exchange{Name: "NASDAQ", MicCode: "XNGS"},
+		expectedCode:     201,
+		expectedResponse: CommonResponse{Code: 201, Message: "successful"},
+	},
+	{
+		name:             utils.TestName("add exchange with client role"),
+		role:             model.ClientRole,
+		exchange:         model.Exchange{Name: "NASDAQ", MicCode: "XNGS"},
+		expectedCode:     401,
+		expectedResponse: CommonResponse{Code: 401, Message: "you don't have permissions for this endpoint"},
+	},
+	{
+		name:             utils.TestName("add exchange with wrong content type"),
+		role:             model.AdminRole,
+		exchange:         model.Exchange{Name: "NASDAQ", MicCode: "XNGS"},
+		wrongContentType: true,
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "Wrong content type"},
+	},
+	{
+		name:             utils.TestName("add exchange without mic code"),
+		role:             model.AdminRole,
+		exchange:         model.Exchange{Name: "NASDAQ"},
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'name' and 'mic_code' must not be empty"},
+	},
+	{
+		name:             utils.TestName("add exchange with timezone"),
+		role:             model.AdminRole,
+		exchange:         model.Exchange{Name: "NASDAQ", MicCode: "XNGS", Timezone: "America/New_York"},
+		expectedCode:     201,
+		expectedResponse: CommonResponse{Code: 201, Message: "successful"},
+	},
+	{
+		name:             utils.TestName("add exchange with unknown timezone"),
+		role:             model.AdminRole,
+		exchange:         model.Exchange{Name: "NASDAQ", MicCode: "XNGS", Timezone: "Mars/Olympus"},
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'timezone' must be an IANA time zone name"},
+	},
+	{
+		name:             utils.TestName("add existing exchange"),
+		role:             model.AdminRole,
+		exchange:         model.Exchange{Name: "NASDAQ", MicCode: "XNGS"},
+		serviceError:     model.ExchangeAlreadyExists,
+		expectedCode:     409,
+		expectedResponse: CommonResponse{Code: 409, Message: "exchange XNGS already exists"},
+	},
+}
+
+func TestAttachSymbol(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockExchangeService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	app := setupFiberTest(&Handler{eh: exchangeHandler{service: mockService, symbolCache: mockCache}}, utils.TestAuthMiddleware)
+	for _, td := range attachSymbolTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.role == model.AdminRole {
+				mockService.EXPECT().AttachSymbol(gomock.Any(), "XNGS", td.expectedSymbol).Return(td.serviceError)
+			}
+			if td.expectedCode == 200 {
+				mockCache.EXPECT().Delete(td.expectedSymbol).Return(nil)
+			}
+			response, err := app.Test(utils.PutRequest("/api/v1/exchanges/XNGS/symbols/"+td.symbol, nil, false, map[string]string{"Role": string(td.role)}))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var exchangeSymbols = []model.Symbol{{Symbol: "AAPL"}, {Symbol: "MSFT"}}
+
+var changeExchangeTests = []struct {
+	name             string
+	method           string
+	symbolsError     error
+	serviceError     error
+	expectedCode     int
+	expectedResponse CommonResponse
+}{
+	{
+		name:             utils.TestName("update exchange evicts its symbols"),
+		method:           "PUT",
+		expectedCode:     200,
+		expectedResponse: CommonResponse{Code: 200, Message: "successful"},
+	},
+	{
+		name:             utils.TestName("delete exchange evicts its symbols"),
+		method:           "DELETE",
+		expectedCode:     200,
+		expectedResponse: CommonResponse{Code: 200, Message: "successful"},
+	},
+	{
+		name:             utils.TestName("update unknown exchange"),
+		method:           "PUT",
+		symbolsError:     model.ExchangeNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "exchange not found"},
+	},
+	{
+		name:             utils.TestName("delete exchange failed"),
+		method:           "DELETE",
+		serviceError:     errors.New("db is down"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to delete XNGS exchange"},
+	},
+}
+
+func TestChangeExchange(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockExchangeService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	app := setupFiberTest(&Handler{eh: exchangeHandler{service: mockService, symbolCache: mockCache}}, utils.TestAuthMiddleware)
+	name := "Nasdaq"
+	for _, td := range changeExchangeTests {
+		t.Run(td.name, func(t *testing.T) {
+			mockService.EXPECT().GetSymbols(gomock.Any(), "XNGS").Return(exchangeSymbols, td.symbolsError)
+			if td.symbolsError == nil {
+				if td.method == "PUT" {
+					mockService.EXPECT().Update(gomock.Any(), "XNGS", model.UpdateExchange{Name: &name}).Return(td.serviceError)
+				} else {
+					mockService.EXPECT().Delete(gomock.Any(), "XNGS").Return(td.serviceError)
+				}
+			}
+			if td.expectedCode == 200 {
+				for _, symbol := range exchangeSymbols {
+					mockCache.EXPECT().Delete(symbol.Symbol).Return(nil)
+				}
+			}
+			headers := map[string]string{"Role": string(model.AdminRole)}
+			request := utils.DeleteRequest("/api/v1/exchanges/XNGS", nil, false, headers)
+			if td.method == "PUT" {
+				request = utils.PutRequest("/api/v1/exchanges/XNGS", model.UpdateExchange{Name: &name}, false, headers)
+			}
+			response, err := app.Test(request)
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+func TestUpdateExchangeTimezone(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockExchangeService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	app := setupFiberTest(&Handler{eh: exchangeHandler{service: mockService, symbolCache: mockCache}}, utils.TestAuthMiddleware)
+	headers := map[string]string{"Role": string(model.AdminRole)}
+	for _, td := range []struct {
+		name             string
+		timezone         string
+		expectedCode     int
+		expectedResponse CommonResponse
+	}{
+		{name: utils.TestName("update exchange timezone successfully"), timezone: "America/New_York", expectedCode: 200, expectedResponse: CommonResponse{Code: 200, Message: "successful"}},
+		{name: utils.TestName("update exchange with unknown timezone"), timezone: "Mars/Olympus", expectedCode: 400, expectedResponse: CommonResponse{Code: 400, Message: "'timezone' must be an IANA time zone name"}},
+		{name: utils.TestName("update exchange with empty timezone"), timezone: "", expectedCode: 400, expectedResponse: CommonResponse{Code: 400, Message: "'timezone' must be an IANA time zone name"}},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			timezone := td.timezone
+			if td.expectedCode == 200 {
+				mockService.EXPECT().GetSymbols(gomock.Any(), "XNGS").Return(nil, nil)
+				mockService.EXPECT().Update(gomock.Any(), "XNGS", model.UpdateExchange{Timezone: &timezone}).Return(nil)
+			}
+			response, err := app.Test(utils.PutRequest("/api/v1/exchanges/XNGS", model.UpdateExchange{Timezone: &timezone}, false, headers))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var attachSymbolTests = []struct {
+	name             string
+	role             model.Role
+	symbol           string
+	expectedSymbol   string
+	serviceError     error
+	expectedCode     int
+	expectedResponse CommonResponse
+}{
+	{
+		name:             utils.TestName("attach symbol successfully"),
+		role:             model.AdminRole,
+		symbol:           "EUR-USD",
+		expectedSymbol:   "EUR/USD",
+		expectedCode:     200,
+		expectedResponse: CommonResponse{Code: 200, Message: "successful"},
+	},
+	{
+		name:             utils.TestName("attach symbol with client role"),
+		role:             model.ClientRole,
+		symbol:           "AAPL",
+		expectedCode:     401,
+		expectedResponse: CommonResponse{Code: 401, Message: "you don't have permissions for this endpoint"},
+	},
+	{
+		name:             utils.TestName("attach unknown symbol"),
+		role:             model.AdminRole,
+		symbol:           "UNKNOWN",
+		expectedSymbol:   "UNKNOWN",
+		serviceError:     model.SymbolNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "symbol not found"},
+	},
+	{
+		name:             utils.TestName("attach symbol failed"),
+		role:             model.AdminRole,
+		symbol:           "AAPL",
+		expectedSymbol:   "AAPL",
+		serviceError:     errors.New("db is down"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to attach AAPL symbol to XNGS exchange"},
+	},
+}
+
+func TestSaveCalendar(t *testing.T) {
+	mockCalendars := mock.NewMockCalendarService(gomock.NewController(t))
+	app := setupFiberTest(&Handler{eh: exchangeHandler{calendars: mockCalendars}}, utils.TestAuthMiddleware)
+	for _, td := range saveCalendarTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.role == model.AdminRole {
+				expected := td.calendar
+				expected.MicCode = "XNYS"
+				mockCalendars.EXPECT().Save(gomock.Any(), expected).Return(td.serviceError)
+			}
+			response, err := app.Test(utils.PutRequest("/api/v1/exchanges/XNYS/calendar", td.calendar, false, map[string]string{"Role": string(td.role)}))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var saveCalendarTests = []struct {
+	name             string
+	role             model.Role
+	calendar         model.TradingCalendar
+	serviceError     error
+	expectedCode     int
+	expectedResponse CommonResponse
+}{
+	{
+		name:             utils.TestName("save calendar successfully"),
+		role:             model.AdminRole,
+		calendar:         model.TradingCalendar{Timezone: "America/New_York", Open: "09:30", Close: "16:00", Holidays: []string{"2023-07-04"}},
+		expectedCode:     200,
+		expectedResponse: CommonResponse{Code: 200, Message: "successful"},
+	},
+	{
+		name:             utils.TestName("save calendar with client role"),
+		role:             model.ClientRole,
+		expectedCode:     401,
+		expectedResponse: CommonResponse{Code: 401, Message: "you don't have permissions for this endpoint"},
+	},
+	{
+		name:             utils.TestName("save invalid calendar"),
+		role:             model.AdminRole,
+		calendar:         model.TradingCalendar{Timezone: "America/New_York", Open: "9h", Close: "16:00"},
+		serviceError:     fmt.Errorf("%w: 9h must be a time in HH:MM format", model.InvalidCalendar),
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "invalid trading calendar: 9h must be a time in HH:MM format"},
+	},
+}
+
+func TestGetExchangeStatus(t *testing.T) {
+	mockCalendars := mock.NewMockCalendarService(gomock.NewController(t))
+	app := setupFiberTest(&Handler{eh: exchangeHandler{calendars: mockCalendars}}, utils.TestAuthMiddleware)
+	nextOpen := time.Date(2023, 7, 5, 13, 30, 0, 0, time.UTC)
+	mockCalendars.EXPECT().Status("XNYS", gomock.Any()).Return(model.MarketStatus{MicCode: "XNYS", Timezone: "America/New_York", NextOpen: nextOpen, NextClose: nextOpen.Add(390 * time.Minute)}, nil)
+	response, err := app.Test(utils.GetRequest("/api/v1/exchanges/XNYS/status", map[string]string{"Role": string(model.ClientRole)}))
+	utils.CommonResponseAssertions(t, response, err, 200, model.MarketStatus{MicCode: "XNYS", Timezone: "America/New_York", NextOpen: nextOpen, NextClose: nextOpen.Add(390 * time.Minute)})
+
+	mockCalendars.EXPECT().Status("XXXX", gomock.Any()).Return(model.MarketStatus{}, model.CalendarNotFound)
+	response, err = app.Test(utils.GetRequest("/api/v1/exchanges/XXXX/status", map[string]string{"Role": string(model.ClientRole)}))
+	utils.CommonResponseAssertions(t, response, err, 404, CommonResponse{Code: 404, Message: "trading calendar not found"})
+}
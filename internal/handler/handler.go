@@ -5,6 +5,8 @@ import (
 	_ "github.com/galushkoart/finance-api/docs"
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/internal/service"
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
+	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog/log"
 )
@@ -13,6 +15,12 @@ type Handler struct {
 	swaggerHandler fiber.Handler
 	ah             authHandler
 	sh             symbolHandler
+	eh             exchangeHandler
+	anh            analyticsHandler
+	ch             conversionHandler
+	uh             userHandler
+	ach            accountHandler
+	adm            adminHandler
 	apiMiddleware  []fiber.Handler
 }
 
@@ -20,19 +28,58 @@ func New(
 	swaggerHandler fiber.Handler,
 	authService service.AuthService,
 	symbolService service.SymbolService,
+	indicatorService service.IndicatorService,
 	symbolCache simpleCache.GenericCache[model.Symbol],
+	exchangeService service.ExchangeService,
+	calendarService service.CalendarService,
+	analyticsService service.AnalyticsService,
+	analyticsCache simpleCache.GenericCache[model.SymbolAnalytics],
+	correlationCache simpleCache.GenericCache[model.Correlation],
+	conversionService service.ConversionService,
+	userService service.UserService,
+	accountService service.AccountService,
+	rateLimits []ratelimit.Reporter,
 	apiMiddleware ...fiber.Handler,
 ) *Handler {
 	ahLog = log.With().Str("from", "authHandler").Logger()
 	shLog = log.With().Str("from", "symbolHandler").Logger()
+	ehLog = log.With().Str("from", "exchangeHandler").Logger()
+	anLog = log.With().Str("from", "analyticsHandler").Logger()
+	chLog = log.With().Str("from", "conversionHandler").Logger()
+	uhLog = log.With().Str("from", "userHandler").Logger()
+	acLog = log.With().Str("from", "accountHandler").Logger()
 	return &Handler{
 		swaggerHandler: swaggerHandler,
 		ah: authHandler{
 			service: authService,
 		},
 		sh: symbolHandler{
-			service: symbolService,
-			cache:   symbolCache,
+			service:     symbolService,
+			indicators:  indicatorService,
+			conversions: conversionService,
+			cache:       symbolCache,
+		},
+		eh: exchangeHandler{
+			service:     exchangeService,
+			calendars:   calendarService,
+			symbolCache: symbolCache,
+		},
+		anh: analyticsHandler{
+			service:      analyticsService,
+			cache:        analyticsCache,
+			correlations: correlationCache,
+		},
+		ch: conversionHandler{
+			service: conversionService,
+		},
+		uh: userHandler{
+			service: userService,
+		},
+		ach: accountHandler{
+			service: accountService,
+		},
+		adm: adminHandler{
+			rateLimits: rateLimits,
 		},
 		apiMiddleware: apiMiddleware,
 	}
@@ -45,6 +92,8 @@ func (h *Handler) InitRoutes(app *fiber.App) {
 		auth.Post("/signup", h.ah.SignUp)
 		auth.Post("/signin", h.ah.SignIn)
 		auth.Get("/refresh", h.ah.Refresh)
+		auth.Post("/password/forgot", h.ah.ForgotPassword)
+		auth.Post("/password/reset", h.ah.ResetPassword)
 	}
 	api := app.Group("/api")
 	{
@@ -55,11 +104,56 @@ func (h *Handler) InitRoutes(app *fiber.App) {
 		{
 			symbols := v1.Group("/symbols")
 			{
-				symbols.Get("", h.sh.GetSymbols)
-				symbols.Post("", AdminOnly, h.sh.AddSymbol)
-				symbols.Put("", AdminOnly, h.sh.UpdateSymbol)
-				symbols.Get("/:symbol", h.sh.GetSymbol)
-				symbols.Delete("/:symbol", AdminOnly, h.sh.DeleteSymbol)
+				symbols.Get("", RequirePermission(model.SymbolsRead), h.sh.GetSymbols)
+				symbols.Post("", RequirePermission(model.SymbolsWrite), h.sh.AddSymbol)
+				symbols.Put("", RequirePermission(model.SymbolsWrite), h.sh.UpdateSymbol)
+				symbols.Get("/search", RequirePermission(model.SymbolsRead), h.sh.SearchSymbols)
+				symbols.Get("/:symbol", RequirePermission(model.SymbolsRead), h.sh.GetSymbol)
+				symbols.Get("/:symbol/prices", RequirePermission(model.SymbolsRead), h.sh.GetPrices)
+				symbols.Get("/:symbol/indicators/:name", RequirePermission(model.SymbolsRead, model.AnalyticsRead), h.sh.GetIndicator)
+				symbols.Post("/:symbol/backfill", RequirePermission(model.SymbolsWrite), h.sh.Backfill)
+				symbols.Delete("/:symbol", RequirePermission(model.SymbolsWrite), h.sh.DeleteSymbol)
+			}
+			v1.Get("/quotes", RequirePermission(model.SymbolsRead), h.sh.GetQuotes)
+			v1.Get("/convert", RequirePermission(model.SymbolsRead), h.ch.Convert)
+			me := v1.Group("/me")
+			{
+				me.Get("", h.ach.GetAccount)
+				me.Put("", h.ach.UpdateProfile)
+				me.Delete("", h.ach.DeleteAccount)
+				me.Put("/password", h.ach.ChangePassword)
+			}
+			exchanges := v1.Group("/exchanges")
+			{
+				exchanges.Get("", RequirePermission(model.ExchangesRead), h.eh.GetExchanges)
+				exchanges.Post("", RequirePermission(model.ExchangesWrite), h.eh.AddExchange)
+				exchanges.Get("/:mic_code", RequirePermission(model.ExchangesRead), h.eh.GetExchange)
+				exchanges.Put("/:mic_code", RequirePermission(model.ExchangesWrite), h.eh.UpdateExchange)
+				exchanges.Delete("/:mic_code", RequirePermission(model.ExchangesWrite), h.eh.DeleteExchange)
+				exchanges.Get("/:mic_code/status", RequirePermission(model.ExchangesRead), h.eh.GetExchangeStatus)
+				exchanges.Get("/:mic_code/calendar", RequirePermission(model.ExchangesRead), h.eh.GetCalendar)
+				exchanges.Put("/:mic_code/calendar", RequirePermission(model.ExchangesWrite), h.eh.SaveCalendar)
+				exchanges.Get("/:mic_code/symbols", RequirePermission(model.ExchangesRead, model.SymbolsRead), h.eh.GetExchangeSymbols)
+				exchanges.Put("/:mic_code/symbols/:symbol", RequirePermission(model.ExchangesWrite), h.eh.AttachSymbol)
+				exchanges.Delete("/:mic_code/symbols/:symbol", RequirePermission(model.ExchangesWrite), h.eh.DetachSymbol)
+			}
+			analytics := v1.Group("/analytics")
+			{
+				analytics.Get("/correlation", RequirePermission(model.AnalyticsRead), h.anh.GetCorrelation)
+				analytics.Get("/:symbol", RequirePermission(model.AnalyticsRead), h.anh.GetAnalytics)
+			}
+			admin := v1.Group("/admin")
+			{
+				admin.Get("/rate-limits", RequirePermission(model.ProvidersRead), h.adm.GetRateLimits)
+				users := admin.Group("/users")
+				{
+					users.Get("", RequirePermission(model.UsersAdmin), h.uh.GetUsers)
+					users.Get("/:id", RequirePermission(model.UsersAdmin), h.uh.GetUser)
+					users.Put("/:id/roles", RequirePermission(model.UsersAdmin), h.uh.UpdateRoles)
+					users.Post("/:id/disable", RequirePermission(model.UsersAdmin), h.uh.DisableUser)
+					users.Post("/:id/enable", RequirePermission(model.UsersAdmin), h.uh.EnableUser)
+					users.Post("/:id/logout", RequirePermission(model.UsersAdmin), h.uh.LogoutUser)
+				}
 			}
 		}
 	}
@@ -67,6 +161,9 @@ func (h *Handler) InitRoutes(app *fiber.App) {
 
 func setupFiberTest(handler *Handler, middleware ...func(c *fiber.Ctx) error) *fiber.App {
 	app := fiber.New()
+	if len(middleware) == 0 {
+		middleware = append(middleware, utils.TestAuthMiddleware)
+	}
 	handler.apiMiddleware = middleware
 	handler.InitRoutes(app)
 	return app
@@ -79,7 +79,7 @@ func AuthMiddleware(parser *service.JwtParser, authConf ...AuthConfig) fiber.Han
 		if err != nil {
 			return c.Status(fiber.StatusUnauthorized).JSON(CommonResponse{Code: fiber.StatusUnauthorized, Message: err.Error()})
 		}
-		userId, role, err := parser.ParseToken(tokenString)
+		principal, err := parser.ParseToken(tokenString)
 		if err != nil {
 			if err == jwt.ErrHashUnavailable {
 				return c.SendStatus(fiber.StatusInternalServerError)
@@ -89,8 +89,8 @@ func AuthMiddleware(parser *service.JwtParser, authConf ...AuthConfig) fiber.Han
 		log.Info().
 			Str("request-id", utils.GetRequestId(c.Context())).
 			Str("from", "authMiddleware").
-			Msgf("request from %s with %s id", role, userId)
-		c.Locals("role", role)
+			Msgf("request from %v with %s id", principal.Roles, principal.ID)
+		c.Locals(principalKey, principal)
 		return c.Next()
 	}
 }
@@ -113,9 +113,24 @@ func getTokenFromRequest(c *fiber.Ctx) (string, error) {
 	return headerParts[1], nil
 }
 
-func AdminOnly(c *fiber.Ctx) error {
-	if c.Locals("role") != model.AdminRole {
-		return c.Status(fiber.StatusUnauthorized).JSON(CommonResponse{Code: fiber.StatusUnauthorized, Message: "you don't have permissions for this endpoint"})
+// principalKey is the key of authenticated model.Principal in fiber.Ctx locals
+const principalKey = "principal"
+
+// principalOf returns principal authenticated by AuthMiddleware
+func principalOf(c *fiber.Ctx) model.Principal {
+	principal, _ := c.Locals(principalKey).(model.Principal)
+	return principal
+}
+
+// RequirePermission allows request only if authenticated principal has all the permissions
+func RequirePermission(permissions ...model.Permission) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		principal := principalOf(c)
+		for _, permission := range permissions {
+			if !principal.HasPermission(permission) {
+				return c.Status(fiber.StatusUnauthorized).JSON(CommonResponse{Code: fiber.StatusUnauthorized, Message: "you don't have permissions for this endpoint"})
+			}
+		}
+		return c.Next()
 	}
-	return c.Next()
 }
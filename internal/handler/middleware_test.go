@@ -0,0 +1,74 @@
+package handler
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/service"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/gofiber/fiber/v2"
+	"testing"
+	"time"
+)
+
+func TestRequirePermission(t *testing.T) {
+	producer := service.NewJwtProducer("secret", time.Minute)
+	app := fiber.New()
+	app.Use(AuthMiddleware(service.NewJwtParser("secret")))
+	app.Get("/symbols", RequirePermission(model.SymbolsRead), func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusOK).JSON(c.Locals(principalKey))
+	})
+	app.Post("/symbols", RequirePermission(model.SymbolsRead, model.SymbolsWrite), func(c *fiber.Ctx) error {
+		return c.Status(fiber.StatusCreated).JSON(CommonResponse{Code: fiber.StatusCreated, Message: "created"})
+	})
+	for _, td := range requirePermissionTests {
+		t.Run(td.name, func(t *testing.T) {
+			token, err := producer.GetToken(context.TODO(), td.principal)
+			utils.PanicOnError(err)
+			request := utils.Request(td.method, "/symbols", nil, false, map[string]string{"Authorization": "Bearer " + token})
+			response, err := app.Test(request)
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var (
+	client = model.Principal{ID: "1", Roles: []model.Role{model.ClientRole}, Permissions: []model.Permission{model.SymbolsRead}}
+	editor = model.Principal{ID: "2", Roles: []model.Role{model.ClientRole, "Editor"}, Permissions: []model.Permission{model.SymbolsRead, model.SymbolsWrite}}
+)
+
+var requirePermissionTests = []struct {
+	name             string
+	method           string
+	principal        model.Principal
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("permission granted"),
+		method:           fiber.MethodGet,
+		principal:        client,
+		expectedCode:     200,
+		expectedResponse: client,
+	},
+	{
+		name:             utils.TestName("all permissions granted by roles"),
+		method:           fiber.MethodPost,
+		principal:        editor,
+		expectedCode:     201,
+		expectedResponse: CommonResponse{Code: 201, Message: "created"},
+	},
+	{
+		name:             utils.TestName("permission missing"),
+		method:           fiber.MethodPost,
+		principal:        client,
+		expectedCode:     401,
+		expectedResponse: CommonResponse{Code: 401, Message: "you don't have permissions for this endpoint"},
+	},
+	{
+		name:             utils.TestName("no permissions"),
+		method:           fiber.MethodGet,
+		principal:        model.Principal{ID: "3"},
+		expectedCode:     401,
+		expectedResponse: CommonResponse{Code: 401, Message: "you don't have permissions for this endpoint"},
+	},
+}
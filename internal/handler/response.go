@@ -1,10 +1,17 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/provider"
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
 	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
+	"math"
+	"strconv"
+	"time"
 )
 
 type CommonResponse struct {
@@ -28,6 +35,28 @@ func infoErrorResponse(c *fiber.Ctx, logger *zerolog.Logger, err error, statusCo
 	return returnError(c, statusCode, message, authErrors...)
 }
 
+// unavailableErrorResponse rejects request which couldn't be queued by rate limiter and tells client when to retry
+func unavailableErrorResponse(c *fiber.Ctx, logger *zerolog.Logger, err error, retryAfter time.Duration) error {
+	seconds := int(math.Ceil(retryAfter.Seconds()))
+	c.Set(fiber.HeaderRetryAfter, strconv.Itoa(seconds))
+	return warnErrorResponse(c, logger, err, fiber.StatusServiceUnavailable, fmt.Sprintf("Market data provider is busy. Retry after %d seconds", seconds))
+}
+
+// isProviderError reports whether err is caused by rate limit or unavailability of market data provider
+func isProviderError(err error) bool {
+	var limitErr *ratelimit.LimitExceededError
+	return errors.As(err, &limitErr) || errors.Is(err, provider.NoProviderAvailable)
+}
+
+// providerErrorResponse returns 503 with Retry-After if market data provider is rate limited and 503 if no provider is available
+func providerErrorResponse(c *fiber.Ctx, logger *zerolog.Logger, err error) error {
+	var limitErr *ratelimit.LimitExceededError
+	if errors.As(err, &limitErr) {
+		return unavailableErrorResponse(c, logger, err, limitErr.RetryAfter)
+	}
+	return warnErrorResponse(c, logger, err, fiber.StatusServiceUnavailable, "Market data provider is unavailable")
+}
+
 func returnError(c *fiber.Ctx, statusCode int, message string, authErrors ...[]*model.AuthError) error {
 	if len(authErrors) > 0 {
 		return c.Status(statusCode).JSON(CommonResponse{Code: statusCode, Message: message, AuthErrors: authErrors[0]})
@@ -1,19 +1,26 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/GalushkoArt/simpleCache"
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/internal/service"
+	"github.com/galushkoart/finance-api/pkg/indicators"
 	"github.com/gofiber/fiber/v2"
 	"github.com/rs/zerolog"
+	"math"
+	"strconv"
 	"strings"
+	"time"
 )
 
 type symbolHandler struct {
-	service service.SymbolService
-	cache   simpleCache.GenericCache[model.Symbol]
+	service     service.SymbolService
+	indicators  service.IndicatorService
+	conversions service.ConversionService
+	cache       simpleCache.GenericCache[model.Symbol]
 }
 
 var shLog zerolog.Logger
@@ -30,54 +37,465 @@ func (h *symbolHandler) infoErrorResponse(c *fiber.Ctx, err error, statusCode in
 	return infoErrorResponse(c, &shLog, err, statusCode, message, authErrors...)
 }
 
+func (h *symbolHandler) providerErrorResponse(c *fiber.Ctx, err error) error {
+	return providerErrorResponse(c, &shLog, err)
+}
+
+const (
+	defaultSymbolsLimit = 100
+	maxSymbolsLimit     = 1000
+)
+
 // GetSymbols godoc
 //
 //	@Summary		GetSymbols
 //	@Tags			Symbols
-//	@Description	Get all available latest symbols
+//	@Description	Get page of available latest symbols. Use next_cursor of the response as cursor to get the next page
 //	@Security		ApiKeyAuth[client, admin]
 //	@ID				get-symbols
 //	@Produce		json
-//	@Success		200	{array}		model.Symbol	"Successful response"
-//	@Failure		401	{object}	CommonResponse	"Unauthorized"
-//	@Failure		404	{object}	CommonResponse	"Data not found"
+//	@Param			type		query		string				false	"Instrument type, e.g. Common Stock"
+//	@Param			currency	query		string				false	"Currency of the symbol"
+//	@Param			mic_code	query		string				false	"MIC code of the exchange the symbol is traded on"
+//	@Param			country		query		string				false	"Country of the exchange the symbol is traded on"
+//	@Param			sort		query		string				false	"Field to sort by"	Enums(symbol, name, close, volume)	default(symbol)
+//	@Param			order		query		string				false	"Sort order"		Enums(asc, desc)					default(asc)
+//	@Param			limit		query		int					false	"Max number of symbols on the page"	minimum(1)	maximum(1000)	default(100)
+//	@Param			cursor		query		string				false	"Cursor of the page"
+//	@Success		200			{object}	model.SymbolPage	"Successful response"
+//	@Failure		400			{object}	CommonResponse		"Client request error"
+//	@Failure		401			{object}	CommonResponse		"Unauthorized"
+//	@Failure		404			{object}	CommonResponse		"Data not found"
 //	@Router			/api/v1/symbols [get]
 func (h *symbolHandler) GetSymbols(c *fiber.Ctx) error {
-	symbols, err := h.service.GetAll(c.Context())
+	query, err := parseSymbolQuery(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	page, err := h.service.GetAll(c.Context(), query)
 	if err != nil {
 		return h.warnErrorResponse(c, err, fiber.StatusNotFound, "CommonResponse on retrieving all symbols")
 	}
-	return c.Status(fiber.StatusOK).JSON(symbols)
+	return c.Status(fiber.StatusOK).JSON(page)
+}
+
+func parseSymbolQuery(c *fiber.Ctx) (model.SymbolQuery, error) {
+	query := model.SymbolQuery{
+		Type:     c.Query("type"),
+		Currency: c.Query("currency"),
+		MicCode:  c.Query("mic_code"),
+		Country:  c.Query("country"),
+	}
+	var err error
+	if query.Sort, err = model.ParseSymbolSort(c.Query("sort")); err != nil {
+		return query, errors.New("'sort' must be one of symbol, name, close, volume")
+	}
+	switch c.Query("order", "asc") {
+	case "asc":
+	case "desc":
+		query.Descending = true
+	default:
+		return query, errors.New("'order' must be asc or desc")
+	}
+	query.Limit = c.QueryInt("limit", 0)
+	if c.Query("limit") == "" {
+		query.Limit = defaultSymbolsLimit
+	}
+	if query.Limit < 1 || query.Limit > maxSymbolsLimit {
+		return query, fmt.Errorf("'limit' must be a number between 1 and %d", maxSymbolsLimit)
+	}
+	if cursor := c.Query("cursor"); cursor != "" {
+		if query.Cursor, err = model.ParseSymbolCursor(cursor); err != nil {
+			return query, errors.New("'cursor' is invalid")
+		}
+		if query.Cursor.Sort != query.Sort || query.Cursor.Descending != query.Descending {
+			return query, errors.New("'cursor' doesn't match 'sort' and 'order'")
+		}
+	}
+	return query, nil
+}
+
+const (
+	defaultSearchLimit = 10
+	maxSearchLimit     = 50
+)
+
+// SearchSymbols godoc
+//
+//	@Summary		SearchSymbols
+//	@Tags			Symbols
+//	@Description	Find symbols by partial symbol or name. Best matches go first. TwelveData catalogue is searched if no stored symbol matches
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				search-symbols
+//	@Produce		json
+//	@Param			q		query		string				true	"Part of symbol or name"
+//	@Param			limit	query		int					false	"Max number of matches"	minimum(1)	maximum(50)	default(10)
+//	@Success		200		{array}		model.SymbolMatch	"Successful response"
+//	@Failure		400		{object}	CommonResponse		"Client request error"
+//	@Failure		401		{object}	CommonResponse		"Unauthorized"
+//	@Failure		500		{object}	CommonResponse		"Internal server error"
+//	@Router			/api/v1/symbols/search [get]
+func (h *symbolHandler) SearchSymbols(c *fiber.Ctx) error {
+	query := strings.TrimSpace(c.Query("q"))
+	if query == "" {
+		return h.infoErrorResponse(c, errors.New("query is empty"), fiber.StatusBadRequest, "'q' must not be empty")
+	}
+	limit := c.QueryInt("limit", 0)
+	if c.Query("limit") == "" {
+		limit = defaultSearchLimit
+	}
+	if limit < 1 || limit > maxSearchLimit {
+		return h.infoErrorResponse(c, errors.New("invalid limit"), fiber.StatusBadRequest, fmt.Sprintf("'limit' must be a number between 1 and %d", maxSearchLimit))
+	}
+	matches, err := h.service.Search(c.Context(), query, limit)
+	if err != nil {
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to search symbols")
+	}
+	return c.Status(fiber.StatusOK).JSON(matches)
 }
 
 // GetSymbol godoc
 //
 //	@Summary		GetSymbol
 //	@Tags			Symbols
-//	@Description	Get latest data for particular symbol
+//	@Description	Get latest data for particular symbol. Symbol is marked as stale if its outdated prices couldn't be refreshed.
+//	@Description	Prices are converted to the currency if it is set
 //	@Security		ApiKeyAuth[client, admin]
 //	@ID				get-symbol
 //	@Produce		json
-//	@Success		200		{array}		model.Symbol	"Successful response"
-//	@Failure		400,404	{object}	CommonResponse	"Client request error"
-//	@Failure		401		{object}	CommonResponse	"Unauthorized"
-//	@Failure		500		{object}	CommonResponse	"Internal server error"
+//	@Param			symbol		path		string			true	"Symbol name. Use '-' instead of '/' for fx pairs"
+//	@Param			currency	query		string			false	"Currency code to convert prices to"
+//	@Success		200			{array}		model.Symbol	"Successful response"
+//	@Failure		400,404		{object}	CommonResponse	"Client request error"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		500			{object}	CommonResponse	"Internal server error"
+//	@Failure		503			{object}	CommonResponse	"Market data provider rate limit exceeded or provider is unavailable"
+//	@Header			503			{integer}	Retry-After		"Seconds to wait before retry"
 //	@Router			/api/v1/symbols/{symbol} [get]
 func (h *symbolHandler) GetSymbol(c *fiber.Ctx) error {
 	symbol := strings.Replace(c.Params("symbol"), "-", "/", 1)
-	cached := h.cache.Get(symbol)
-	if cached != nil {
+	currency, err := parseCurrency(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	found := h.cache.Get(symbol)
+	if found != nil {
 		shLog.Debug().Msgf("Return %s symbol from cache", symbol)
-		return c.Status(fiber.StatusOK).JSON(cached)
+	} else {
+		fetched, err := h.service.GetBySymbol(c.Context(), symbol)
+		if err == model.SymbolNotFound {
+			return h.infoErrorResponse(c, errors.New("symbol not found"), fiber.StatusNotFound, fmt.Sprintf("symbol %s not found", symbol))
+		} else if isProviderError(err) {
+			return h.providerErrorResponse(c, err)
+		} else if err != nil {
+			return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get %s symbol", symbol))
+		}
+		if !fetched.Stale {
+			h.cache.Set(symbol, fetched)
+		}
+		found = &fetched
+	}
+	if currency == "" || currency == found.Currency {
+		return c.Status(fiber.StatusOK).JSON(found)
+	}
+	if found.Currency == "" {
+		return h.infoErrorResponse(c, errors.New("no currency"), fiber.StatusBadRequest, fmt.Sprintf("symbol %s has no currency to convert from", symbol))
+	}
+	converted := *found
+	if converted.Values, err = h.conversions.ConvertPrices(c.Context(), found.Values, found.Currency, currency); err != nil {
+		return conversionErrorResponse(c, &shLog, err, found.Currency, currency)
+	}
+	converted.Currency = currency
+	return c.Status(fiber.StatusOK).JSON(converted)
+}
+
+// parseCurrency returns upper case 'currency' query param or error if it is not a currency code
+func parseCurrency(c *fiber.Ctx) (string, error) {
+	currency := strings.ToUpper(c.Query("currency"))
+	if currency != "" && !currencyPattern.MatchString(currency) {
+		return "", errors.New("'currency' must be a currency code")
+	}
+	return currency, nil
+}
+
+const maxQuoteSymbols = 100
+
+// GetQuotes godoc
+//
+//	@Summary		GetQuotes
+//	@Tags			Symbols
+//	@Description	Get latest data for several symbols at once. Symbols which couldn't be resolved are returned with error
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-quotes
+//	@Produce		json
+//	@Param			symbols	query		string			true	"Comma separated symbol names. Use '-' instead of '/' for fx pairs"
+//	@Success		200		{array}		model.Quote		"Successful response"
+//	@Failure		400		{object}	CommonResponse	"Client request error"
+//	@Failure		401		{object}	CommonResponse	"Unauthorized"
+//	@Failure		500		{object}	CommonResponse	"Internal server error"
+//	@Failure		503		{object}	CommonResponse	"Market data provider rate limit exceeded or provider is unavailable"
+//	@Header			503		{integer}	Retry-After		"Seconds to wait before retry"
+//	@Router			/api/v1/quotes [get]
+func (h *symbolHandler) GetQuotes(c *fiber.Ctx) error {
+	names := parseSymbols(c.Query("symbols"))
+	if len(names) == 0 || len(names) > maxQuoteSymbols {
+		return h.infoErrorResponse(c, errors.New("invalid symbols"), fiber.StatusBadRequest, fmt.Sprintf("'symbols' must contain from 1 to %d symbols", maxQuoteSymbols))
+	}
+	quotes := make([]model.Quote, len(names))
+	positions := make(map[string]int, len(names))
+	missing := make([]string, 0, len(names))
+	for i, name := range names {
+		if cached := h.cache.Get(name); cached != nil {
+			quotes[i] = model.Quote{Symbol: name, Data: cached}
+		} else {
+			positions[name] = i
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		fetched, err := h.service.GetQuotes(c.Context(), missing)
+		if isProviderError(err) {
+			return h.providerErrorResponse(c, err)
+		} else if err != nil {
+			return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to get quotes")
+		}
+		for _, quote := range fetched {
+			if quote.Data != nil && !quote.Data.Stale {
+				h.cache.Set(quote.Symbol, *quote.Data)
+			}
+			quotes[positions[quote.Symbol]] = quote
+		}
+	}
+	return c.Status(fiber.StatusOK).JSON(quotes)
+}
+
+// parseSymbols splits comma separated symbols skipping empty and duplicated ones
+func parseSymbols(query string) []string {
+	result := make([]string, 0)
+	seen := make(map[string]bool)
+	for _, name := range strings.Split(query, ",") {
+		name = strings.Replace(strings.TrimSpace(name), "-", "/", 1)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		result = append(result, name)
+	}
+	return result
+}
+
+const maxPricesLimit = 5000
+
+// GetPrices godoc
+//
+//	@Summary		GetPrices
+//	@Tags			Symbols
+//	@Description	Get historical prices for particular symbol ordered by date. By default returns prices for the last year.
+//	@Description	Monthly and quarterly prices are aggregated from daily prices in exchange timezone and dated by the first day of the period.
+//	@Description	Weekly prices are aggregated the same way if no weekly prices are stored in the range
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-prices
+//	@Produce		json
+//	@Param			symbol		path		string			true	"Symbol name. Use '-' instead of '/' for fx pairs"
+//	@Param			interval	query		string			false	"Interval of prices"	Enums(1min, 5min, 15min, 1h, 1day, 1week, 1month, 1quarter)	default(1day)
+//	@Param			from		query		string			false	"Start date (inclusive) in YYYY-MM-DD format"
+//	@Param			to			query		string			false	"End date (inclusive) in YYYY-MM-DD format"
+//	@Param			limit		query		int				false	"Max number of the latest prices in range"
+//	@Param			currency	query		string			false	"Currency code to convert prices to"
+//	@Success		200			{array}		model.Price		"Successful response"
+//	@Failure		400,404		{object}	CommonResponse	"Client request error"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		500			{object}	CommonResponse	"Internal server error"
+//	@Failure		503			{object}	CommonResponse	"Market data provider rate limit exceeded or provider is unavailable"
+//	@Header			503			{integer}	Retry-After		"Seconds to wait before retry"
+//	@Router			/api/v1/symbols/{symbol}/prices [get]
+func (h *symbolHandler) GetPrices(c *fiber.Ctx) error {
+	symbol := strings.Replace(c.Params("symbol"), "-", "/", 1)
+	query, err := parsePriceQuery(c, symbol)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	currency, err := parseCurrency(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	var from string
+	if currency != "" {
+		found := h.cache.Get(symbol)
+		if found == nil {
+			stored, err := h.service.GetBySymbol(c.Context(), symbol)
+			if err == model.SymbolNotFound {
+				return h.infoErrorResponse(c, err, fiber.StatusNotFound, fmt.Sprintf("symbol %s not found", symbol))
+			} else if isProviderError(err) {
+				return h.providerErrorResponse(c, err)
+			} else if err != nil {
+				return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get %s symbol", symbol))
+			}
+			found = &stored
+		}
+		if found.Currency == "" {
+			return h.infoErrorResponse(c, errors.New("no currency"), fiber.StatusBadRequest, fmt.Sprintf("symbol %s has no currency to convert from", symbol))
+		} else if found.Currency != currency {
+			from = found.Currency
+		}
+	}
+	var prices []model.Price
+	if from == "" {
+		prices, err = h.service.GetPrices(c.Context(), query)
+	} else {
+		prices, err = h.service.GetConvertedPrices(c.Context(), query, func(ctx context.Context, prices []model.Price) ([]model.Price, error) {
+			return h.conversions.ConvertPrices(ctx, prices, from, currency)
+		})
+	}
+	if err == model.SymbolNotFound {
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, fmt.Sprintf("symbol %s not found", symbol))
+	} else if err != nil && from != "" {
+		return conversionErrorResponse(c, &shLog, err, from, currency)
+	} else if err != nil {
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get prices for %s symbol", symbol))
+	}
+	return c.Status(fiber.StatusOK).JSON(prices)
+}
+
+func parsePriceQuery(c *fiber.Ctx, symbol string) (model.PriceQuery, error) {
+	query := model.PriceQuery{Symbol: symbol}
+	var err error
+	if query.Interval, err = model.ParsePriceInterval(c.Query("interval")); err != nil {
+		return query, err
+	}
+	if to := c.Query("to"); to != "" {
+		if query.To, err = time.Parse(model.DateLayout, to); err != nil {
+			return query, errors.New("'to' must be a date in YYYY-MM-DD format")
+		}
+	} else {
+		query.To = time.Now().UTC().Truncate(24 * time.Hour)
+	}
+	if from := c.Query("from"); from != "" {
+		if query.From, err = time.Parse(model.DateLayout, from); err != nil {
+			return query, errors.New("'from' must be a date in YYYY-MM-DD format")
+		}
+	} else {
+		query.From = query.To.AddDate(-1, 0, 0)
+	}
+	if query.From.After(query.To) {
+		return query, errors.New("'from' must not be after 'to'")
+	}
+	query.Limit = c.QueryInt("limit", 0)
+	if query.Limit < 0 || query.Limit > maxPricesLimit || (c.Query("limit") != "" && query.Limit == 0) {
+		return query, fmt.Errorf("'limit' must be a number between 1 and %d", maxPricesLimit)
+	}
+	return query, nil
+}
+
+// GetIndicator godoc
+//
+//	@Summary		GetIndicator
+//	@Tags			Symbols
+//	@Description	Get technical indicator computed from close prices of the symbol in the range. Prices preceding the range are used
+//	@Description	to warm up the indicator, so values start from the range start if enough prices are stored. Limit is applied to indicator values.
+//	@Description	sma, ema and rsi have a single line named after the indicator, macd has macd, signal and histogram lines,
+//	@Description	bbands has middle, upper and lower lines
+//	@Security		ApiKeyAuth[client, admin]
+//	@ID				get-indicator
+//	@Produce		json
+//	@Param			symbol		path		string			true	"Symbol name. Use '-' instead of '/' for fx pairs"
+//	@Param			name		path		string			true	"Indicator name"	Enums(sma, ema, rsi, macd, bbands)
+//	@Param			period		query		int				false	"Period of sma, ema, rsi and bbands. 20 by default, 14 for rsi"
+//	@Param			fast		query		int				false	"Fast EMA period of macd"	default(12)
+//	@Param			slow		query		int				false	"Slow EMA period of macd"	default(26)
+//	@Param			signal		query		int				false	"Signal EMA period of macd"	default(9)
+//	@Param			stddev		query		number			false	"Width of bbands in standard deviations, not greater than 10"	default(2)
+//	@Param			interval	query		string			false	"Interval of prices"	Enums(1min, 5min, 15min, 1h, 1day, 1week, 1month, 1quarter)	default(1day)
+//	@Param			from		query		string			false	"Start date (inclusive) in YYYY-MM-DD format"
+//	@Param			to			query		string			false	"End date (inclusive) in YYYY-MM-DD format"
+//	@Param			limit		query		int				false	"Max number of the latest indicator values"
+//	@Success		200			{object}	model.Indicator	"Successful response"
+//	@Failure		400,404		{object}	CommonResponse	"Client request error"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		500			{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/symbols/{symbol}/indicators/{name} [get]
+func (h *symbolHandler) GetIndicator(c *fiber.Ctx) error {
+	symbol := strings.Replace(c.Params("symbol"), "-", "/", 1)
+	name := strings.ToLower(c.Params("name"))
+	query, err := parsePriceQuery(c, symbol)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	params, err := parseIndicatorParams(c, name)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	indicator, err := h.indicators.Get(c.Context(), query, name, params)
+	if err == model.SymbolNotFound {
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, fmt.Sprintf("symbol %s not found", symbol))
+	} else if err != nil {
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to compute %s for %s symbol", name, symbol))
+	}
+	return c.Status(fiber.StatusOK).JSON(indicator)
+}
+
+// parseIndicatorParams overrides default params of the indicator with query params and validates them
+func parseIndicatorParams(c *fiber.Ctx, name string) (indicators.Params, error) {
+	params, err := indicators.DefaultParams(name)
+	if err != nil {
+		return params, fmt.Errorf("indicator must be one of %s", strings.Join(indicators.Names, ", "))
+	}
+	for _, param := range []struct {
+		key   string
+		value *int
+	}{{"period", &params.Period}, {"fast", &params.Fast}, {"slow", &params.Slow}, {"signal", &params.Signal}} {
+		if value := c.Query(param.key); value != "" {
+			if *param.value, err = strconv.Atoi(value); err != nil {
+				return params, fmt.Errorf("'%s' must be a number", param.key)
+			}
+		}
+	}
+	if value := c.Query("stddev"); value != "" {
+		if params.StdDev, err = strconv.ParseFloat(value, 64); err != nil || math.IsNaN(params.StdDev) || math.IsInf(params.StdDev, 0) {
+			return params, errors.New("'stddev' must be a number")
+		}
 	}
-	found, err := h.service.GetBySymbol(c.Context(), symbol)
+	return params, params.Validate(name)
+}
+
+// Backfill godoc
+//
+//	@Summary		Backfill
+//	@Tags			Symbols
+//	@Description	Load historical prices for symbol from api until requested start date is covered
+//	@Security		ApiKeyAuth[admin]
+//	@ID				backfill-symbol
+//	@Produce		json
+//	@Param			symbol		path		string					true	"Symbol name. Use '-' instead of '/' for fx pairs"
+//	@Param			interval	query		string					false	"Interval of prices"	Enums(1min, 5min, 15min, 1h, 1day, 1week)	default(1day)
+//	@Param			from		query		string					true	"Start date in YYYY-MM-DD format"
+//	@Success		200			{object}	model.BackfillResult	"Backfilled successfully"
+//	@Failure		400,404		{object}	CommonResponse			"Client request errors"
+//	@Failure		401			{object}	CommonResponse			"Unauthorized"
+//	@Failure		500			{object}	CommonResponse			"Internal server errors"
+//	@Failure		503			{object}	CommonResponse			"Market data provider rate limit exceeded or provider is unavailable"
+//	@Header			503			{integer}	Retry-After				"Seconds to wait before retry"
+//	@Router			/api/v1/symbols/{symbol}/backfill [post]
+func (h *symbolHandler) Backfill(c *fiber.Ctx) error {
+	symbol := strings.Replace(c.Params("symbol"), "-", "/", 1)
+	interval, err := model.ParseInterval(c.Query("interval"))
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	from, err := time.Parse(model.DateLayout, c.Query("from"))
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "'from' must be a date in YYYY-MM-DD format")
+	}
+	result, err := h.service.Backfill(c.Context(), symbol, interval, from)
 	if err == model.SymbolNotFound {
-		return h.infoErrorResponse(c, errors.New("symbol not found"), fiber.StatusNotFound, fmt.Sprintf("symbol %s not found", symbol))
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, fmt.Sprintf("symbol %s not found", symbol))
+	} else if isProviderError(err) {
+		return h.providerErrorResponse(c, err)
 	} else if err != nil {
-		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to get %s symbol", symbol))
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to backfill %s symbol", symbol))
 	}
-	h.cache.Set(symbol, found)
-	return c.Status(fiber.StatusOK).JSON(found)
+	h.cache.Delete(symbol)
+	return c.Status(fiber.StatusOK).JSON(result)
 }
 
 // DeleteSymbol godoc
@@ -123,9 +541,15 @@ func (h *symbolHandler) DeleteSymbol(c *fiber.Ctx) error {
 func (h *symbolHandler) AddSymbol(c *fiber.Ctx) error {
 	var symbol model.Symbol
 	if err := c.BodyParser(&symbol); err != nil {
+		if errors.Is(err, model.InvalidDecimal) {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+		}
 		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
 	}
 	if err := h.service.Add(c.Context(), symbol); err != nil {
+		if errors.Is(err, model.InvalidPrice) {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+		}
 		return h.warnErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to add %s symbol", symbol.Symbol))
 	}
 	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
@@ -149,9 +573,15 @@ func (h *symbolHandler) AddSymbol(c *fiber.Ctx) error {
 func (h *symbolHandler) UpdateSymbol(c *fiber.Ctx) error {
 	var symbol model.UpdateSymbol
 	if err := c.BodyParser(&symbol); err != nil {
+		if errors.Is(err, model.InvalidDecimal) {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+		}
 		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
 	}
 	if err := h.service.Update(c.Context(), symbol); err != nil {
+		if errors.Is(err, model.InvalidPrice) {
+			return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+		}
 		return h.warnErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to update %s symbol", symbol.Symbol))
 	}
 	h.cache.Delete(symbol.Symbol)
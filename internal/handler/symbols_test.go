@@ -1,18 +1,24 @@
 package handler
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/indicators"
+	"github.com/galushkoart/finance-api/pkg/provider"
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
 	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/golang/mock/gomock"
 	"strings"
 	"testing"
+	"time"
 )
 
 //go:generate echo $PWD - $GOFILE
 //go:generate mockgen -package mock -destination ../../mock/symbol_service_mock.go -source=../service/symbol_service.go SymbolService
+//go:generate mockgen -package mock -destination ../../mock/indicator_service_mock.go -source=../service/indicator_service.go IndicatorService
 //use this generate function if generic cache is changed but need some manual correction of mock go:generate mockgen -package mock -destination ../../mock/generic_cache_mock.go github.com/GalushkoArt/simpleCache GenericCache[any]
 
 func TestGetSymbols(t *testing.T) {
@@ -21,34 +27,146 @@ func TestGetSymbols(t *testing.T) {
 	app := setupFiberTest(&Handler{sh: symbolHandler{service: mockService}}, utils.TestAuthMiddleware)
 	for _, td := range getSymbolsTests {
 		t.Run(td.name, func(t *testing.T) {
-			mockService.EXPECT().GetAll(gomock.Any()).Return(td.symbols, td.serviceError)
-			response, err := app.Test(utils.GetRequest("/api/v1/symbols"))
+			if td.expectedQuery != nil {
+				mockService.EXPECT().GetAll(gomock.Any(), *td.expectedQuery).Return(td.page, td.serviceError)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/symbols" + td.query))
 			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
 		})
 	}
 }
 
+var nameCursor = model.SymbolCursor{Sort: model.SortByName, Descending: true, Value: "Test Inc", Symbol: "TEST"}
+
 var getSymbolsTests = []struct {
 	name             string
-	symbols          []model.Symbol
+	query            string
+	expectedQuery    *model.SymbolQuery
+	page             model.SymbolPage
 	serviceError     error
 	expectedCode     int
 	expectedResponse interface{}
 }{
 	{
 		name:             utils.TestName("get symbols successfully"),
-		symbols:          []model.Symbol{{Symbol: "TEST"}},
+		expectedQuery:    &model.SymbolQuery{Sort: model.SortBySymbol, Limit: 100},
+		page:             model.SymbolPage{Symbols: []model.Symbol{{Symbol: "TEST"}}, Total: 1},
+		expectedCode:     200,
+		expectedResponse: model.SymbolPage{Symbols: []model.Symbol{{Symbol: "TEST"}}, Total: 1},
+	},
+	{
+		name:             utils.TestName("get symbols with filters and cursor"),
+		query:            "?type=Common%20Stock&currency=USD&mic_code=XNGS&country=United%20States&sort=name&order=desc&limit=1&cursor=" + nameCursor.Encode(),
+		expectedQuery:    &model.SymbolQuery{Type: "Common Stock", Currency: "USD", MicCode: "XNGS", Country: "United States", Sort: model.SortByName, Descending: true, Limit: 1, Cursor: &nameCursor},
+		page:             model.SymbolPage{Symbols: []model.Symbol{{Symbol: "SYMB"}}, Total: 3, NextCursor: "next"},
 		expectedCode:     200,
-		expectedResponse: []model.Symbol{{Symbol: "TEST"}},
+		expectedResponse: model.SymbolPage{Symbols: []model.Symbol{{Symbol: "SYMB"}}, Total: 3, NextCursor: "next"},
+	},
+	{
+		name:             utils.TestName("unsupported sort"),
+		query:            "?sort=date",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'sort' must be one of symbol, name, close, volume"},
+	},
+	{
+		name:             utils.TestName("unsupported order"),
+		query:            "?order=up",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'order' must be asc or desc"},
+	},
+	{
+		name:             utils.TestName("limit too big"),
+		query:            "?limit=1001",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'limit' must be a number between 1 and 1000"},
+	},
+	{
+		name:             utils.TestName("invalid cursor"),
+		query:            "?cursor=invalid",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'cursor' is invalid"},
+	},
+	{
+		name:             utils.TestName("cursor of another sort"),
+		query:            "?sort=name&cursor=" + nameCursor.Encode(),
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'cursor' doesn't match 'sort' and 'order'"},
 	},
 	{
 		name:             utils.TestName("get symbols failed"),
+		expectedQuery:    &model.SymbolQuery{Sort: model.SortBySymbol, Limit: 100},
 		serviceError:     errors.New("failed to get symbols"),
 		expectedCode:     404,
 		expectedResponse: CommonResponse{Code: 404, Message: "CommonResponse on retrieving all symbols"},
 	},
 }
 
+func TestSearchSymbols(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockSymbolService(controller)
+	app := setupFiberTest(&Handler{sh: symbolHandler{service: mockService}})
+	for _, td := range searchSymbolsTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedLimit > 0 {
+				mockService.EXPECT().Search(gomock.Any(), td.expectedQuery, td.expectedLimit).Return(td.matches, td.serviceError)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/symbols/search" + td.query))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var searchSymbolsTests = []struct {
+	name             string
+	query            string
+	expectedQuery    string
+	expectedLimit    int
+	matches          []model.SymbolMatch
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("search successfully"),
+		query:            "?q=%20app%20",
+		expectedQuery:    "app",
+		expectedLimit:    10,
+		matches:          []model.SymbolMatch{{Symbol: "AAPL", Name: "Apple Inc", Source: model.SearchSourceLocal, Score: 2.5}},
+		expectedCode:     200,
+		expectedResponse: []model.SymbolMatch{{Symbol: "AAPL", Name: "Apple Inc", Source: model.SearchSourceLocal, Score: 2.5}},
+	},
+	{
+		name:             utils.TestName("search with limit"),
+		query:            "?q=apple&limit=50",
+		expectedQuery:    "apple",
+		expectedLimit:    50,
+		matches:          []model.SymbolMatch{},
+		expectedCode:     200,
+		expectedResponse: []model.SymbolMatch{},
+	},
+	{
+		name:             utils.TestName("empty query"),
+		query:            "?q=%20",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'q' must not be empty"},
+	},
+	{
+		name:             utils.TestName("limit too big"),
+		query:            "?q=app&limit=51",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'limit' must be a number between 1 and 50"},
+	},
+	{
+		name:             utils.TestName("search failed"),
+		query:            "?q=app",
+		expectedQuery:    "app",
+		expectedLimit:    10,
+		serviceError:     errors.New("db is down"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to search symbols"},
+	},
+}
+
 // GetSymbol godoc
 //
 //	@Summary		GetSymbol
@@ -95,7 +213,7 @@ func TestGetSymbol(t *testing.T) {
 			} else {
 				mockCache.EXPECT().Get(symbolParam).Return(nil)
 				mockService.EXPECT().GetBySymbol(gomock.Any(), symbolParam).Return(td.symbol, td.serviceError)
-				if td.serviceError == nil {
+				if td.serviceError == nil && !td.symbol.Stale {
 					mockCache.EXPECT().Set(symbolParam, td.symbol).Times(1)
 				}
 			}
@@ -129,6 +247,13 @@ var getSymbolTests = []struct {
 		expectedCode:     200,
 		expectedResponse: model.Symbol{Symbol: "TE/ST"},
 	},
+	{
+		name:             utils.TestName("stale symbol is not cached"),
+		requestedSymbol:  "TEST",
+		symbol:           model.Symbol{Symbol: "TEST", Stale: true},
+		expectedCode:     200,
+		expectedResponse: model.Symbol{Symbol: "TEST", Stale: true},
+	},
 	{
 		name:             utils.TestName("symbol not found"),
 		requestedSymbol:  "TE-ST",
@@ -143,6 +268,579 @@ var getSymbolTests = []struct {
 		expectedCode:     500,
 		expectedResponse: CommonResponse{Code: 500, Message: "Failed to get TE/ST symbol"},
 	},
+	{
+		name:             utils.TestName("provider rate limit exceeded"),
+		requestedSymbol:  "TEST",
+		serviceError:     &ratelimit.LimitExceededError{Name: "twelveData", RetryAfter: 1500 * time.Millisecond},
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is busy. Retry after 2 seconds"},
+	},
+	{
+		name:             utils.TestName("no provider available"),
+		requestedSymbol:  "TEST",
+		serviceError:     provider.NoProviderAvailable,
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is unavailable"},
+	},
+}
+
+func TestGetSymbolInCurrency(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockSymbolService(controller)
+	mockConversions := mock.NewMockConversionService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	app := setupFiberTest(&Handler{sh: symbolHandler{service: mockService, conversions: mockConversions, cache: mockCache}})
+	for _, td := range getSymbolInCurrencyTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedCode != 400 || td.symbol.Symbol != "" {
+				mockCache.EXPECT().Get("AAPL").Return(&td.symbol)
+			}
+			if td.converted != nil || td.conversionError != nil {
+				mockConversions.EXPECT().ConvertPrices(gomock.Any(), td.symbol.Values, "USD", "EUR").Return(td.converted, td.conversionError)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/symbols/AAPL?" + td.query))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var getSymbolInCurrencyTests = []struct {
+	name             string
+	query            string
+	symbol           model.Symbol
+	converted        []model.Price
+	conversionError  error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("convert symbol prices"),
+		query:            "currency=eur",
+		symbol:           model.Symbol{Symbol: "AAPL", Currency: "USD", Values: []model.Price{{Date: "2023-06-02", Close: "180.95"}}},
+		converted:        []model.Price{{Date: "2023-06-02", Close: "168.9822"}},
+		expectedCode:     200,
+		expectedResponse: model.Symbol{Symbol: "AAPL", Currency: "EUR", Values: []model.Price{{Date: "2023-06-02", Close: "168.9822"}}},
+	},
+	{
+		name:             utils.TestName("same currency"),
+		query:            "currency=USD",
+		symbol:           model.Symbol{Symbol: "AAPL", Currency: "USD", Values: []model.Price{{Date: "2023-06-02", Close: "180.95"}}},
+		expectedCode:     200,
+		expectedResponse: model.Symbol{Symbol: "AAPL", Currency: "USD", Values: []model.Price{{Date: "2023-06-02", Close: "180.95"}}},
+	},
+	{
+		name:             utils.TestName("rate not found"),
+		query:            "currency=EUR",
+		symbol:           model.Symbol{Symbol: "AAPL", Currency: "USD"},
+		conversionError:  model.RateNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "rate of USD to EUR not found"},
+	},
+	{
+		name:             utils.TestName("symbol without currency"),
+		query:            "currency=EUR",
+		symbol:           model.Symbol{Symbol: "AAPL"},
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "symbol AAPL has no currency to convert from"},
+	},
+	{
+		name:             utils.TestName("invalid currency"),
+		query:            "currency=euro1",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'currency' must be a currency code"},
+	},
+}
+
+func TestGetQuotes(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockSymbolService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	app := setupFiberTest(&Handler{sh: symbolHandler{service: mockService, cache: mockCache}})
+	for _, td := range getQuotesTests {
+		t.Run(td.name, func(t *testing.T) {
+			for name, cached := range td.cached {
+				cached := cached
+				mockCache.EXPECT().Get(name).Return(cached)
+			}
+			if td.missing != nil {
+				mockService.EXPECT().GetQuotes(gomock.Any(), td.missing).Return(td.quotes, td.serviceError)
+			}
+			for _, quote := range td.quotes {
+				if quote.Data != nil && !quote.Data.Stale {
+					mockCache.EXPECT().Set(quote.Symbol, *quote.Data)
+				}
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/quotes?symbols=" + td.symbols))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var getQuotesTests = []struct {
+	name             string
+	symbols          string
+	cached           map[string]*model.Symbol
+	missing          []string
+	quotes           []model.Quote
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:    utils.TestName("get quotes from cache and service"),
+		symbols: "AAPL,EUR-USD,INVALID,AAPL,MSFT",
+		cached:  map[string]*model.Symbol{"AAPL": {Symbol: "AAPL"}, "EUR/USD": nil, "INVALID": nil, "MSFT": nil},
+		missing: []string{"EUR/USD", "INVALID", "MSFT"},
+		quotes: []model.Quote{
+			{Symbol: "EUR/USD", Data: &model.Symbol{Symbol: "EUR/USD"}},
+			{Symbol: "INVALID", Error: "symbol INVALID not found"},
+			{Symbol: "MSFT", Data: &model.Symbol{Symbol: "MSFT", Stale: true}},
+		},
+		expectedCode: 200,
+		expectedResponse: []model.Quote{
+			{Symbol: "AAPL", Data: &model.Symbol{Symbol: "AAPL"}},
+			{Symbol: "EUR/USD", Data: &model.Symbol{Symbol: "EUR/USD"}},
+			{Symbol: "INVALID", Error: "symbol INVALID not found"},
+			{Symbol: "MSFT", Data: &model.Symbol{Symbol: "MSFT", Stale: true}},
+		},
+	},
+	{
+		name:             utils.TestName("get quotes from cache only"),
+		symbols:          "AAPL",
+		cached:           map[string]*model.Symbol{"AAPL": {Symbol: "AAPL"}},
+		expectedCode:     200,
+		expectedResponse: []model.Quote{{Symbol: "AAPL", Data: &model.Symbol{Symbol: "AAPL"}}},
+	},
+	{
+		name:             utils.TestName("get quotes without symbols"),
+		symbols:          " , ",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'symbols' must contain from 1 to 100 symbols"},
+	},
+	{
+		name:             utils.TestName("get quotes with internal server error"),
+		symbols:          "AAPL",
+		cached:           map[string]*model.Symbol{"AAPL": nil},
+		missing:          []string{"AAPL"},
+		serviceError:     errors.New("db error"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to get quotes"},
+	},
+	{
+		name:             utils.TestName("get quotes with provider rate limit exceeded"),
+		symbols:          "AAPL",
+		cached:           map[string]*model.Symbol{"AAPL": nil},
+		missing:          []string{"AAPL"},
+		serviceError:     &ratelimit.LimitExceededError{Name: "twelveData", RetryAfter: 1500 * time.Millisecond},
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is busy. Retry after 2 seconds"},
+	},
+	{
+		name:             utils.TestName("get quotes without available provider"),
+		symbols:          "AAPL",
+		cached:           map[string]*model.Symbol{"AAPL": nil},
+		missing:          []string{"AAPL"},
+		serviceError:     provider.NoProviderAvailable,
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is unavailable"},
+	},
+}
+
+func TestGetPrices(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockSymbolService(controller)
+	app := setupFiberTest(&Handler{sh: symbolHandler{service: mockService}})
+	for _, td := range getPricesTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedQuery != nil {
+				mockService.EXPECT().GetPrices(gomock.Any(), *td.expectedQuery).Return(td.prices, td.serviceError)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/symbols/" + td.requestedPath))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var getPricesTests = []struct {
+	name             string
+	requestedPath    string
+	expectedQuery    *model.PriceQuery
+	prices           []model.Price
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("get prices successfully"),
+		requestedPath:    "TE-ST/prices?from=2023-06-01&to=2023-06-02&limit=10",
+		expectedQuery:    &model.PriceQuery{Symbol: "TE/ST", Interval: model.Interval1Day, From: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), Limit: 10},
+		prices:           []model.Price{{Date: "2023-06-01", Close: "180.09"}, {Date: "2023-06-02", Close: "179.8"}},
+		expectedCode:     200,
+		expectedResponse: []model.Price{{Date: "2023-06-01", Close: "180.09"}, {Date: "2023-06-02", Close: "179.8"}},
+	},
+	{
+		name:             utils.TestName("get prices with default range"),
+		requestedPath:    "TEST/prices?to=2023-06-02",
+		expectedQuery:    &model.PriceQuery{Symbol: "TEST", Interval: model.Interval1Day, From: time.Date(2022, 6, 2, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)},
+		prices:           []model.Price{},
+		expectedCode:     200,
+		expectedResponse: []model.Price{},
+	},
+	{
+		name:             utils.TestName("get intraday prices"),
+		requestedPath:    "TEST/prices?interval=5min&from=2023-06-02&to=2023-06-02",
+		expectedQuery:    &model.PriceQuery{Symbol: "TEST", Interval: model.Interval5Min, From: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)},
+		prices:           []model.Price{{Interval: model.Interval5Min, Date: "2023-06-02 15:55:00", Close: "179.8"}},
+		expectedCode:     200,
+		expectedResponse: []model.Price{{Interval: model.Interval5Min, Date: "2023-06-02 15:55:00", Close: "179.8"}},
+	},
+	{
+		name:             utils.TestName("get monthly prices"),
+		requestedPath:    "TEST/prices?interval=1month&from=2023-05-15&to=2023-06-02",
+		expectedQuery:    &model.PriceQuery{Symbol: "TEST", Interval: model.Interval1Month, From: time.Date(2023, 5, 15, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)},
+		prices:           []model.Price{{Interval: model.Interval1Month, Date: "2023-05-01", Close: "177.25"}, {Interval: model.Interval1Month, Date: "2023-06-01", Close: "179.8"}},
+		expectedCode:     200,
+		expectedResponse: []model.Price{{Interval: model.Interval1Month, Date: "2023-05-01", Close: "177.25"}, {Interval: model.Interval1Month, Date: "2023-06-01", Close: "179.8"}},
+	},
+	{
+		name:             utils.TestName("unsupported interval"),
+		requestedPath:    "TEST/prices?interval=2min",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "unsupported interval 2min"},
+	},
+	{
+		name:             utils.TestName("invalid from date"),
+		requestedPath:    "TEST/prices?from=01.06.2023",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'from' must be a date in YYYY-MM-DD format"},
+	},
+	{
+		name:             utils.TestName("from after to"),
+		requestedPath:    "TEST/prices?from=2023-06-03&to=2023-06-02",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'from' must not be after 'to'"},
+	},
+	{
+		name:             utils.TestName("invalid limit"),
+		requestedPath:    "TEST/prices?limit=abc",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'limit' must be a number between 1 and 5000"},
+	},
+	{
+		name:             utils.TestName("symbol not found"),
+		requestedPath:    "TEST/prices?from=2023-06-01&to=2023-06-02",
+		expectedQuery:    &model.PriceQuery{Symbol: "TEST", Interval: model.Interval1Day, From: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)},
+		serviceError:     model.SymbolNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "symbol TEST not found"},
+	},
+	{
+		name:             utils.TestName("get prices with internal server error"),
+		requestedPath:    "TEST/prices?from=2023-06-01&to=2023-06-02",
+		expectedQuery:    &model.PriceQuery{Symbol: "TEST", Interval: model.Interval1Day, From: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)},
+		serviceError:     errors.New("db error"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to get prices for TEST symbol"},
+	},
+}
+
+func TestGetPricesInCurrency(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockSymbolService(controller)
+	mockConversions := mock.NewMockConversionService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	app := setupFiberTest(&Handler{sh: symbolHandler{service: mockService, conversions: mockConversions, cache: mockCache}})
+	query := model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Month, From: time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)}
+	daily := []model.Price{{Date: "2023-06-02", Close: "180.95"}}
+	for _, td := range getPricesInCurrencyTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.cached != nil {
+				mockCache.EXPECT().Get("AAPL").Return(td.cached)
+			} else {
+				mockCache.EXPECT().Get("AAPL").Return(nil)
+				mockService.EXPECT().GetBySymbol(gomock.Any(), "AAPL").Return(model.Symbol{}, td.symbolError)
+			}
+			if td.symbolError == nil && td.cached.Currency == "EUR" {
+				mockService.EXPECT().GetPrices(gomock.Any(), query).Return(td.converted, nil)
+			} else if td.symbolError == nil {
+				mockService.EXPECT().GetConvertedPrices(gomock.Any(), query, gomock.Any()).DoAndReturn(
+					func(ctx context.Context, _ model.PriceQuery, convert func(context.Context, []model.Price) ([]model.Price, error)) ([]model.Price, error) {
+						return convert(ctx, daily)
+					})
+				mockConversions.EXPECT().ConvertPrices(gomock.Any(), daily, "USD", "EUR").Return(td.converted, td.conversionError)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/symbols/AAPL/prices?interval=1month&from=2023-05-01&to=2023-06-02&currency=EUR"))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var getPricesInCurrencyTests = []struct {
+	name             string
+	cached           *model.Symbol
+	symbolError      error
+	converted        []model.Price
+	conversionError  error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("convert daily prices before aggregation"),
+		cached:           &model.Symbol{Symbol: "AAPL", Currency: "USD"},
+		converted:        []model.Price{{Date: "2023-06-02", Close: "168.9822"}},
+		expectedCode:     200,
+		expectedResponse: []model.Price{{Date: "2023-06-02", Close: "168.9822"}},
+	},
+	{
+		name:             utils.TestName("prices in the same currency"),
+		cached:           &model.Symbol{Symbol: "AAPL", Currency: "EUR"},
+		converted:        []model.Price{{Date: "2023-06-02", Close: "168.9822"}},
+		expectedCode:     200,
+		expectedResponse: []model.Price{{Date: "2023-06-02", Close: "168.9822"}},
+	},
+	{
+		name:             utils.TestName("rate not found"),
+		cached:           &model.Symbol{Symbol: "AAPL", Currency: "USD"},
+		conversionError:  model.RateNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "rate of USD to EUR not found"},
+	},
+	{
+		name:             utils.TestName("rate provider rate limit exceeded"),
+		cached:           &model.Symbol{Symbol: "AAPL", Currency: "USD"},
+		conversionError:  &ratelimit.LimitExceededError{Name: "twelveData", RetryAfter: 1500 * time.Millisecond},
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is busy. Retry after 2 seconds"},
+	},
+	{
+		name:             utils.TestName("rate provider unavailable"),
+		cached:           &model.Symbol{Symbol: "AAPL", Currency: "USD"},
+		conversionError:  provider.NoProviderAvailable,
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is unavailable"},
+	},
+	{
+		name:             utils.TestName("symbol provider rate limit exceeded"),
+		symbolError:      &ratelimit.LimitExceededError{Name: "twelveData", RetryAfter: 1500 * time.Millisecond},
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is busy. Retry after 2 seconds"},
+	},
+	{
+		name:             utils.TestName("symbol not found"),
+		symbolError:      model.SymbolNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "symbol AAPL not found"},
+	},
+}
+
+func TestGetIndicator(t *testing.T) {
+	mockIndicators := mock.NewMockIndicatorService(gomock.NewController(t))
+	app := setupFiberTest(&Handler{sh: symbolHandler{indicators: mockIndicators}})
+	for _, td := range getIndicatorTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedCode != 400 {
+				mockIndicators.EXPECT().Get(gomock.Any(), td.expectedQuery, td.indicator, td.expectedParams).Return(td.result, td.serviceError)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/symbols/" + td.requestedPath))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var indicatorQuery = model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Day, From: time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)}
+
+var getIndicatorTests = []struct {
+	name             string
+	requestedPath    string
+	indicator        string
+	expectedQuery    model.PriceQuery
+	expectedParams   indicators.Params
+	result           model.Indicator
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:           utils.TestName("get sma with period"),
+		requestedPath:  "AAPL/indicators/sma?period=50&from=2023-01-01&to=2023-06-02",
+		indicator:      indicators.NameSMA,
+		expectedQuery:  indicatorQuery,
+		expectedParams: indicators.Params{Period: 50},
+		result: model.Indicator{Symbol: "AAPL", Name: indicators.NameSMA, Interval: model.Interval1Day, Params: indicators.Params{Period: 50},
+			Values: []model.IndicatorValue{{Date: "2023-06-02", Lines: map[string]float64{"sma": 172.37}}}},
+		expectedCode: 200,
+		expectedResponse: model.Indicator{Symbol: "AAPL", Name: indicators.NameSMA, Interval: model.Interval1Day, Params: indicators.Params{Period: 50},
+			Values: []model.IndicatorValue{{Date: "2023-06-02", Lines: map[string]float64{"sma": 172.37}}}},
+	},
+	{
+		name:             utils.TestName("get macd with default params"),
+		requestedPath:    "AAPL/indicators/MACD?from=2023-01-01&to=2023-06-02",
+		indicator:        indicators.NameMACD,
+		expectedQuery:    indicatorQuery,
+		expectedParams:   indicators.Params{Fast: 12, Slow: 26, Signal: 9},
+		serviceError:     model.SymbolNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "symbol AAPL not found"},
+	},
+	{
+		name:             utils.TestName("unknown indicator"),
+		requestedPath:    "AAPL/indicators/vwap",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "indicator must be one of sma, ema, rsi, macd, bbands"},
+	},
+	{
+		name:             utils.TestName("invalid period"),
+		requestedPath:    "AAPL/indicators/rsi?period=1",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'period' must be a number between 2 and 500"},
+	},
+	{
+		name:             utils.TestName("non-numeric stddev"),
+		requestedPath:    "AAPL/indicators/bbands?stddev=wide",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'stddev' must be a number"},
+	},
+	{
+		name:             utils.TestName("infinite stddev"),
+		requestedPath:    "AAPL/indicators/bbands?stddev=inf",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'stddev' must be a number"},
+	},
+	{
+		name:             utils.TestName("NaN stddev"),
+		requestedPath:    "AAPL/indicators/bbands?stddev=NaN",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'stddev' must be a number"},
+	},
+	{
+		name:             utils.TestName("too wide stddev"),
+		requestedPath:    "AAPL/indicators/bbands?stddev=100",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'stddev' must be a positive number not greater than 10"},
+	},
+	{
+		name:             utils.TestName("compute failed"),
+		requestedPath:    "AAPL/indicators/ema?from=2023-01-01&to=2023-06-02",
+		indicator:        indicators.NameEMA,
+		expectedQuery:    indicatorQuery,
+		expectedParams:   indicators.Params{Period: 20},
+		serviceError:     errors.New("db is down"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to compute ema for AAPL symbol"},
+	},
+}
+
+func TestBackfill(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockSymbolService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	app := setupFiberTest(&Handler{sh: symbolHandler{service: mockService, cache: mockCache}}, utils.TestAuthMiddleware)
+	for _, td := range backfillTests {
+		t.Run(td.name, func(t *testing.T) {
+			if !td.from.IsZero() && td.role == model.AdminRole {
+				mockService.EXPECT().Backfill(gomock.Any(), td.symbol, td.interval, td.from).Return(td.result, td.serviceError)
+			}
+			if td.expectedCode == 200 {
+				mockCache.EXPECT().Delete(td.symbol).Return(nil)
+			}
+			request := utils.PostRequest(fmt.Sprintf("/api/v1/symbols/%s/backfill?%s", td.symbol, td.query), nil, false, map[string]string{"Role": string(td.role)})
+			response, err := app.Test(request)
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var backfillTests = []struct {
+	name             string
+	role             model.Role
+	symbol           string
+	query            string
+	interval         model.Interval
+	from             time.Time
+	result           model.BackfillResult
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("backfill successfully"),
+		role:             model.AdminRole,
+		symbol:           "TEST",
+		query:            "from=2020-01-01",
+		interval:         model.Interval1Day,
+		from:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		result:           model.BackfillResult{Symbol: "TEST", Interval: model.Interval1Day, From: "2020-01-01", Prices: 860},
+		expectedCode:     200,
+		expectedResponse: model.BackfillResult{Symbol: "TEST", Interval: model.Interval1Day, From: "2020-01-01", Prices: 860},
+	},
+	{
+		name:             utils.TestName("backfill with client role"),
+		role:             model.ClientRole,
+		symbol:           "TEST",
+		query:            "from=2020-01-01",
+		expectedCode:     401,
+		expectedResponse: CommonResponse{Code: 401, Message: "you don't have permissions for this endpoint"},
+	},
+	{
+		name:             utils.TestName("backfill intraday prices"),
+		role:             model.AdminRole,
+		symbol:           "TEST",
+		query:            "interval=1h&from=2023-01-01",
+		interval:         model.Interval1Hour,
+		from:             time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC),
+		result:           model.BackfillResult{Symbol: "TEST", Interval: model.Interval1Hour, From: "2023-01-01", Prices: 2000},
+		expectedCode:     200,
+		expectedResponse: model.BackfillResult{Symbol: "TEST", Interval: model.Interval1Hour, From: "2023-01-01", Prices: 2000},
+	},
+	{
+		name:             utils.TestName("backfill with unsupported interval"),
+		role:             model.AdminRole,
+		symbol:           "TEST",
+		query:            "interval=1month&from=2023-01-01",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "unsupported interval 1month"},
+	},
+	{
+		name:             utils.TestName("backfill with invalid date"),
+		role:             model.AdminRole,
+		symbol:           "TEST",
+		query:            "from=2020",
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'from' must be a date in YYYY-MM-DD format"},
+	},
+	{
+		name:             utils.TestName("backfill unknown symbol"),
+		role:             model.AdminRole,
+		symbol:           "INVALID",
+		query:            "from=2020-01-01",
+		interval:         model.Interval1Day,
+		from:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		serviceError:     model.SymbolNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: "symbol INVALID not found"},
+	},
+	{
+		name:             utils.TestName("backfill failed"),
+		role:             model.AdminRole,
+		symbol:           "TEST",
+		query:            "from=2020-01-01",
+		interval:         model.Interval1Day,
+		from:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		serviceError:     errors.New("api error"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to backfill TEST symbol"},
+	},
+	{
+		name:             utils.TestName("backfill without available provider"),
+		role:             model.AdminRole,
+		symbol:           "TEST",
+		query:            "from=2020-01-01",
+		interval:         model.Interval1Day,
+		from:             time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+		serviceError:     provider.NoProviderAvailable,
+		expectedCode:     503,
+		expectedResponse: CommonResponse{Code: 503, Message: "Market data provider is unavailable"},
+	},
 }
 
 func TestDeleteSymbol(t *testing.T) {
@@ -211,7 +909,7 @@ func TestAddSymbol(t *testing.T) {
 	app := setupFiberTest(&Handler{sh: symbolHandler{service: mockService}}, utils.TestAuthMiddleware)
 	for _, td := range addSymbolTests {
 		t.Run(utils.TestName(td.name), func(t *testing.T) {
-			if !td.wrongContentType && td.role == model.AdminRole {
+			if td.role == model.AdminRole && (td.expectedCode != 400 || td.serviceError != nil) {
 				mockService.EXPECT().Add(gomock.Any(), td.symbol).Return(td.serviceError)
 			}
 			response, err := app.Test(utils.PostRequest("/api/v1/symbols", td.symbol, td.wrongContentType, map[string]string{"Role": string(td.role)}))
@@ -251,6 +949,21 @@ var addSymbolTests = []struct {
 		wrongContentType: true,
 		expectedResponse: CommonResponse{Code: 400, Message: "Wrong content type"},
 	},
+	{
+		name:             utils.TestName("add symbol with invalid price"),
+		role:             model.AdminRole,
+		symbol:           model.Symbol{Symbol: "TEST", Values: []model.Price{{Date: "2023-06-02", Open: "1.5", High: "1", Low: "2", Close: "1.5"}}},
+		expectedCode:     400,
+		serviceError:     fmt.Errorf("%w: 2023-06-02 low 2 is greater than high 1", model.InvalidPrice),
+		expectedResponse: CommonResponse{Code: 400, Message: "invalid price: 2023-06-02 low 2 is greater than high 1"},
+	},
+	{
+		name:             utils.TestName("add symbol with non-numeric price"),
+		role:             model.AdminRole,
+		symbol:           model.Symbol{Symbol: "TEST", Values: []model.Price{{Date: "2023-06-02", Open: "n/a"}}},
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "invalid decimal: 'n/a' is not a decimal number"},
+	},
 	{
 		name:             utils.TestName("add symbol failed"),
 		role:             model.AdminRole,
@@ -0,0 +1,239 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/gofrs/uuid/v5"
+	"github.com/rs/zerolog"
+	"strconv"
+)
+
+type userHandler struct {
+	service service.UserService
+}
+
+var uhLog zerolog.Logger
+
+func (h *userHandler) errorErrorResponse(c *fiber.Ctx, err error, statusCode int, message string) error {
+	return errorErrorResponse(c, &uhLog, err, statusCode, message)
+}
+
+func (h *userHandler) infoErrorResponse(c *fiber.Ctx, err error, statusCode int, message string) error {
+	return infoErrorResponse(c, &uhLog, err, statusCode, message)
+}
+
+const (
+	defaultUsersLimit = 100
+	maxUsersLimit     = 1000
+)
+
+// GetUsers godoc
+//
+//	@Summary		GetUsers
+//	@Tags			Users
+//	@Description	Get page of users ordered by username. Use next_cursor of the response as cursor to get the next page
+//	@Security		ApiKeyAuth[admin]
+//	@ID				get-users
+//	@Produce		json
+//	@Param			q			query		string			false	"Part of username or email"
+//	@Param			role		query		string			false	"Role of the user"
+//	@Param			disabled	query		bool			false	"Whether the user is disabled"
+//	@Param			limit		query		int				false	"Max number of users on the page"	minimum(1)	maximum(1000)	default(100)
+//	@Param			cursor		query		string			false	"Cursor of the page"
+//	@Success		200			{object}	model.UserPage	"Successful response"
+//	@Failure		400			{object}	CommonResponse	"Client request error"
+//	@Failure		401			{object}	CommonResponse	"Unauthorized"
+//	@Failure		500			{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/admin/users [get]
+func (h *userHandler) GetUsers(c *fiber.Ctx) error {
+	query, err := parseUserQuery(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	page, err := h.service.GetAll(c.Context(), query)
+	if err != nil {
+		return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, "Failed to get users")
+	}
+	return c.Status(fiber.StatusOK).JSON(page)
+}
+
+func parseUserQuery(c *fiber.Ctx) (model.UserQuery, error) {
+	query := model.UserQuery{Search: c.Query("q"), Role: model.Role(c.Query("role")), After: c.Query("cursor")}
+	if c.Query("disabled") != "" {
+		disabled, err := strconv.ParseBool(c.Query("disabled"))
+		if err != nil {
+			return query, errors.New("'disabled' must be true or false")
+		}
+		query.Disabled = &disabled
+	}
+	query.Limit = c.QueryInt("limit", 0)
+	if c.Query("limit") == "" {
+		query.Limit = defaultUsersLimit
+	}
+	if query.Limit < 1 || query.Limit > maxUsersLimit {
+		return query, fmt.Errorf("'limit' must be a number between 1 and %d", maxUsersLimit)
+	}
+	return query, nil
+}
+
+// GetUser godoc
+//
+//	@Summary		GetUser
+//	@Tags			Users
+//	@Description	Get user with roles
+//	@Security		ApiKeyAuth[admin]
+//	@ID				get-user
+//	@Produce		json
+//	@Param			id		path		string			true	"User id"
+//	@Success		200		{object}	model.User		"Successful response"
+//	@Failure		400,404	{object}	CommonResponse	"Client request error"
+//	@Failure		401		{object}	CommonResponse	"Unauthorized"
+//	@Failure		500		{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/admin/users/{id} [get]
+func (h *userHandler) GetUser(c *fiber.Ctx) error {
+	id, err := parseUserID(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	user, err := h.service.Get(c.Context(), id)
+	if err != nil {
+		return h.userErrorResponse(c, err, id, "get")
+	}
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// UpdateRoles godoc
+//
+//	@Summary		UpdateRoles
+//	@Tags			Users
+//	@Description	Replace roles of the user. New roles are applied to tokens issued after the update
+//	@Security		ApiKeyAuth[admin]
+//	@ID				update-user-roles
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string			true	"User id"
+//	@Param			input	body		model.UserRoles	true	"New roles of the user"
+//	@Success		200		{object}	model.User		"Updated user"
+//	@Failure		400,404	{object}	CommonResponse	"Client request error"
+//	@Failure		401		{object}	CommonResponse	"Unauthorized"
+//	@Failure		500		{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/admin/users/{id}/roles [put]
+func (h *userHandler) UpdateRoles(c *fiber.Ctx) error {
+	id, err := parseUserID(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	var roles model.UserRoles
+	if err = c.BodyParser(&roles); err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, "Wrong content type")
+	}
+	if len(roles.Roles) == 0 {
+		return h.infoErrorResponse(c, errors.New("empty roles"), fiber.StatusBadRequest, "'roles' must not be empty")
+	}
+	user, err := h.service.UpdateRoles(c.Context(), id, roles.Roles)
+	if errors.Is(err, model.UnknownRole) {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	} else if err != nil {
+		return h.userErrorResponse(c, err, id, "update roles of")
+	}
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// DisableUser godoc
+//
+//	@Summary		DisableUser
+//	@Tags			Users
+//	@Description	Disable user. Disabled user can't sign in nor refresh tokens and its refresh tokens are revoked. Issued jwt tokens stay valid until they expire, so /api/v1/me routes remain usable until then
+//	@Security		ApiKeyAuth[admin]
+//	@ID				disable-user
+//	@Produce		json
+//	@Param			id		path		string			true	"User id"
+//	@Success		200		{object}	model.User		"Disabled user"
+//	@Failure		400,404	{object}	CommonResponse	"Client request error"
+//	@Failure		401		{object}	CommonResponse	"Unauthorized"
+//	@Failure		500		{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/admin/users/{id}/disable [post]
+func (h *userHandler) DisableUser(c *fiber.Ctx) error {
+	id, err := parseUserID(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	if principalOf(c).ID == id {
+		return h.infoErrorResponse(c, errors.New("self disable"), fiber.StatusBadRequest, "you can't disable yourself")
+	}
+	user, err := h.service.SetDisabled(c.Context(), id, true)
+	if err != nil {
+		return h.userErrorResponse(c, err, id, "disable")
+	}
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// EnableUser godoc
+//
+//	@Summary		EnableUser
+//	@Tags			Users
+//	@Description	Enable disabled user
+//	@Security		ApiKeyAuth[admin]
+//	@ID				enable-user
+//	@Produce		json
+//	@Param			id		path		string			true	"User id"
+//	@Success		200		{object}	model.User		"Enabled user"
+//	@Failure		400,404	{object}	CommonResponse	"Client request error"
+//	@Failure		401		{object}	CommonResponse	"Unauthorized"
+//	@Failure		500		{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/admin/users/{id}/enable [post]
+func (h *userHandler) EnableUser(c *fiber.Ctx) error {
+	id, err := parseUserID(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	user, err := h.service.SetDisabled(c.Context(), id, false)
+	if err != nil {
+		return h.userErrorResponse(c, err, id, "enable")
+	}
+	return c.Status(fiber.StatusOK).JSON(user)
+}
+
+// LogoutUser godoc
+//
+//	@Summary		LogoutUser
+//	@Tags			Users
+//	@Description	Revoke all refresh tokens of the user. Issued jwt tokens stay valid until they expire
+//	@Security		ApiKeyAuth[admin]
+//	@ID				logout-user
+//	@Produce		json
+//	@Param			id		path		string			true	"User id"
+//	@Success		200		{object}	CommonResponse	"Logged out successfully"
+//	@Failure		400,404	{object}	CommonResponse	"Client request error"
+//	@Failure		401		{object}	CommonResponse	"Unauthorized"
+//	@Failure		500		{object}	CommonResponse	"Internal server error"
+//	@Router			/api/v1/admin/users/{id}/logout [post]
+func (h *userHandler) LogoutUser(c *fiber.Ctx) error {
+	id, err := parseUserID(c)
+	if err != nil {
+		return h.infoErrorResponse(c, err, fiber.StatusBadRequest, err.Error())
+	}
+	if err = h.service.Logout(c.Context(), id); err != nil {
+		return h.userErrorResponse(c, err, id, "logout")
+	}
+	return c.Status(fiber.StatusOK).JSON(CommonResponse{Code: fiber.StatusOK, Message: "successful"})
+}
+
+func parseUserID(c *fiber.Ctx) (string, error) {
+	id, err := uuid.FromString(c.Params("id"))
+	if err != nil {
+		return "", errors.New("'id' must be a uuid")
+	}
+	return id.String(), nil
+}
+
+// userErrorResponse returns 404 if the user is not found and 500 otherwise
+func (h *userHandler) userErrorResponse(c *fiber.Ctx, err error, id string, action string) error {
+	if err == model.UserNotFound {
+		return h.infoErrorResponse(c, err, fiber.StatusNotFound, fmt.Sprintf("user %s not found", id))
+	}
+	return h.errorErrorResponse(c, err, fiber.StatusInternalServerError, fmt.Sprintf("Failed to %s %s user", action, id))
+}
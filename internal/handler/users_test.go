@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"errors"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"testing"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/user_service_mock.go -source=../service/user_service.go UserService
+
+const (
+	adminID = "0188f1f4-6b1e-7a3c-9d2e-1b6f0c2a4e01"
+	userID  = "0188f1f4-6b1e-7a3c-9d2e-1b6f0c2a4e02"
+)
+
+var (
+	admin = map[string]string{"Role": string(model.AdminRole), "User-Id": adminID}
+	user  = model.User{ID: userID, Username: "user", Email: "user@test.com", Roles: []model.Role{model.ClientRole}}
+)
+
+func TestGetUsers(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockUserService(controller)
+	app := setupFiberTest(&Handler{uh: userHandler{service: mockService}}, utils.TestAuthMiddleware)
+	for _, td := range getUsersTests {
+		t.Run(td.name, func(t *testing.T) {
+			if td.expectedQuery != nil {
+				mockService.EXPECT().GetAll(gomock.Any(), *td.expectedQuery).Return(td.page, td.serviceError)
+			}
+			response, err := app.Test(utils.GetRequest("/api/v1/admin/users"+td.query, td.headers))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var disabled = true
+
+var getUsersTests = []struct {
+	name             string
+	query            string
+	headers          map[string]string
+	expectedQuery    *model.UserQuery
+	page             model.UserPage
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("get users successfully"),
+		headers:          admin,
+		expectedQuery:    &model.UserQuery{Limit: 100},
+		page:             model.UserPage{Users: []model.User{user}, Total: 1},
+		expectedCode:     200,
+		expectedResponse: model.UserPage{Users: []model.User{user}, Total: 1},
+	},
+	{
+		name:             utils.TestName("search users with filters and cursor"),
+		query:            "?q=test&role=Client&disabled=true&limit=1&cursor=admin",
+		headers:          admin,
+		expectedQuery:    &model.UserQuery{Search: "test", Role: model.ClientRole, Disabled: &disabled, Limit: 1, After: "admin"},
+		page:             model.UserPage{Users: []model.User{user}, Total: 2, NextCursor: "user"},
+		expectedCode:     200,
+		expectedResponse: model.UserPage{Users: []model.User{user}, Total: 2, NextCursor: "user"},
+	},
+	{
+		name:             utils.TestName("invalid disabled"),
+		query:            "?disabled=maybe",
+		headers:          admin,
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'disabled' must be true or false"},
+	},
+	{
+		name:             utils.TestName("limit too big"),
+		query:            "?limit=1001",
+		headers:          admin,
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'limit' must be a number between 1 and 1000"},
+	},
+	{
+		name:             utils.TestName("client is not allowed"),
+		expectedCode:     401,
+		expectedResponse: CommonResponse{Code: 401, Message: "you don't have permissions for this endpoint"},
+	},
+	{
+		name:             utils.TestName("get users failed"),
+		headers:          admin,
+		expectedQuery:    &model.UserQuery{Limit: 100},
+		serviceError:     errors.New("db is down"),
+		expectedCode:     500,
+		expectedResponse: CommonResponse{Code: 500, Message: "Failed to get users"},
+	},
+}
+
+func TestGetUser(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockUserService(controller)
+	app := setupFiberTest(&Handler{uh: userHandler{service: mockService}}, utils.TestAuthMiddleware)
+
+	mockService.EXPECT().Get(gomock.Any(), userID).Return(user, nil)
+	response, err := app.Test(utils.GetRequest("/api/v1/admin/users/"+userID, admin))
+	utils.CommonResponseAssertions(t, response, err, 200, user)
+
+	mockService.EXPECT().Get(gomock.Any(), userID).Return(model.User{}, model.UserNotFound)
+	response, err = app.Test(utils.GetRequest("/api/v1/admin/users/"+userID, admin))
+	utils.CommonResponseAssertions(t, response, err, 404, CommonResponse{Code: 404, Message: fmt.Sprintf("user %s not found", userID)})
+
+	response, err = app.Test(utils.GetRequest("/api/v1/admin/users/123", admin))
+	utils.CommonResponseAssertions(t, response, err, 400, CommonResponse{Code: 400, Message: "'id' must be a uuid"})
+}
+
+func TestUpdateRoles(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockUserService(controller)
+	app := setupFiberTest(&Handler{uh: userHandler{service: mockService}}, utils.TestAuthMiddleware)
+	for _, td := range updateRolesTests {
+		t.Run(td.name, func(t *testing.T) {
+			if len(td.body.Roles) > 0 {
+				mockService.EXPECT().UpdateRoles(gomock.Any(), userID, td.body.Roles).Return(td.user, td.serviceError)
+			}
+			response, err := app.Test(utils.PutRequest("/api/v1/admin/users/"+userID+"/roles", td.body, false, admin))
+			utils.CommonResponseAssertions(t, response, err, td.expectedCode, td.expectedResponse)
+		})
+	}
+}
+
+var updateRolesTests = []struct {
+	name             string
+	body             model.UserRoles
+	user             model.User
+	serviceError     error
+	expectedCode     int
+	expectedResponse interface{}
+}{
+	{
+		name:             utils.TestName("update roles successfully"),
+		body:             model.UserRoles{Roles: []model.Role{model.ClientRole, model.AdminRole}},
+		user:             model.User{ID: userID, Username: "user", Roles: []model.Role{model.ClientRole, model.AdminRole}},
+		expectedCode:     200,
+		expectedResponse: model.User{ID: userID, Username: "user", Roles: []model.Role{model.ClientRole, model.AdminRole}},
+	},
+	{
+		name:             utils.TestName("unknown role"),
+		body:             model.UserRoles{Roles: []model.Role{"Root"}},
+		serviceError:     fmt.Errorf("%w: Root", model.UnknownRole),
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "unknown role: Root"},
+	},
+	{
+		name:             utils.TestName("empty roles"),
+		body:             model.UserRoles{Roles: []model.Role{}},
+		expectedCode:     400,
+		expectedResponse: CommonResponse{Code: 400, Message: "'roles' must not be empty"},
+	},
+	{
+		name:             utils.TestName("user not found"),
+		body:             model.UserRoles{Roles: []model.Role{model.ClientRole}},
+		serviceError:     model.UserNotFound,
+		expectedCode:     404,
+		expectedResponse: CommonResponse{Code: 404, Message: fmt.Sprintf("user %s not found", userID)},
+	},
+}
+
+func TestDisableEnableUser(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockUserService(controller)
+	app := setupFiberTest(&Handler{uh: userHandler{service: mockService}}, utils.TestAuthMiddleware)
+	disabledUser := model.User{ID: userID, Username: "user", Disabled: true}
+
+	mockService.EXPECT().SetDisabled(gomock.Any(), userID, true).Return(disabledUser, nil)
+	response, err := app.Test(utils.PostRequest("/api/v1/admin/users/"+userID+"/disable", nil, false, admin))
+	utils.CommonResponseAssertions(t, response, err, 200, disabledUser)
+
+	response, err = app.Test(utils.PostRequest("/api/v1/admin/users/"+adminID+"/disable", nil, false, admin))
+	utils.CommonResponseAssertions(t, response, err, 400, CommonResponse{Code: 400, Message: "you can't disable yourself"})
+
+	mockService.EXPECT().SetDisabled(gomock.Any(), userID, false).Return(user, nil)
+	response, err = app.Test(utils.PostRequest("/api/v1/admin/users/"+userID+"/enable", nil, false, admin))
+	utils.CommonResponseAssertions(t, response, err, 200, user)
+
+	mockService.EXPECT().SetDisabled(gomock.Any(), userID, false).Return(model.User{}, errors.New("db is down"))
+	response, err = app.Test(utils.PostRequest("/api/v1/admin/users/"+userID+"/enable", nil, false, admin))
+	utils.CommonResponseAssertions(t, response, err, 500, CommonResponse{Code: 500, Message: fmt.Sprintf("Failed to enable %s user", userID)})
+}
+
+func TestLogoutUser(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockService := mock.NewMockUserService(controller)
+	app := setupFiberTest(&Handler{uh: userHandler{service: mockService}}, utils.TestAuthMiddleware)
+
+	mockService.EXPECT().Logout(gomock.Any(), userID).Return(nil)
+	response, err := app.Test(utils.PostRequest("/api/v1/admin/users/"+userID+"/logout", nil, false, admin))
+	utils.CommonResponseAssertions(t, response, err, 200, CommonResponse{Code: 200, Message: "successful"})
+
+	mockService.EXPECT().Logout(gomock.Any(), userID).Return(model.UserNotFound)
+	response, err = app.Test(utils.PostRequest("/api/v1/admin/users/"+userID+"/logout", nil, false, admin))
+	utils.CommonResponseAssertions(t, response, err, 404, CommonResponse{Code: 404, Message: fmt.Sprintf("user %s not found", userID)})
+}
@@ -0,0 +1,34 @@
+package model
+
+import "errors"
+
+var NotEnoughPrices = errors.New("not enough prices")
+
+// Returns are relative changes of the latest close price to close prices of the period start. Nil if there is no price before the period
+type Returns struct {
+	Day   *float64 `json:"1d"`
+	Week  *float64 `json:"1w"`
+	Month *float64 `json:"1m"`
+	YTD   *float64 `json:"ytd"`
+	Year  *float64 `json:"1y"`
+}
+
+// SymbolAnalytics is derived from daily prices of the last year up to the latest stored price.
+// Volatility is annualised standard deviation of daily log returns. MaxDrawdown is the largest relative fall from a peak as a negative fraction
+type SymbolAnalytics struct {
+	Symbol      string   `json:"symbol"`
+	Date        string   `json:"date"`
+	Close       Decimal  `json:"close" swaggertype:"string" example:"180.95"`
+	Returns     Returns  `json:"returns"`
+	Volatility  *float64 `json:"volatility"`
+	MaxDrawdown float64  `json:"max_drawdown"`
+}
+
+// Correlation is matrix of pairwise correlations of daily returns of symbols in the window.
+// Matrix rows and columns are ordered as Symbols. Value is nil if symbols have less than 3 common returns
+type Correlation struct {
+	Symbols []string     `json:"symbols"`
+	From    string       `json:"from"`
+	To      string       `json:"to"`
+	Matrix  [][]*float64 `json:"matrix"`
+}
@@ -3,6 +3,7 @@ package model
 import (
 	"errors"
 	"github.com/go-playground/validator/v10"
+	"time"
 )
 
 type SignUp struct {
@@ -16,12 +17,39 @@ type SignIn struct {
 	Password string `json:"password" validate:"min=6,max=32" binding:"required"`
 }
 
+// UpdateProfile changes only non-empty fields
+type UpdateProfile struct {
+	Username string `json:"username" validate:"omitempty,min=3,max=32,excludesall=!@#?."`
+	Email    string `json:"email" validate:"omitempty,email,max=255"`
+}
+
+type ChangePassword struct {
+	CurrentPassword string `json:"current_password" validate:"min=6,max=32" binding:"required"`
+	NewPassword     string `json:"new_password" validate:"min=6,max=32" binding:"required"`
+}
+
+type DeleteAccount struct {
+	Password string `json:"password" validate:"min=6,max=32" binding:"required"`
+}
+
+type ForgotPassword struct {
+	Email string `json:"email" validate:"email,max=255" binding:"required"`
+}
+
+type ResetPassword struct {
+	Token    string `json:"token" validate:"len=64,hexadecimal" binding:"required"`
+	Password string `json:"password" validate:"min=6,max=32" binding:"required"`
+}
+
 type User struct {
-	ID       string `json:"-"`
-	Username string `json:"username"`
-	Email    string `json:"email"`
-	Role     Role   `json:"role"`
-	Password string `json:"-"`
+	ID        string    `json:"id"`
+	Username  string    `json:"username"`
+	Email     string    `json:"email"`
+	Roles     []Role    `json:"roles"`
+	Disabled  bool      `json:"disabled"`
+	Password  string    `json:"-"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
 }
 
 type SuccessfulAuthentication struct {
@@ -35,7 +63,41 @@ const (
 	ClientRole Role = "Client"
 )
 
-var UserNotFound = errors.New("user not found")
+type Permission string
+
+const (
+	SymbolsRead    Permission = "symbols:read"
+	SymbolsWrite   Permission = "symbols:write"
+	ExchangesRead  Permission = "exchanges:read"
+	ExchangesWrite Permission = "exchanges:write"
+	AnalyticsRead  Permission = "analytics:read"
+	ProvidersRead  Permission = "providers:read"
+	UsersAdmin     Permission = "users:admin"
+)
+
+// Principal is the authenticated user with permissions granted by all of its roles
+type Principal struct {
+	ID          string       `json:"-"`
+	Roles       []Role       `json:"roles"`
+	Permissions []Permission `json:"permissions"`
+}
+
+func (p Principal) HasPermission(permission Permission) bool {
+	for _, granted := range p.Permissions {
+		if granted == permission {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	UserNotFound      = errors.New("user not found")
+	UserAlreadyExists = errors.New("user is already exists")
+	UserDisabled      = errors.New("user is disabled")
+	UnknownRole       = errors.New("unknown role")
+	WrongPassword     = errors.New("wrong password")
+)
 
 type AuthError struct {
 	Field string `json:"field"`
@@ -44,7 +106,7 @@ type AuthError struct {
 
 var validate = validator.New()
 
-func Validate[T SignIn | SignUp](action T) []*AuthError {
+func Validate[T SignIn | SignUp | UpdateProfile | ChangePassword | DeleteAccount | ForgotPassword | ResetPassword](action T) []*AuthError {
 	authErrors := make([]*AuthError, 0)
 	err := validate.Struct(action)
 	if err != nil {
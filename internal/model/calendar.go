@@ -0,0 +1,34 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+// TradingCalendar describes regular sessions of the exchange in its Timezone.
+// Open, Close and EarlyClose are HH:MM local times, EarlyClose is the close of HalfDays.
+// Exchange is closed on weekends and Holidays
+type TradingCalendar struct {
+	MicCode    string   `json:"mic_code" yaml:"-"`
+	Timezone   string   `json:"timezone" yaml:"timezone"`
+	Open       string   `json:"open" yaml:"open"`
+	Close      string   `json:"close" yaml:"close"`
+	EarlyClose string   `json:"early_close,omitempty" yaml:"earlyClose"`
+	Holidays   []string `json:"holidays,omitempty" yaml:"holidays"`
+	HalfDays   []string `json:"half_days,omitempty" yaml:"halfDays"`
+}
+
+// MarketStatus is the state of the exchange at the moment. NextOpen and NextClose are in exchange timezone.
+// NextClose is the close of the current session if exchange is open and of the next session otherwise
+type MarketStatus struct {
+	MicCode   string    `json:"mic_code"`
+	IsOpen    bool      `json:"is_open"`
+	Timezone  string    `json:"timezone"`
+	NextOpen  time.Time `json:"next_open"`
+	NextClose time.Time `json:"next_close"`
+}
+
+var (
+	CalendarNotFound = errors.New("trading calendar not found")
+	InvalidCalendar  = errors.New("invalid trading calendar")
+)
@@ -0,0 +1,22 @@
+package model
+
+import "errors"
+
+var RateNotFound = errors.New("exchange rate not found")
+
+// Rate is the price of a unit of From currency in To currency by the close of Date.
+// Path lists fx symbols the rate is resolved through: direct or inverse pair or two pairs via USD
+type Rate struct {
+	From  string   `json:"from"`
+	To    string   `json:"to"`
+	Date  string   `json:"date,omitempty"`
+	Value Decimal  `json:"rate" swaggertype:"string" example:"157.2315"`
+	Path  []string `json:"path"`
+}
+
+// Conversion is the amount of From currency converted to To currency with the rate
+type Conversion struct {
+	Rate
+	Amount Decimal `json:"amount" swaggertype:"string" example:"100"`
+	Result Decimal `json:"result" swaggertype:"string" example:"15723.15"`
+}
@@ -0,0 +1,163 @@
+package model
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an exact decimal number kept in its text form to not lose precision of prices.
+// Empty Decimal means there is no value and is stored as NULL
+type Decimal string
+
+var InvalidDecimal = errors.New("invalid decimal")
+
+var decimalPattern = regexp.MustCompile(`^[+-]?(\d+(\.\d*)?|\.\d+)$`)
+
+// ParseDecimal validates and normalizes decimal number like 150.2300 or -0.5. Scale of the number is kept
+func ParseDecimal(value string) (Decimal, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return "", nil
+	}
+	if !decimalPattern.MatchString(value) {
+		return "", fmt.Errorf("%w: '%s' is not a decimal number", InvalidDecimal, value)
+	}
+	rat, _ := new(big.Rat).SetString(value)
+	return Decimal(rat.FloatString(scale(value))), nil
+}
+
+// scale returns the number of digits after decimal point
+func scale(value string) int {
+	if point := strings.IndexByte(value, '.'); point >= 0 {
+		return len(value) - point - 1
+	}
+	return 0
+}
+
+func (d Decimal) IsEmpty() bool {
+	return d == ""
+}
+
+func (d Decimal) String() string {
+	return string(d)
+}
+
+// rat returns zero for empty or malformed decimal
+func (d Decimal) rat() *big.Rat {
+	rat, ok := new(big.Rat).SetString(string(d))
+	if !ok {
+		return new(big.Rat)
+	}
+	return rat
+}
+
+// Cmp compares decimals and returns -1 if d < other, 0 if d == other and 1 if d > other. Empty decimal is zero
+func (d Decimal) Cmp(other Decimal) int {
+	return d.rat().Cmp(other.rat())
+}
+
+// Add returns exact sum with the larger scale of the two decimals. Empty decimal is zero
+func (d Decimal) Add(other Decimal) Decimal {
+	sumScale := scale(string(d))
+	if otherScale := scale(string(other)); otherScale > sumScale {
+		sumScale = otherScale
+	}
+	return Decimal(new(big.Rat).Add(d.rat(), other.rat()).FloatString(sumScale))
+}
+
+// Mul returns exact product with the scale of the sum of scales of the two decimals
+func (d Decimal) Mul(other Decimal) Decimal {
+	return Decimal(new(big.Rat).Mul(d.rat(), other.rat()).FloatString(scale(string(d)) + scale(string(other))))
+}
+
+// Div returns quotient rounded to the scale. Error wraps InvalidDecimal on division by zero
+func (d Decimal) Div(other Decimal, scale int) (Decimal, error) {
+	if other.Sign() == 0 {
+		return "", fmt.Errorf("%w: division of %s by zero", InvalidDecimal, d)
+	}
+	return Decimal(new(big.Rat).Quo(d.rat(), other.rat()).FloatString(scale)).Normalize(), nil
+}
+
+// Round returns the decimal rounded half away from zero to at most scale digits after decimal point
+func (d Decimal) Round(scale int) Decimal {
+	if d.IsEmpty() {
+		return d
+	}
+	return Decimal(d.rat().FloatString(scale)).Normalize()
+}
+
+// Normalize drops trailing zeros after decimal point
+func (d Decimal) Normalize() Decimal {
+	value := string(d)
+	if strings.IndexByte(value, '.') < 0 {
+		return d
+	}
+	return Decimal(strings.TrimSuffix(strings.TrimRight(value, "0"), "."))
+}
+
+func (d Decimal) Sign() int {
+	return d.rat().Sign()
+}
+
+// Float64 returns the nearest float64 value for calculations where exactness is not required
+func (d Decimal) Float64() float64 {
+	value, _ := d.rat().Float64()
+	return value
+}
+
+// UnmarshalJSON accepts decimal both as JSON string and JSON number
+func (d *Decimal) UnmarshalJSON(data []byte) error {
+	value := string(data)
+	if value == "null" {
+		*d = ""
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(value); err == nil {
+		value = unquoted
+	}
+	parsed, err := ParseDecimal(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Scan reads NUMERIC column. NULL is scanned as empty decimal
+func (d *Decimal) Scan(src any) error {
+	var value string
+	switch v := src.(type) {
+	case nil:
+		*d = ""
+		return nil
+	case []byte:
+		value = string(v)
+	case string:
+		value = v
+	case int64:
+		value = strconv.FormatInt(v, 10)
+	case float64:
+		value = strconv.FormatFloat(v, 'f', -1, 64)
+	default:
+		return fmt.Errorf("%w: cannot scan %T", InvalidDecimal, src)
+	}
+	parsed, err := ParseDecimal(value)
+	if err != nil {
+		return err
+	}
+	*d = parsed
+	return nil
+}
+
+// Value writes empty decimal as NULL
+func (d Decimal) Value() (driver.Value, error) {
+	if d.IsEmpty() {
+		return nil, nil
+	}
+	return string(d), nil
+}
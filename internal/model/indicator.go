@@ -0,0 +1,18 @@
+package model
+
+import "github.com/galushkoart/finance-api/pkg/indicators"
+
+// Indicator is technical indicator computed from symbol prices of the interval
+type Indicator struct {
+	Symbol   string            `json:"symbol"`
+	Name     string            `json:"name"`
+	Interval Interval          `json:"interval"`
+	Params   indicators.Params `json:"params"`
+	Values   []IndicatorValue  `json:"values"`
+}
+
+// IndicatorValue has values of indicator lines by their names at the date of the price
+type IndicatorValue struct {
+	Date  string             `json:"date"`
+	Lines map[string]float64 `json:"lines"`
+}
@@ -1,6 +1,10 @@
 package model
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 type Symbol struct {
 	ID            int64      `json:"-"`
@@ -12,6 +16,14 @@ type Symbol struct {
 	CurrencyQuote string     `json:"currency_quote,omitempty"`
 	Exchanges     []Exchange `json:"exchanges,omitempty"`
 	Values        []Price    `json:"values,omitempty"`
+	Stale         bool       `json:"stale,omitempty"`
+}
+
+// Quote is the latest data of the symbol in batch response. Error is set if the symbol couldn't be resolved
+type Quote struct {
+	Symbol string  `json:"symbol"`
+	Data   *Symbol `json:"data,omitempty"`
+	Error  string  `json:"error,omitempty"`
 }
 
 type UpdateSymbol struct {
@@ -33,13 +45,152 @@ type Exchange struct {
 	MicCode  string `json:"mic_code,omitempty"`
 }
 
+// UpdateExchange contains exchange fields to update. Nil fields are not changed
+type UpdateExchange struct {
+	Name     *string `json:"name,omitempty"`
+	Country  *string `json:"country,omitempty"`
+	Timezone *string `json:"timezone,omitempty"`
+}
+
 type Price struct {
-	Date   string `json:"date,omitempty"`
-	Open   string `json:"open,omitempty"`
-	High   string `json:"high,omitempty"`
-	Low    string `json:"low,omitempty"`
-	Close  string `json:"close,omitempty"`
-	Volume string `json:"volume,omitempty"`
+	Interval Interval `json:"interval,omitempty"`
+	Date     string   `json:"date,omitempty"`
+	Open     Decimal  `json:"open,omitempty" swaggertype:"string" example:"150.23"`
+	High     Decimal  `json:"high,omitempty" swaggertype:"string" example:"151.10"`
+	Low      Decimal  `json:"low,omitempty" swaggertype:"string" example:"149.87"`
+	Close    Decimal  `json:"close,omitempty" swaggertype:"string" example:"150.95"`
+	Volume   Decimal  `json:"volume,omitempty" swaggertype:"string" example:"53472100"`
+	Provider string   `json:"provider,omitempty"`
+}
+
+// Validate checks that the bar has open, high, low and close, open and close are between low and high and volume is not negative.
+// Error wraps InvalidPrice
+func (p Price) Validate() error {
+	if p.Open.IsEmpty() || p.High.IsEmpty() || p.Low.IsEmpty() || p.Close.IsEmpty() {
+		return fmt.Errorf("%w: %s must have open, high, low and close", InvalidPrice, p.Date)
+	}
+	if p.Low.Cmp(p.High) > 0 {
+		return fmt.Errorf("%w: %s low %s is greater than high %s", InvalidPrice, p.Date, p.Low, p.High)
+	}
+	if p.Open.Cmp(p.Low) < 0 || p.Open.Cmp(p.High) > 0 || p.Close.Cmp(p.Low) < 0 || p.Close.Cmp(p.High) > 0 {
+		return fmt.Errorf("%w: %s open and close must be between low and high", InvalidPrice, p.Date)
+	}
+	if p.Volume.Sign() < 0 {
+		return fmt.Errorf("%w: %s volume %s is negative", InvalidPrice, p.Date, p.Volume)
+	}
+	return nil
+}
+
+// ValidatePrices returns error of the first invalid price
+func ValidatePrices(prices []Price) error {
+	for _, price := range prices {
+		if err := price.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type PriceQuery struct {
+	Symbol   string
+	Interval Interval
+	From     time.Time
+	To       time.Time
+	Limit    int
+}
+
+type BackfillResult struct {
+	Symbol   string   `json:"symbol"`
+	Interval Interval `json:"interval"`
+	From     string   `json:"from"`
+	Prices   int      `json:"prices"`
+}
+
+type Interval string
+
+const (
+	Interval1Min     Interval = "1min"
+	Interval5Min     Interval = "5min"
+	Interval15Min    Interval = "15min"
+	Interval1Hour    Interval = "1h"
+	Interval1Day     Interval = "1day"
+	Interval1Week    Interval = "1week"
+	Interval1Month   Interval = "1month"
+	Interval1Quarter Interval = "1quarter"
+)
+
+// Intervals are intervals of prices stored in repo and loaded from api
+var Intervals = []Interval{Interval1Min, Interval5Min, Interval15Min, Interval1Hour, Interval1Day, Interval1Week}
+
+// ResampledIntervals are intervals of prices aggregated from daily prices on read.
+// Interval1Week is stored as well, stored weekly prices have priority over aggregated ones
+var ResampledIntervals = []Interval{Interval1Week, Interval1Month, Interval1Quarter}
+
+// ParseInterval returns Interval1Day for empty string and error for unsupported interval
+func ParseInterval(interval string) (Interval, error) {
+	return parseInterval(interval, Intervals)
+}
+
+// ParsePriceInterval is ParseInterval which also accepts ResampledIntervals
+func ParsePriceInterval(interval string) (Interval, error) {
+	return parseInterval(interval, append(Intervals, Interval1Month, Interval1Quarter))
+}
+
+func parseInterval(interval string, intervals []Interval) (Interval, error) {
+	if interval == "" {
+		return Interval1Day, nil
+	}
+	for _, supported := range intervals {
+		if Interval(interval) == supported {
+			return supported, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported interval %s", interval)
+}
+
+// OrDefault returns Interval1Day for empty interval
+func (i Interval) OrDefault() Interval {
+	if i == "" {
+		return Interval1Day
+	}
+	return i
 }
 
-var SymbolNotFound = errors.New("symbol not found")
+func (i Interval) IsIntraday() bool {
+	switch i {
+	case Interval1Min, Interval5Min, Interval15Min, Interval1Hour:
+		return true
+	}
+	return false
+}
+
+func (i Interval) IsResampled() bool {
+	switch i {
+	case Interval1Week, Interval1Month, Interval1Quarter:
+		return true
+	}
+	return false
+}
+
+// Layout returns time layout of the price date for the interval
+func (i Interval) Layout() string {
+	if i.IsIntraday() {
+		return DateTimeLayout
+	}
+	return DateLayout
+}
+
+const (
+	DateLayout     = "2006-01-02"
+	DateTimeLayout = "2006-01-02 15:04:05"
+)
+
+var (
+	SymbolNotFound = errors.New("symbol not found")
+	InvalidPrice   = errors.New("invalid price")
+)
+
+var (
+	ExchangeNotFound      = errors.New("exchange not found")
+	ExchangeAlreadyExists = errors.New("exchange already exists")
+)
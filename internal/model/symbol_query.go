@@ -0,0 +1,102 @@
+package model
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+)
+
+type SymbolSort string
+
+const (
+	SortBySymbol SymbolSort = "symbol"
+	SortByName   SymbolSort = "name"
+	SortByClose  SymbolSort = "close"
+	SortByVolume SymbolSort = "volume"
+)
+
+var SymbolSorts = []SymbolSort{SortBySymbol, SortByName, SortByClose, SortByVolume}
+
+// ParseSymbolSort returns SortBySymbol for empty string and error for unsupported sort
+func ParseSymbolSort(sort string) (SymbolSort, error) {
+	if sort == "" {
+		return SortBySymbol, nil
+	}
+	for _, supported := range SymbolSorts {
+		if SymbolSort(sort) == supported {
+			return supported, nil
+		}
+	}
+	return "", fmt.Errorf("unsupported sort %s", sort)
+}
+
+// SymbolQuery filters and sorts symbols with the latest price. Empty filters are ignored.
+// Page starts after Cursor if it is not nil
+type SymbolQuery struct {
+	Type       string
+	Currency   string
+	MicCode    string
+	Country    string
+	Sort       SymbolSort
+	Descending bool
+	Limit      int
+	Cursor     *SymbolCursor
+}
+
+// SymbolCursor points to the last symbol of the page. Value is the value of the sorted field of the symbol
+type SymbolCursor struct {
+	Sort       SymbolSort `json:"sort"`
+	Descending bool       `json:"desc,omitempty"`
+	Value      string     `json:"value"`
+	Symbol     string     `json:"symbol"`
+}
+
+func (c SymbolCursor) Encode() string {
+	encoded, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(encoded)
+}
+
+func ParseSymbolCursor(cursor string) (*SymbolCursor, error) {
+	decoded, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+	var result SymbolCursor
+	if err = json.Unmarshal(decoded, &result); err != nil {
+		return nil, err
+	}
+	if _, err = ParseSymbolSort(string(result.Sort)); err != nil || result.Sort == "" {
+		return nil, fmt.Errorf("unsupported cursor sort %s", result.Sort)
+	}
+	return &result, nil
+}
+
+// SortValue returns value of the field the symbols are sorted by
+func (s Symbol) SortValue(sort SymbolSort) string {
+	switch sort {
+	case SortByName:
+		return s.Name
+	case SortByClose, SortByVolume:
+		if len(s.Values) == 0 {
+			return "0"
+		}
+		value := s.Values[0].Close
+		if sort == SortByVolume {
+			value = s.Values[0].Volume
+		}
+		if value.IsEmpty() {
+			return "0"
+		}
+		return value.String()
+	default:
+		return s.Symbol
+	}
+}
+
+// SymbolPage is a page of symbols. Total is the number of symbols matching filters on all pages.
+// NextCursor is empty on the last page
+type SymbolPage struct {
+	Symbols    []Symbol `json:"symbols"`
+	Total      int      `json:"total"`
+	NextCursor string   `json:"next_cursor,omitempty"`
+}
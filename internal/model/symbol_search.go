@@ -0,0 +1,18 @@
+package model
+
+// SearchSourceLocal is the source of symbols found in the stored catalogue
+const SearchSourceLocal = "local"
+
+// SymbolMatch is a symbol found by search. Matches are ordered by Score, the best match first.
+// Source is either SearchSourceLocal or the name of market data provider
+type SymbolMatch struct {
+	Symbol   string  `json:"symbol"`
+	Name     string  `json:"name,omitempty"`
+	Type     string  `json:"type,omitempty"`
+	Currency string  `json:"currency,omitempty"`
+	Exchange string  `json:"exchange,omitempty"`
+	MicCode  string  `json:"mic_code,omitempty"`
+	Country  string  `json:"country,omitempty"`
+	Source   string  `json:"source"`
+	Score    float64 `json:"score,omitempty"`
+}
@@ -11,6 +11,13 @@ type RefreshToken struct {
 	ExpiresAt time.Time
 }
 
+// ResetToken is single-use password reset token. Only sha256 hash of the token is stored
+type ResetToken struct {
+	UserId    string
+	TokenHash string
+	ExpiresAt time.Time
+}
+
 var (
 	TokenNotFound = errors.New("token not found")
 	TokenExpired  = errors.New("token expired")
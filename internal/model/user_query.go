@@ -0,0 +1,21 @@
+package model
+
+// UserQuery filters users by case-insensitive part of username or email, role and disabled flag.
+// Users are ordered by username and After is the username of the last user of the previous page
+type UserQuery struct {
+	Search   string
+	Role     Role
+	Disabled *bool
+	Limit    int
+	After    string
+}
+
+type UserPage struct {
+	Users      []User `json:"users"`
+	Total      int    `json:"total"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+type UserRoles struct {
+	Roles []Role `json:"roles"`
+}
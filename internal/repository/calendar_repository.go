@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/goccy/go-json"
+	"github.com/jmoiron/sqlx"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type calendarRepositoryPostgres struct {
+	db *sqlx.DB
+}
+
+// CalendarRepository stores trading calendars edited by admins
+type CalendarRepository interface {
+	GetAll(ctx context.Context) ([]model.TradingCalendar, error)
+	Save(ctx context.Context, calendar model.TradingCalendar) error
+}
+
+func crLog(c context.Context, e *zerolog.Event) *zerolog.Event {
+	return utils.LogRequest(c, e).Str("from", "calendarRepositoryPostgres")
+}
+
+func NewCalendarRepository(db *sqlx.DB) CalendarRepository {
+	return &calendarRepositoryPostgres{db: db}
+}
+
+func (r *calendarRepositoryPostgres) GetAll(ctx context.Context) ([]model.TradingCalendar, error) {
+	const calendarsQuery = `SELECT calendar FROM EXCHANGE_CALENDAR`
+	var stored [][]byte
+	if err := r.db.SelectContext(ctx, &stored, calendarsQuery); err != nil {
+		crLog(ctx, log.Error()).Err(err).Msg("Cannot retrieve trading calendars!")
+		return nil, err
+	}
+	result := make([]model.TradingCalendar, 0, len(stored))
+	for _, raw := range stored {
+		var calendar model.TradingCalendar
+		if err := json.Unmarshal(raw, &calendar); err != nil {
+			crLog(ctx, log.Error()).Err(err).Msg("Cannot parse stored trading calendar!")
+			return nil, err
+		}
+		result = append(result, calendar)
+	}
+	return result, nil
+}
+
+func (r *calendarRepositoryPostgres) Save(ctx context.Context, calendar model.TradingCalendar) error {
+	const calendarUpsert = `INSERT INTO EXCHANGE_CALENDAR (code, calendar) VALUES ($1, $2) ON CONFLICT (code) DO UPDATE SET calendar = EXCLUDED.calendar, updated_at = NOW()`
+	raw, err := json.Marshal(calendar)
+	if err != nil {
+		return err
+	}
+	if _, err = r.db.ExecContext(ctx, calendarUpsert, calendar.MicCode, string(raw)); err != nil {
+		crLog(ctx, log.Warn()).Err(err).Msgf("Fail on save %s trading calendar!", calendar.MicCode)
+	}
+	return err
+}
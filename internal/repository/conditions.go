@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+)
+
+// likeEscaper escapes wildcards of user input in LIKE patterns
+var likeEscaper = strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`)
+
+// conditions collects WHERE conditions of a query together with their positional arguments
+type conditions struct {
+	list []string
+	args []any
+}
+
+// add appends condition with values as arguments. Every value is referenced in condition by $%d verb in order
+func (c *conditions) add(condition string, values ...any) {
+	placeholders := make([]any, 0, len(values))
+	for _, value := range values {
+		placeholders = append(placeholders, c.arg(value))
+	}
+	c.list = append(c.list, fmt.Sprintf(condition, placeholders...))
+}
+
+// arg appends argument which isn't a part of conditions, e.g. limit, and returns its position
+func (c *conditions) arg(value any) int {
+	c.args = append(c.args, value)
+	return len(c.args)
+}
+
+func (c *conditions) where() string {
+	if len(c.list) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(c.list, " AND ")
+}
@@ -1,17 +1,20 @@
 package repository
 
 import (
+	"github.com/galushkoart/finance-api/internal/model"
 	"time"
 )
 
 type price struct {
-	SymbolID int64     `db:"symbol_id"`
-	Date     time.Time `db:"date"`
-	Open     string    `db:"open"`
-	High     string    `db:"high"`
-	Low      string    `db:"low"`
-	Close    string    `db:"close"`
-	Volume   string    `db:"volume"`
+	SymbolID int64         `db:"symbol_id"`
+	Interval string        `db:"interval"`
+	Date     time.Time     `db:"date"`
+	Open     model.Decimal `db:"open"`
+	High     model.Decimal `db:"high"`
+	Low      model.Decimal `db:"low"`
+	Close    model.Decimal `db:"close"`
+	Volume   model.Decimal `db:"volume"`
+	Provider string        `db:"provider"`
 }
 
 type symbol struct {
@@ -24,6 +27,17 @@ type symbol struct {
 	CurrencyQuote string `db:"currency_quote"`
 }
 
+type symbolMatch struct {
+	Symbol     string  `db:"symbol"`
+	Name       string  `db:"name"`
+	SymbolType string  `db:"type"`
+	Currency   string  `db:"currency"`
+	Exchange   string  `db:"exchange"`
+	MicCode    string  `db:"mic_code"`
+	Country    string  `db:"country"`
+	Score      float64 `db:"score"`
+}
+
 type exchange struct {
 	ID       int64  `db:"id"`
 	Name     string `db:"name"`
@@ -32,18 +46,34 @@ type exchange struct {
 	Timezone string `db:"timezone"`
 }
 
+type symbolExchange struct {
+	SymbolID int64 `db:"symbol_id"`
+	exchange
+}
+
 type userEntity struct {
 	ID        string    `db:"id"`
 	Username  string    `db:"username"`
 	Email     string    `db:"email"`
 	Password  string    `db:"password"`
+	Disabled  bool      `db:"disabled"`
 	CreatedAt time.Time `db:"created_at"`
 	UpdatedAt time.Time `db:"updated_at"`
 }
 
+type userRole struct {
+	UserID string     `db:"user_id"`
+	Role   model.Role `db:"role"`
+}
+
 type refreshToken struct {
 	ID        int64     `db:"id"`
 	UserId    string    `db:"user_id"`
 	Token     string    `db:"token"`
 	ExpiresAt time.Time `db:"expires_at"`
 }
+
+type resetToken struct {
+	UserId    string    `db:"user_id"`
+	ExpiresAt time.Time `db:"expires_at"`
+}
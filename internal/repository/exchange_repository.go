@@ -0,0 +1,214 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+type exchangeRepositoryPostgres struct {
+	db *sqlx.DB
+}
+
+type ExchangeRepository interface {
+	GetAll(ctx context.Context) ([]model.Exchange, error)
+	GetByMicCode(ctx context.Context, micCode string) (model.Exchange, error)
+	Add(ctx context.Context, exchange model.Exchange) error
+	Update(ctx context.Context, micCode string, exchange model.UpdateExchange) error
+	Delete(ctx context.Context, micCode string) error
+	GetSymbols(ctx context.Context, micCode string) ([]model.Symbol, error)
+	AttachSymbol(ctx context.Context, micCode string, symbolName string) error
+	DetachSymbol(ctx context.Context, micCode string, symbolName string) error
+}
+
+func erLog(c context.Context, e *zerolog.Event) *zerolog.Event {
+	return utils.LogRequest(c, e).Str("from", "exchangeRepositoryPostgres")
+}
+
+func NewExchangeRepository(db *sqlx.DB) ExchangeRepository {
+	return &exchangeRepositoryPostgres{db: db}
+}
+
+const (
+	exchangesQuery         = `SELECT id, name, COALESCE(country, '') AS country, code, COALESCE(timezone, '') AS timezone FROM EXCHANGE ORDER BY code`
+	exchangeByMicCodeQuery = `SELECT id, name, COALESCE(country, '') AS country, code, COALESCE(timezone, '') AS timezone FROM EXCHANGE WHERE CODE = $1`
+	exchangeIdQuery        = `SELECT id FROM EXCHANGE WHERE CODE = $1`
+	symbolIdQuery          = `SELECT id FROM SYMBOL WHERE SYMBOL = $1`
+	symbolsByExchangeQuery = `SELECT S.id, S.symbol, S.name, S.type, COALESCE(S.currency, '') AS currency, COALESCE(S.currency_base, '') AS currency_base, COALESCE(S.currency_quote, '') AS currency_quote FROM SYMBOL S JOIN SYMBOL_EXCHANGE SE ON SE.SYMBOL_ID = S.ID WHERE SE.EXCHANGE_ID = $1 ORDER BY S.symbol`
+	symbolExchangeUpsert   = `INSERT INTO SYMBOL_EXCHANGE (symbol_id, exchange_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`
+	symbolExchangeDelete   = `DELETE FROM SYMBOL_EXCHANGE WHERE SYMBOL_ID = $1 AND EXCHANGE_ID = $2`
+)
+
+// uniqueViolationCode is postgres error code of unique constraint violation
+const uniqueViolationCode = "23505"
+
+func (r *exchangeRepositoryPostgres) GetAll(ctx context.Context) ([]model.Exchange, error) {
+	var stored []exchange
+	if err := r.db.SelectContext(ctx, &stored, exchangesQuery); err != nil {
+		erLog(ctx, log.Error()).Err(err).Msg("Cannot retrieve exchanges!")
+		return nil, err
+	}
+	result := make([]model.Exchange, 0, len(stored))
+	for _, storedExchange := range stored {
+		result = append(result, exchangeToModel(storedExchange))
+	}
+	return result, nil
+}
+
+func (r *exchangeRepositoryPostgres) GetByMicCode(ctx context.Context, micCode string) (model.Exchange, error) {
+	var stored exchange
+	err := r.db.GetContext(ctx, &stored, exchangeByMicCodeQuery, micCode)
+	if err == sql.ErrNoRows {
+		return model.Exchange{}, model.ExchangeNotFound
+	} else if err != nil {
+		erLog(ctx, log.Error()).Err(err).Msgf("Cannot retrieve %s exchange!", micCode)
+		return model.Exchange{}, err
+	}
+	return exchangeToModel(stored), nil
+}
+
+func (r *exchangeRepositoryPostgres) Add(ctx context.Context, newExchange model.Exchange) error {
+	const exchangeInsert = `INSERT INTO EXCHANGE (name, code, country, timezone) VALUES ($1, $2, $3, $4)`
+	_, err := r.db.ExecContext(ctx, exchangeInsert, newExchange.Name, newExchange.MicCode, newExchange.Country, newExchange.Timezone)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		return model.ExchangeAlreadyExists
+	} else if err != nil {
+		erLog(ctx, log.Warn()).Err(err).Msgf("Fail on insert %s exchange!", newExchange.MicCode)
+	}
+	return err
+}
+
+func (r *exchangeRepositoryPostgres) Update(ctx context.Context, micCode string, newExchange model.UpdateExchange) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		erLog(ctx, log.Error()).Err(err).Msg("Failed to begin transaction")
+		return err
+	}
+	var stored exchange
+	err = tx.GetContext(ctx, &stored, exchangeByMicCodeQuery+" FOR UPDATE", micCode)
+	if err != nil {
+		utils.PanicOnError(tx.Rollback())
+		if err == sql.ErrNoRows {
+			return model.ExchangeNotFound
+		}
+		erLog(ctx, log.Info()).Err(err).Msgf("Cannot update %s exchange!", micCode)
+		return err
+	}
+	if newExchange.Name != nil {
+		stored.Name = *newExchange.Name
+	}
+	if newExchange.Country != nil {
+		stored.Country = *newExchange.Country
+	}
+	if newExchange.Timezone != nil {
+		stored.Timezone = *newExchange.Timezone
+	}
+	const exchangeUpdate = `UPDATE EXCHANGE SET name = $1, country = $2, timezone = $3 WHERE id = $4`
+	if _, err = tx.ExecContext(ctx, exchangeUpdate, stored.Name, stored.Country, stored.Timezone, stored.ID); err != nil {
+		erLog(ctx, log.Info()).Err(err).Msgf("Fail on update %s exchange!", micCode)
+		utils.PanicOnError(tx.Rollback())
+		return err
+	}
+	return tx.Commit()
+}
+
+func (r *exchangeRepositoryPostgres) Delete(ctx context.Context, micCode string) error {
+	const exchangeDelete = `DELETE FROM EXCHANGE WHERE CODE = $1`
+	result, err := r.db.ExecContext(ctx, exchangeDelete, micCode)
+	if err != nil {
+		erLog(ctx, log.Info()).Err(err).Msgf("Cannot delete %s exchange!", micCode)
+		return err
+	}
+	affected, _ := result.RowsAffected()
+	if affected < 1 {
+		return model.ExchangeNotFound
+	}
+	return nil
+}
+
+// GetSymbols returns reference data of symbols traded on the exchange ordered by symbol
+func (r *exchangeRepositoryPostgres) GetSymbols(ctx context.Context, micCode string) ([]model.Symbol, error) {
+	exchangeID, err := r.exchangeID(ctx, micCode)
+	if err != nil {
+		return nil, err
+	}
+	var stored []symbol
+	if err = r.db.SelectContext(ctx, &stored, symbolsByExchangeQuery, exchangeID); err != nil {
+		erLog(ctx, log.Error()).Err(err).Msgf("Cannot retrieve symbols of %s exchange!", micCode)
+		return nil, err
+	}
+	result := make([]model.Symbol, 0, len(stored))
+	for _, storedSymbol := range stored {
+		result = append(result, model.Symbol{
+			Symbol:        storedSymbol.Symbol,
+			Name:          storedSymbol.Name,
+			Type:          storedSymbol.SymbolType,
+			Currency:      storedSymbol.Currency,
+			CurrencyBase:  storedSymbol.CurrencyBase,
+			CurrencyQuote: storedSymbol.CurrencyQuote,
+		})
+	}
+	return result, nil
+}
+
+// AttachSymbol links the symbol to the exchange. Attaching already attached symbol is not an error
+func (r *exchangeRepositoryPostgres) AttachSymbol(ctx context.Context, micCode string, symbolName string) error {
+	exchangeID, symbolID, err := r.relationIDs(ctx, micCode, symbolName)
+	if err != nil {
+		return err
+	}
+	if _, err = r.db.ExecContext(ctx, symbolExchangeUpsert, symbolID, exchangeID); err != nil {
+		erLog(ctx, log.Warn()).Err(err).Msgf("Fail on attach %s symbol to %s exchange!", symbolName, micCode)
+	}
+	return err
+}
+
+// DetachSymbol unlinks the symbol from the exchange. Detaching not attached symbol is not an error
+func (r *exchangeRepositoryPostgres) DetachSymbol(ctx context.Context, micCode string, symbolName string) error {
+	exchangeID, symbolID, err := r.relationIDs(ctx, micCode, symbolName)
+	if err != nil {
+		return err
+	}
+	if _, err = r.db.ExecContext(ctx, symbolExchangeDelete, symbolID, exchangeID); err != nil {
+		erLog(ctx, log.Warn()).Err(err).Msgf("Fail on detach %s symbol from %s exchange!", symbolName, micCode)
+	}
+	return err
+}
+
+func (r *exchangeRepositoryPostgres) relationIDs(ctx context.Context, micCode string, symbolName string) (int64, int64, error) {
+	exchangeID, err := r.exchangeID(ctx, micCode)
+	if err != nil {
+		return 0, 0, err
+	}
+	var symbolID int64
+	err = r.db.GetContext(ctx, &symbolID, symbolIdQuery, symbolName)
+	if err == sql.ErrNoRows {
+		return 0, 0, model.SymbolNotFound
+	}
+	return exchangeID, symbolID, err
+}
+
+func (r *exchangeRepositoryPostgres) exchangeID(ctx context.Context, micCode string) (int64, error) {
+	var exchangeID int64
+	err := r.db.GetContext(ctx, &exchangeID, exchangeIdQuery, micCode)
+	if err == sql.ErrNoRows {
+		return 0, model.ExchangeNotFound
+	}
+	return exchangeID, err
+}
+
+func exchangeToModel(stored exchange) model.Exchange {
+	return model.Exchange{
+		Name:     stored.Name,
+		Country:  stored.Country,
+		Timezone: stored.Timezone,
+		MicCode:  stored.Code,
+	}
+}
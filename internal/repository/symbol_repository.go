@@ -3,11 +3,14 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"time"
 )
 
 type symbolRepositoryPostgres struct {
@@ -17,7 +20,12 @@ type symbolRepositoryPostgres struct {
 type SymbolRepository interface {
 	Add(ctx context.Context, symbol model.Symbol) error
 	GetBySymbol(ctx context.Context, name string) (model.Symbol, error)
-	GetAll(ctx context.Context) ([]model.Symbol, error)
+	GetAll(ctx context.Context, query model.SymbolQuery) (model.SymbolPage, error)
+	GetBySymbols(ctx context.Context, names []string) ([]model.Symbol, error)
+	Search(ctx context.Context, query string, limit int) ([]model.SymbolMatch, error)
+	GetPrices(ctx context.Context, query model.PriceQuery) ([]model.Price, error)
+	UpsertPrices(ctx context.Context, symbolName string, prices []model.Price) error
+	GetLatestDates(ctx context.Context, interval model.Interval) (map[string]time.Time, error)
 	Update(ctx context.Context, symbol model.UpdateSymbol) error
 	Delete(ctx context.Context, symbolName string) error
 }
@@ -31,12 +39,30 @@ func NewSymbolRepository(db *sqlx.DB) SymbolRepository {
 }
 
 const (
-	symbolQuery              = `SELECT id, symbol, name, type, currency, currency_base, currency_quote FROM SYMBOL WHERE SYMBOL = $1`
-	exchangeQuery            = `SELECT id, name, country, code, timezone FROM EXCHANGE WHERE NAME = $1`
-	exchangesBySymbolIdQuery = `SELECT E.id, E.name, E.country, E.code, E.timezone FROM EXCHANGE E JOIN symbol_exchange se ON se.EXCHANGE_ID = E.ID  WHERE SYMBOL_ID = $1`
-	symbolExchangeInsert     = `INSERT INTO symbol_exchange (symbol_id, exchange_id) VALUES ($1, $2)`
-	symbolsWithLatestPrice   = `SELECT id, symbol, name, type, currency, currency_base, currency_quote, date, open, close, high, low, volume FROM v_latest_symbol_info`
-	symbolWithLatestPrice    = `SELECT id, symbol, name, type, currency, currency_base, currency_quote, date, open, close, high, low, volume FROM v_latest_symbol_info where symbol = $1`
+	symbolQuery               = `SELECT id, symbol, name, type, currency, currency_base, currency_quote FROM SYMBOL WHERE SYMBOL = $1`
+	exchangesBySymbolIdQuery  = `SELECT E.id, E.name, E.country, E.code, E.timezone FROM EXCHANGE E JOIN symbol_exchange se ON se.EXCHANGE_ID = E.ID  WHERE SYMBOL_ID = $1`
+	exchangesBySymbolIdsQuery = `SELECT se.symbol_id, E.id, E.name, E.country, E.code, E.timezone FROM EXCHANGE E JOIN symbol_exchange se ON se.EXCHANGE_ID = E.ID WHERE se.SYMBOL_ID = ANY($1)`
+	symbolsWithLatestPrice    = `SELECT id, symbol, name, type, currency, currency_base, currency_quote, date, open, close, high, low, volume FROM v_latest_symbol_info V`
+	symbolsCount              = `SELECT COUNT(*) FROM v_latest_symbol_info V`
+	symbolsWithLatestPriceIn  = symbolsWithLatestPrice + ` where symbol = ANY($1)`
+	symbolWithLatestPrice     = `SELECT id, symbol, name, type, currency, currency_base, currency_quote, date, open, close, high, low, volume FROM v_latest_symbol_info where symbol = $1`
+	symbolSearchQuery         = `SELECT S.symbol, S.name, S.type, COALESCE(S.currency, '') AS currency, COALESCE(E.name, '') AS exchange, COALESCE(E.code, '') AS mic_code, COALESCE(E.country, '') AS country,
+       CASE WHEN UPPER(S.SYMBOL) = UPPER($1) THEN 3 WHEN S.SYMBOL ILIKE $2 THEN 2 WHEN S.NAME ILIKE $2 THEN 1 ELSE 0 END + GREATEST(SIMILARITY(S.SYMBOL, $1), SIMILARITY(S.NAME, $1)) AS score
+FROM SYMBOL S
+         LEFT JOIN LATERAL (SELECT E.name, E.code, E.country FROM SYMBOL_EXCHANGE SE JOIN EXCHANGE E ON E.ID = SE.EXCHANGE_ID WHERE SE.SYMBOL_ID = S.ID ORDER BY E.ID LIMIT 1) E ON TRUE
+WHERE S.SYMBOL ILIKE $2 OR S.NAME ILIKE $3 OR S.SYMBOL % $1 OR S.NAME % $1
+ORDER BY score DESC, S.SYMBOL
+LIMIT $4`
+	pricesInRangeQuery = `SELECT symbol_id, interval, date, open, close, high, low, volume, provider FROM (SELECT symbol_id, interval, date, open, close, high, low, volume, COALESCE(provider, '') AS provider FROM PRICE WHERE SYMBOL_ID = $1 AND INTERVAL = $2 AND DATE >= $3 AND DATE < $4 ORDER BY DATE DESC LIMIT $5) P ORDER BY DATE`
+)
+
+// Exchanges are identified by MIC code
+const (
+	// exchangeMerge stores exchange received from provider keeping non-empty fields of the stored exchange
+	exchangeMerge = `INSERT INTO EXCHANGE (name, code, country, timezone) VALUES ($1, $2, $3, $4) ON CONFLICT (code) DO UPDATE
+SET name = COALESCE(NULLIF(EXCHANGE.name, ''), EXCLUDED.name), country = COALESCE(NULLIF(EXCHANGE.country, ''), EXCLUDED.country), timezone = COALESCE(NULLIF(EXCHANGE.timezone, ''), EXCLUDED.timezone) RETURNING id`
+	// exchangeUpsert stores exchange replacing fields of the stored exchange
+	exchangeUpsert = `INSERT INTO EXCHANGE (name, code, country, timezone) VALUES ($1, $2, $3, $4) ON CONFLICT (code) DO UPDATE SET name = EXCLUDED.name, country = EXCLUDED.country, timezone = EXCLUDED.timezone RETURNING id`
 )
 
 func (r *symbolRepositoryPostgres) Add(ctx context.Context, newSymbol model.Symbol) error {
@@ -61,38 +87,24 @@ func (r *symbolRepositoryPostgres) Add(ctx context.Context, newSymbol model.Symb
 			return err
 		}
 	}
-	if len(newSymbol.Exchanges) > 0 {
-		var storedExchange exchange
-		for _, exchange := range newSymbol.Exchanges {
-			srLog(ctx, log.Debug()).Msgf("Searching for %s exchange!", exchange.Name)
-			err = tx.Get(&storedExchange, exchangeQuery, exchange.Name)
-			if err != nil {
-				if err != sql.ErrNoRows {
-					srLog(ctx, log.Error()).Stack().Err(err).Msg("Found error in exchange select!")
-					return err
-				}
-				srLog(ctx, log.Info()).Msgf("New symbol exchange %s reference not found Trying to insert received values!", exchange.Name)
-				const exchangeInsert = `INSERT INTO EXCHANGE (name, code, country, timezone) VALUES ($1, $2, $3, $4) RETURNING id`
-				row := tx.QueryRow(exchangeInsert, exchange.Name, exchange.MicCode, exchange.Country, exchange.Timezone)
-				if err := row.Scan(&storedExchange.ID); err != nil {
-					srLog(ctx, log.Warn()).Err(err).Msg("Fail on insert new exchange!")
-					utils.PanicOnError(tx.Rollback())
-					return err
-				}
-			}
-			srLog(ctx, log.Debug()).Msgf("Searching for %s symbol - %s exchange relation!", newSymbol.Symbol, exchange.Name)
-			_, err := tx.Exec(symbolExchangeInsert, stored.ID, storedExchange.ID)
-			if err != nil {
-				srLog(ctx, log.Warn()).Err(err).Msg("Fail on insert symbol exchange relation!")
-				utils.PanicOnError(tx.Rollback())
-				return err
-			}
+	for _, exchange := range newSymbol.Exchanges {
+		srLog(ctx, log.Debug()).Msgf("Storing %s exchange of %s symbol!", exchange.MicCode, newSymbol.Symbol)
+		var exchangeID int64
+		if err = tx.QueryRow(exchangeMerge, exchange.Name, exchange.MicCode, exchange.Country, exchange.Timezone).Scan(&exchangeID); err != nil {
+			srLog(ctx, log.Warn()).Err(err).Msgf("Fail on store %s exchange!", exchange.MicCode)
+			utils.PanicOnError(tx.Rollback())
+			return err
+		}
+		if _, err = tx.Exec(symbolExchangeUpsert, stored.ID, exchangeID); err != nil {
+			srLog(ctx, log.Warn()).Err(err).Msg("Fail on insert symbol exchange relation!")
+			utils.PanicOnError(tx.Rollback())
+			return err
 		}
 	}
 	for _, price := range newSymbol.Values {
 		srLog(ctx, log.Debug()).Msgf("Inserting price %+v for %s", price, newSymbol.Symbol)
-		const priceInsert = `INSERT INTO PRICE(SYMBOL_ID, DATE, OPEN, CLOSE, HIGH, LOW, VOLUME) VALUES ($1, $2, $3, $4, $5, $6, $7)`
-		_, err := tx.Exec(priceInsert, stored.ID, price.Date, price.Open, price.Close, price.High, price.Low, price.Volume)
+		const priceInsert = `INSERT INTO PRICE(SYMBOL_ID, INTERVAL, DATE, OPEN, CLOSE, HIGH, LOW, VOLUME, PROVIDER) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))`
+		_, err := tx.Exec(priceInsert, stored.ID, price.Interval.OrDefault(), price.Date, price.Open, price.Close, price.High, price.Low, price.Volume, price.Provider)
 		if err != nil {
 			srLog(ctx, log.Warn()).Err(err).Msg("Fail on insert new price!")
 			utils.PanicOnError(tx.Rollback())
@@ -128,35 +140,32 @@ func (r *symbolRepositoryPostgres) Update(ctx context.Context, newSymbol model.U
 		utils.PanicOnError(tx.Rollback())
 		return err
 	}
-	if len(newSymbol.Exchanges) > 0 {
-		var storedExchange exchange
-		for _, exchange := range newSymbol.Exchanges {
-			err = tx.Get(&storedExchange, exchangeQuery, exchange.Name)
-			if err != nil {
-				srLog(ctx, log.Info()).Err(err).Msgf("Cannot update %s exchange!", exchange.Name)
-				utils.PanicOnError(tx.Rollback())
-				return err
-			}
-			const exchangeUpdate = `UPDATE EXCHANGE SET name = $1, code = $2, country = $3, timezone = $4 where id = $5`
-			_, err := tx.Exec(exchangeUpdate, exchange.Name, exchange.MicCode, exchange.Country, exchange.Timezone, storedExchange.ID)
-			if err != nil {
-				srLog(ctx, log.Info()).Err(err).Msgf("Fail on update %s exchange!", exchange.Name)
-			}
+	for _, exchange := range newSymbol.Exchanges {
+		var exchangeID int64
+		if err = tx.QueryRow(exchangeUpsert, exchange.Name, exchange.MicCode, exchange.Country, exchange.Timezone).Scan(&exchangeID); err != nil {
+			srLog(ctx, log.Info()).Err(err).Msgf("Cannot update %s exchange!", exchange.MicCode)
+			utils.PanicOnError(tx.Rollback())
+			return err
+		}
+		if _, err = tx.Exec(symbolExchangeUpsert, stored.ID, exchangeID); err != nil {
+			srLog(ctx, log.Info()).Err(err).Msgf("Fail on attach %s symbol to %s exchange!", newSymbol.Symbol, exchange.MicCode)
+			utils.PanicOnError(tx.Rollback())
+			return err
 		}
 	}
 	var storedPrice price
 	for _, price := range newSymbol.Values {
-		const priceQuery = `SELECT SYMBOL_ID, DATE, OPEN, CLOSE, HIGH, LOW, VOLUME FROM PRICE WHERE SYMBOL_ID = $1 AND DATE = $2`
-		err = tx.Get(&storedPrice, priceQuery, stored.ID, price.Date)
+		const priceQuery = `SELECT SYMBOL_ID, INTERVAL, DATE, OPEN, CLOSE, HIGH, LOW, VOLUME FROM PRICE WHERE SYMBOL_ID = $1 AND INTERVAL = $2 AND DATE = $3`
+		err = tx.Get(&storedPrice, priceQuery, stored.ID, price.Interval.OrDefault(), price.Date)
 		if err != nil {
-			const priceInsert = `INSERT INTO PRICE(SYMBOL_ID, DATE, OPEN, CLOSE, HIGH, LOW, VOLUME) VALUES ($1, $2, $3, $4, $5, $6, $7)`
-			_, err := tx.Exec(priceInsert, stored.ID, price.Date, price.Open, price.Close, price.High, price.Low, price.Volume)
+			const priceInsert = `INSERT INTO PRICE(SYMBOL_ID, INTERVAL, DATE, OPEN, CLOSE, HIGH, LOW, VOLUME, PROVIDER) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))`
+			_, err := tx.Exec(priceInsert, stored.ID, price.Interval.OrDefault(), price.Date, price.Open, price.Close, price.High, price.Low, price.Volume, price.Provider)
 			if err != nil {
 				srLog(ctx, log.Info()).Err(err).Msg("Fail on insert price!")
 			}
 		} else {
-			const priceUpdate = `UPDATE PRICE SET OPEN = $1, CLOSE = $2, HIGH = $3, LOW = $4, VOLUME = $5 WHERE SYMBOL_ID = $6 AND DATE = $7`
-			_, err := tx.Exec(priceUpdate, price.Open, price.Close, price.High, price.Low, price.Volume, stored.ID, price.Date)
+			const priceUpdate = `UPDATE PRICE SET OPEN = $1, CLOSE = $2, HIGH = $3, LOW = $4, VOLUME = $5, PROVIDER = NULLIF($6, '') WHERE SYMBOL_ID = $7 AND INTERVAL = $8 AND DATE = $9`
+			_, err := tx.Exec(priceUpdate, price.Open, price.Close, price.High, price.Low, price.Volume, price.Provider, stored.ID, price.Interval.OrDefault(), price.Date)
 			if err != nil {
 				srLog(ctx, log.Info()).Err(err).Msg("Fail on insert price!")
 			}
@@ -218,21 +227,229 @@ func (r *symbolRepositoryPostgres) GetBySymbol(ctx context.Context, symbolName s
 	}
 	result.Exchanges = make([]model.Exchange, 0, len(storedExchanges))
 	for _, storedExchange := range storedExchanges {
-		result.Exchanges = append(result.Exchanges, model.Exchange{
-			Name:     storedExchange.Name,
-			Country:  storedExchange.Country,
-			MicCode:  storedExchange.Code,
-			Timezone: storedExchange.Timezone})
+		result.Exchanges = append(result.Exchanges, exchangeToModel(storedExchange))
 	}
 	return result, nil
 }
 
-func (r *symbolRepositoryPostgres) GetAll(ctx context.Context) ([]model.Symbol, error) {
-	rows, err := r.db.QueryContext(ctx, symbolsWithLatestPrice)
+// symbolSortColumns maps sort to column of v_latest_symbol_info
+var symbolSortColumns = map[model.SymbolSort]string{
+	model.SortBySymbol: "symbol",
+	model.SortByName:   "name",
+	model.SortByClose:  "close",
+	model.SortByVolume: "COALESCE(volume, 0)",
+}
+
+// GetAll returns a page of symbols with the latest price matching query filters.
+// Pages are built with keyset pagination on the sorted column and symbol
+func (r *symbolRepositoryPostgres) GetAll(ctx context.Context, query model.SymbolQuery) (model.SymbolPage, error) {
+	filters := symbolFilters(query)
+	page := model.SymbolPage{Symbols: []model.Symbol{}}
+	if err := r.db.GetContext(ctx, &page.Total, symbolsCount+filters.where(), filters.args...); err != nil {
+		srLog(ctx, log.Error()).Err(err).Msg("Couldn't count symbols!")
+		return page, err
+	}
+	column := symbolSortColumns[query.Sort]
+	order, comparison := "ASC", ">"
+	if query.Descending {
+		order, comparison = "DESC", "<"
+	}
+	if query.Cursor != nil {
+		filters.add("("+column+", symbol) "+comparison+" ($%d, $%d)", query.Cursor.Value, query.Cursor.Symbol)
+	}
+	limit := filters.arg(query.Limit + 1)
+	pageQuery := symbolsWithLatestPrice + filters.where() + fmt.Sprintf(" ORDER BY %s %s, symbol %s LIMIT $%d", column, order, order, limit)
+	rows, err := r.db.QueryContext(ctx, pageQuery, filters.args...)
+	if err != nil {
+		return page, err
+	}
+	page.Symbols, err = r.retrieveLatest(ctx, rows)
+	if err != nil {
+		return page, err
+	}
+	if len(page.Symbols) > query.Limit {
+		page.Symbols = page.Symbols[:query.Limit]
+		last := page.Symbols[len(page.Symbols)-1]
+		page.NextCursor = model.SymbolCursor{Sort: query.Sort, Descending: query.Descending, Value: last.SortValue(query.Sort), Symbol: last.Symbol}.Encode()
+	}
+	return page, nil
+}
+
+// symbolFilters builds conditions on v_latest_symbol_info aliased as V from query filters
+func symbolFilters(query model.SymbolQuery) *conditions {
+	filters := &conditions{}
+	if query.Type != "" {
+		filters.add("type = $%d", query.Type)
+	}
+	if query.Currency != "" {
+		filters.add("currency = $%d", query.Currency)
+	}
+	if query.MicCode != "" {
+		filters.add("EXISTS (SELECT 1 FROM SYMBOL_EXCHANGE SE JOIN EXCHANGE E ON E.ID = SE.EXCHANGE_ID WHERE SE.SYMBOL_ID = V.ID AND E.CODE = $%d)", query.MicCode)
+	}
+	if query.Country != "" {
+		filters.add("EXISTS (SELECT 1 FROM SYMBOL_EXCHANGE SE JOIN EXCHANGE E ON E.ID = SE.EXCHANGE_ID WHERE SE.SYMBOL_ID = V.ID AND E.COUNTRY = $%d)", query.Country)
+	}
+	return filters
+}
+
+// GetBySymbols returns stored symbols with the latest price. Unknown symbols are omitted
+func (r *symbolRepositoryPostgres) GetBySymbols(ctx context.Context, names []string) ([]model.Symbol, error) {
+	rows, err := r.db.QueryContext(ctx, symbolsWithLatestPriceIn, pq.Array(names))
 	if err != nil {
 		return []model.Symbol{}, err
 	}
-	return r.retrieveLatest(ctx, rows)
+	results, err := r.retrieveLatest(ctx, rows)
+	if err != nil || len(results) == 0 {
+		return results, err
+	}
+	ids := make([]int64, 0, len(results))
+	for _, result := range results {
+		ids = append(ids, result.ID)
+	}
+	var storedExchanges []symbolExchange
+	if err = r.db.SelectContext(ctx, &storedExchanges, exchangesBySymbolIdsQuery, pq.Array(ids)); err != nil {
+		srLog(ctx, log.Error()).Err(err).Msg("Failed to retrieve exchanges of symbols")
+		return results, err
+	}
+	exchanges := make(map[int64][]model.Exchange, len(results))
+	for _, storedExchange := range storedExchanges {
+		exchanges[storedExchange.SymbolID] = append(exchanges[storedExchange.SymbolID], exchangeToModel(storedExchange.exchange))
+	}
+	for i := range results {
+		results[i].Exchanges = exchanges[results[i].ID]
+		if results[i].Exchanges == nil {
+			results[i].Exchanges = []model.Exchange{}
+		}
+	}
+	return results, nil
+}
+
+// Search finds symbols by symbol prefix, name substring or trigram similarity of symbol or name.
+// Exact symbol match is ranked first, then symbol and name prefixes, then similarity
+func (r *symbolRepositoryPostgres) Search(ctx context.Context, query string, limit int) ([]model.SymbolMatch, error) {
+	escaped := likeEscaper.Replace(query)
+	var found []symbolMatch
+	if err := r.db.SelectContext(ctx, &found, symbolSearchQuery, query, escaped+"%", "%"+escaped+"%", limit); err != nil {
+		srLog(ctx, log.Error()).Err(err).Msgf("Failed to search symbols by %s", query)
+		return nil, err
+	}
+	result := make([]model.SymbolMatch, 0, len(found))
+	for _, match := range found {
+		result = append(result, model.SymbolMatch{
+			Symbol:   match.Symbol,
+			Name:     match.Name,
+			Type:     match.SymbolType,
+			Currency: match.Currency,
+			Exchange: match.Exchange,
+			MicCode:  match.MicCode,
+			Country:  match.Country,
+			Source:   model.SearchSourceLocal,
+			Score:    match.Score,
+		})
+	}
+	return result, nil
+}
+
+// GetPrices returns prices of the symbol with query.Interval from the start of query.From day
+// until the end of query.To day ordered by date.
+// If query.Limit is positive only the latest query.Limit prices of the range are returned.
+func (r *symbolRepositoryPostgres) GetPrices(ctx context.Context, query model.PriceQuery) ([]model.Price, error) {
+	var stored symbol
+	srLog(ctx, log.Debug()).Msgf("Searching for %s symbol!", query.Symbol)
+	err := r.db.GetContext(ctx, &stored, symbolQuery, query.Symbol)
+	if err == sql.ErrNoRows {
+		return nil, model.SymbolNotFound
+	} else if err != nil {
+		srLog(ctx, log.Error()).Stack().Err(err).Msg("Found error in symbol select!")
+		return nil, err
+	}
+	limit := sql.NullInt64{Int64: int64(query.Limit), Valid: query.Limit > 0}
+	var storedPrices []price
+	err = r.db.SelectContext(ctx, &storedPrices, pricesInRangeQuery, stored.ID, query.Interval.OrDefault(), query.From.Format(model.DateLayout), query.To.AddDate(0, 0, 1).Format(model.DateLayout), limit)
+	if err != nil {
+		srLog(ctx, log.Error()).Err(err).Msgf("Cannot retrieve prices for %s symbol!", query.Symbol)
+		return nil, err
+	}
+	result := make([]model.Price, 0, len(storedPrices))
+	for _, storedPrice := range storedPrices {
+		result = append(result, priceToModel(storedPrice))
+	}
+	return result, nil
+}
+
+// UpsertPrices inserts prices of the existing symbol and overwrites already stored prices with the same date
+func (r *symbolRepositoryPostgres) UpsertPrices(ctx context.Context, symbolName string, prices []model.Price) error {
+	var stored symbol
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		srLog(ctx, log.Error()).Err(err).Msg("Failed to begin transaction")
+		return err
+	}
+	srLog(ctx, log.Debug()).Msgf("Searching for %s symbol!", symbolName)
+	err = tx.Get(&stored, symbolQuery, symbolName)
+	if err != nil {
+		srLog(ctx, log.Info()).Err(err).Msgf("Cannot upsert prices for %s symbol!", symbolName)
+		utils.PanicOnError(tx.Rollback())
+		if err == sql.ErrNoRows {
+			return model.SymbolNotFound
+		}
+		return err
+	}
+	srLog(ctx, log.Debug()).Msgf("Upserting %d prices for %s", len(prices), symbolName)
+	const priceUpsert = `INSERT INTO PRICE(SYMBOL_ID, INTERVAL, DATE, OPEN, CLOSE, HIGH, LOW, VOLUME, PROVIDER) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, NULLIF($9, ''))
+		ON CONFLICT (SYMBOL_ID, INTERVAL, DATE) DO UPDATE SET OPEN = EXCLUDED.OPEN, CLOSE = EXCLUDED.CLOSE, HIGH = EXCLUDED.HIGH, LOW = EXCLUDED.LOW, VOLUME = EXCLUDED.VOLUME, PROVIDER = EXCLUDED.PROVIDER`
+	for _, price := range prices {
+		_, err = tx.Exec(priceUpsert, stored.ID, price.Interval.OrDefault(), price.Date, price.Open, price.Close, price.High, price.Low, price.Volume, price.Provider)
+		if err != nil {
+			srLog(ctx, log.Warn()).Err(err).Msgf("Fail on upsert price %+v!", price)
+			utils.PanicOnError(tx.Rollback())
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// GetLatestDates returns the date of the latest price with interval for every stored symbol.
+// Symbols without prices have zero date
+func (r *symbolRepositoryPostgres) GetLatestDates(ctx context.Context, interval model.Interval) (map[string]time.Time, error) {
+	const latestDatesQuery = `SELECT S.SYMBOL, MAX(P.DATE) FROM SYMBOL S LEFT JOIN PRICE P ON P.SYMBOL_ID = S.ID AND P.INTERVAL = $1 GROUP BY S.SYMBOL`
+	rows, err := r.db.QueryContext(ctx, latestDatesQuery, interval.OrDefault())
+	if err != nil {
+		srLog(ctx, log.Error()).Err(err).Msg("Cannot retrieve latest dates!")
+		return nil, err
+	}
+	defer func(rows *sql.Rows) {
+		err := rows.Close()
+		if err != nil {
+			srLog(ctx, log.Error()).Err(err).Msg("Couldn't close row!")
+		}
+	}(rows)
+	result := make(map[string]time.Time)
+	for rows.Next() {
+		var name string
+		var date sql.NullTime
+		if err = rows.Scan(&name, &date); err != nil {
+			srLog(ctx, log.Error()).Err(err).Msg("Error on scanning row!")
+			return nil, err
+		}
+		result[name] = date.Time
+	}
+	return result, rows.Err()
+}
+
+func priceToModel(p price) model.Price {
+	interval := model.Interval(p.Interval)
+	return model.Price{
+		Interval: interval,
+		Date:     p.Date.Format(interval.Layout()),
+		Open:     p.Open,
+		High:     p.High,
+		Low:      p.Low,
+		Close:    p.Close,
+		Volume:   p.Volume,
+		Provider: p.Provider,
+	}
 }
 
 func (r *symbolRepositoryPostgres) retrieveLatest(ctx context.Context, rows *sql.Rows) ([]model.Symbol, error) {
@@ -245,11 +462,13 @@ func (r *symbolRepositoryPostgres) retrieveLatest(ctx context.Context, rows *sql
 	}(rows)
 	for rows.Next() {
 		var s model.Symbol
+		var date time.Time
 		s.Values = make([]model.Price, 1)
-		err := rows.Scan(&s.ID, &s.Symbol, &s.Name, &s.Type, &s.Currency, &s.CurrencyBase, &s.CurrencyQuote, &s.Values[0].Date, &s.Values[0].Open, &s.Values[0].Close, &s.Values[0].High, &s.Values[0].Low, &s.Values[0].Volume)
+		err := rows.Scan(&s.ID, &s.Symbol, &s.Name, &s.Type, &s.Currency, &s.CurrencyBase, &s.CurrencyQuote, &date, &s.Values[0].Open, &s.Values[0].Close, &s.Values[0].High, &s.Values[0].Low, &s.Values[0].Volume)
 		if err != nil {
 			srLog(ctx, log.Error()).Err(err).Msg("Error on scanning row!")
 		}
+		s.Values[0].Date = date.Format(model.DateLayout)
 		result = append(result, s)
 	}
 	return result, rows.Err()
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestSymbolFilters(t *testing.T) {
+	for _, td := range symbolFiltersTests {
+		t.Run(td.name, func(t *testing.T) {
+			filters := symbolFilters(td.query)
+			assert.Equal(t, td.expectedWhere, filters.where(), "Conditions should be equal")
+			assert.Equal(t, td.expectedArgs, filters.args, "Arguments should be equal")
+		})
+	}
+}
+
+var symbolFiltersTests = []struct {
+	name          string
+	query         model.SymbolQuery
+	expectedWhere string
+	expectedArgs  []any
+}{
+	{
+		name:  utils.TestName("no filters"),
+		query: model.SymbolQuery{},
+	},
+	{
+		name:          utils.TestName("type and currency"),
+		query:         model.SymbolQuery{Type: "Common Stock", Currency: "USD"},
+		expectedWhere: " WHERE type = $1 AND currency = $2",
+		expectedArgs:  []any{"Common Stock", "USD"},
+	},
+	{
+		name:          utils.TestName("exchange filters are bound to the symbol row"),
+		query:         model.SymbolQuery{Currency: "USD", MicCode: "XNGS", Country: "United States"},
+		expectedWhere: " WHERE currency = $1 AND EXISTS (SELECT 1 FROM SYMBOL_EXCHANGE SE JOIN EXCHANGE E ON E.ID = SE.EXCHANGE_ID WHERE SE.SYMBOL_ID = V.ID AND E.CODE = $2) AND EXISTS (SELECT 1 FROM SYMBOL_EXCHANGE SE JOIN EXCHANGE E ON E.ID = SE.EXCHANGE_ID WHERE SE.SYMBOL_ID = V.ID AND E.COUNTRY = $3)",
+		expectedArgs:  []any{"USD", "XNGS", "United States"},
+	},
+}
+
+func TestConditions(t *testing.T) {
+	filters := &conditions{}
+	filters.add("(close, symbol) > ($%d, $%d)", "180.95", "AAPL")
+	limit := filters.arg(101)
+	assert.Equal(t, " WHERE (close, symbol) > ($1, $2)", filters.where(), "Conditions should be equal")
+	assert.Equal(t, 3, limit, "Argument after conditions should get the next position")
+	assert.Equal(t, []any{"180.95", "AAPL", 101}, filters.args, "Arguments should be equal")
+}
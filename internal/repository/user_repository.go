@@ -2,9 +2,12 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
@@ -16,9 +19,20 @@ type userRepositoryPostgres struct {
 type UserRepository interface {
 	Create(ctx context.Context, user model.User) error
 	CheckLoginIsAvailable(ctx context.Context, username string, email string) (bool, error)
-	GetUser(ctx context.Context, login string, password string) (model.User, error)
-	GetUserRole(ctx context.Context, userID string) (model.Role, error)
+	GetUserByLogin(ctx context.Context, login string) (model.User, error)
+	GetUserRoles(ctx context.Context, userID string) ([]model.Role, error)
+	GetUserPermissions(ctx context.Context, userID string) ([]model.Permission, error)
 	Update(ctx context.Context, user model.User) error
+	UpdatePassword(ctx context.Context, userID string, password string) error
+	GetAll(ctx context.Context, query model.UserQuery) (model.UserPage, error)
+	GetUserByID(ctx context.Context, userID string) (model.User, error)
+	GetRoles(ctx context.Context) ([]model.Role, error)
+	SetUserRoles(ctx context.Context, userID string, roles []model.Role) error
+	SetUserDisabled(ctx context.Context, userID string, disabled bool) error
+	DeleteRefreshTokens(ctx context.Context, userID string) error
+	Delete(ctx context.Context, userID string) error
+	InsertResetToken(ctx context.Context, token model.ResetToken) error
+	UseResetToken(ctx context.Context, tokenHash string) (model.ResetToken, error)
 	GetRefreshToken(ctx context.Context, token string) (model.RefreshToken, error)
 	InsertRefreshToken(ctx context.Context, token model.RefreshToken) error
 }
@@ -45,13 +59,15 @@ func (r *userRepositoryPostgres) Create(ctx context.Context, user model.User) er
 		utils.PanicOnError(tx.Rollback())
 		return err
 	}
-	urLog(ctx, log.Debug()).Msgf("Add %s role for %s user", user.Username, user.Email)
 	const userRoleInsert = `INSERT INTO user_roles(user_id, role) VALUES ($1, $2)`
-	_, err = tx.Exec(userRoleInsert, user.ID, user.Role)
-	if err != nil {
-		urLog(ctx, log.Error()).Err(err).Msg("Fail on insert user role!")
-		utils.PanicOnError(tx.Rollback())
-		return err
+	for _, role := range user.Roles {
+		urLog(ctx, log.Debug()).Msgf("Add %s role for %s user", role, user.Username)
+		_, err = tx.Exec(userRoleInsert, user.ID, role)
+		if err != nil {
+			urLog(ctx, log.Error()).Err(err).Msg("Fail on insert user role!")
+			utils.PanicOnError(tx.Rollback())
+			return err
+		}
 	}
 	urLog(ctx, log.Debug()).Msg("User created!")
 	return tx.Commit()
@@ -68,59 +84,238 @@ func (r *userRepositoryPostgres) CheckLoginIsAvailable(ctx context.Context, user
 	return count == 0, nil
 }
 
-func (r *userRepositoryPostgres) GetUser(ctx context.Context, login string, password string) (model.User, error) {
+func (r *userRepositoryPostgres) GetUserByLogin(ctx context.Context, login string) (model.User, error) {
 	var users []userEntity
 	urLog(ctx, log.Debug()).Msgf("Retrieving user with %s login", login)
-	const usersByLoginQuery = `SELECT * FROM user_entity WHERE (username = $1 or email = $1) and password = $2`
-	err := r.db.SelectContext(ctx, &users, usersByLoginQuery, login, password)
+	const usersByLoginQuery = `SELECT * FROM user_entity WHERE username = $1 or email = $1`
+	err := r.db.SelectContext(ctx, &users, usersByLoginQuery, login)
 	if err != nil {
 		return model.User{}, err
 	}
 	if len(users) == 0 {
 		return model.User{}, model.UserNotFound
 	}
-	role, err := r.GetUserRole(ctx, users[0].ID)
-	if len(users) == 0 {
+	roles, err := r.GetUserRoles(ctx, users[0].ID)
+	if err != nil {
 		return model.User{}, err
 	}
-	result := model.User{
-		ID:       users[0].ID,
-		Username: users[0].Username,
-		Email:    users[0].Email,
-		Role:     role,
-		Password: users[0].Password,
+	return userToModel(users[0], roles), nil
+}
+func (r *userRepositoryPostgres) GetUserRoles(ctx context.Context, userID string) ([]model.Role, error) {
+	roles := make([]model.Role, 0)
+	urLog(ctx, log.Debug()).Msgf("Retrieving user roles with %s user id", userID)
+	const usersRolesQuery = `SELECT role FROM user_roles WHERE user_id = $1 ORDER BY role`
+	err := r.db.SelectContext(ctx, &roles, usersRolesQuery, userID)
+	return roles, err
+}
+
+func (r *userRepositoryPostgres) GetUserPermissions(ctx context.Context, userID string) ([]model.Permission, error) {
+	permissions := make([]model.Permission, 0)
+	urLog(ctx, log.Debug()).Msgf("Retrieving user permissions with %s user id", userID)
+	const usersPermissionsQuery = `SELECT DISTINCT rp.permission FROM user_roles ur JOIN role_permissions rp ON rp.role = ur.role
+		WHERE ur.user_id = $1 ORDER BY rp.permission`
+	err := r.db.SelectContext(ctx, &permissions, usersPermissionsQuery, userID)
+	return permissions, err
+}
+
+// Update changes username and email of the user. Password is changed only by UpdatePassword,
+// so concurrent password change isn't overwritten
+func (r *userRepositoryPostgres) Update(ctx context.Context, user model.User) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Failed to begin transaction")
+		return err
+	}
+	urLog(ctx, log.Info()).Msgf("Updating user: %s username, %s email", user.Username, user.Email)
+	const userUpdate = `UPDATE USER_ENTITY SET USERNAME = $1, EMAIL = $2, UPDATED_AT = now() WHERE ID = $3`
+	_, err = tx.Exec(userUpdate, user.Username, user.Email, user.ID)
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == uniqueViolationCode {
+		urLog(ctx, log.Info()).Err(err).Msg("Username or email is already taken!")
+		utils.PanicOnError(tx.Rollback())
+		return model.UserAlreadyExists
+	} else if err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail on update user!")
+		utils.PanicOnError(tx.Rollback())
+		return err
 	}
-	return result, nil
+	urLog(ctx, log.Debug()).Msg("User updated!")
+	return tx.Commit()
 }
-func (r *userRepositoryPostgres) GetUserRole(ctx context.Context, userID string) (model.Role, error) {
-	var role string
-	urLog(ctx, log.Debug()).Msgf("Retrieving user role with %s user id", userID)
-	const usersRoleQuery = `SELECT role FROM user_roles WHERE user_id = $1`
-	err := r.db.GetContext(ctx, &role, usersRoleQuery, userID)
+
+func (r *userRepositoryPostgres) UpdatePassword(ctx context.Context, userID string, password string) error {
+	urLog(ctx, log.Info()).Msgf("Updating password hash of %s user id", userID)
+	const passwordUpdate = `UPDATE USER_ENTITY SET PASSWORD = $1, UPDATED_AT = now() WHERE ID = $2`
+	_, err := r.db.ExecContext(ctx, passwordUpdate, password, userID)
 	if err != nil {
-		return model.ClientRole, err
+		urLog(ctx, log.Error()).Err(err).Msg("Fail on update password!")
 	}
-	return model.Role(role), nil
+	return err
 }
 
-func (r *userRepositoryPostgres) Update(ctx context.Context, user model.User) error {
+const (
+	usersCount = `SELECT COUNT(1) FROM user_entity U`
+	usersQuery = `SELECT U.* FROM user_entity U`
+)
+
+// userFilters builds conditions on user_entity aliased as U from query filters
+func userFilters(query model.UserQuery) *conditions {
+	filters := &conditions{}
+	if query.Search != "" {
+		pattern := "%" + likeEscaper.Replace(query.Search) + "%"
+		filters.add("(U.username ILIKE $%d OR U.email ILIKE $%d)", pattern, pattern)
+	}
+	if query.Role != "" {
+		filters.add("EXISTS (SELECT 1 FROM user_roles UR WHERE UR.user_id = U.id AND UR.role = $%d)", query.Role)
+	}
+	if query.Disabled != nil {
+		filters.add("U.disabled = $%d", *query.Disabled)
+	}
+	return filters
+}
+
+func (r *userRepositoryPostgres) GetAll(ctx context.Context, query model.UserQuery) (model.UserPage, error) {
+	filters := userFilters(query)
+	page := model.UserPage{Users: []model.User{}}
+	if err := r.db.GetContext(ctx, &page.Total, usersCount+filters.where(), filters.args...); err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Couldn't count users!")
+		return page, err
+	}
+	if query.After != "" {
+		filters.add("U.username > $%d", query.After)
+	}
+	limit := filters.arg(query.Limit + 1)
+	var users []userEntity
+	pageQuery := usersQuery + filters.where() + fmt.Sprintf(" ORDER BY U.username LIMIT $%d", limit)
+	if err := r.db.SelectContext(ctx, &users, pageQuery, filters.args...); err != nil {
+		return page, err
+	}
+	if len(users) > query.Limit {
+		users = users[:query.Limit]
+		page.NextCursor = users[len(users)-1].Username
+	}
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	var roles []userRole
+	const usersRolesQuery = `SELECT user_id, role FROM user_roles WHERE user_id = ANY($1) ORDER BY role`
+	if err := r.db.SelectContext(ctx, &roles, usersRolesQuery, pq.Array(ids)); err != nil {
+		return page, err
+	}
+	rolesByUser := make(map[string][]model.Role, len(users))
+	for _, role := range roles {
+		rolesByUser[role.UserID] = append(rolesByUser[role.UserID], role.Role)
+	}
+	for _, user := range users {
+		userRoles := rolesByUser[user.ID]
+		if userRoles == nil {
+			userRoles = []model.Role{}
+		}
+		page.Users = append(page.Users, userToModel(user, userRoles))
+	}
+	return page, nil
+}
+
+func (r *userRepositoryPostgres) GetUserByID(ctx context.Context, userID string) (model.User, error) {
+	var users []userEntity
+	urLog(ctx, log.Debug()).Msgf("Retrieving user with %s id", userID)
+	const userByIdQuery = `SELECT * FROM user_entity WHERE id = $1`
+	err := r.db.SelectContext(ctx, &users, userByIdQuery, userID)
+	if err != nil {
+		return model.User{}, err
+	}
+	if len(users) == 0 {
+		return model.User{}, model.UserNotFound
+	}
+	roles, err := r.GetUserRoles(ctx, userID)
+	if err != nil {
+		return model.User{}, err
+	}
+	return userToModel(users[0], roles), nil
+}
+
+func (r *userRepositoryPostgres) GetRoles(ctx context.Context) ([]model.Role, error) {
+	roles := make([]model.Role, 0)
+	const rolesQuery = `SELECT DISTINCT role FROM role_permissions ORDER BY role`
+	err := r.db.SelectContext(ctx, &roles, rolesQuery)
+	return roles, err
+}
+
+func (r *userRepositoryPostgres) SetUserRoles(ctx context.Context, userID string, roles []model.Role) error {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
 		urLog(ctx, log.Error()).Err(err).Msg("Failed to begin transaction")
 		return err
 	}
-	urLog(ctx, log.Info()).Msgf("Updating user: %s username, %s email", user.Username, user.Email)
-	const userUpdate = `UPDATE USER_ENTITY SET USERNAME = $1, EMAIL = $2, PASSWORD = $3, UPDATED_AT = now() WHERE ID = $4`
-	_, err = tx.Exec(userUpdate, user.Username, user.Email, user.Password, user.ID)
+	urLog(ctx, log.Info()).Msgf("Setting %v roles of %s user id", roles, userID)
+	const userTouch = `UPDATE USER_ENTITY SET UPDATED_AT = now() WHERE ID = $1`
+	result, err := tx.Exec(userTouch, userID)
 	if err != nil {
 		urLog(ctx, log.Error()).Err(err).Msg("Fail on update user!")
 		utils.PanicOnError(tx.Rollback())
 		return err
 	}
-	urLog(ctx, log.Debug()).Msg("User updated!")
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		utils.PanicOnError(tx.Rollback())
+		return model.UserNotFound
+	}
+	const userRolesDelete = `DELETE FROM user_roles WHERE user_id = $1`
+	if _, err = tx.Exec(userRolesDelete, userID); err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail on delete user roles!")
+		utils.PanicOnError(tx.Rollback())
+		return err
+	}
+	const userRoleInsert = `INSERT INTO user_roles(user_id, role) VALUES ($1, $2)`
+	for _, role := range roles {
+		if _, err = tx.Exec(userRoleInsert, userID, role); err != nil {
+			urLog(ctx, log.Error()).Err(err).Msg("Fail on insert user role!")
+			utils.PanicOnError(tx.Rollback())
+			return err
+		}
+	}
 	return tx.Commit()
 }
 
+func (r *userRepositoryPostgres) SetUserDisabled(ctx context.Context, userID string, disabled bool) error {
+	urLog(ctx, log.Info()).Msgf("Setting disabled %t of %s user id", disabled, userID)
+	const userDisable = `UPDATE USER_ENTITY SET DISABLED = $1, UPDATED_AT = now() WHERE ID = $2`
+	result, err := r.db.ExecContext(ctx, userDisable, disabled, userID)
+	if err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail on update user!")
+		return err
+	}
+	if updated, _ := result.RowsAffected(); updated == 0 {
+		return model.UserNotFound
+	}
+	return nil
+}
+
+func (r *userRepositoryPostgres) DeleteRefreshTokens(ctx context.Context, userID string) error {
+	urLog(ctx, log.Info()).Msgf("Deleting refresh tokens of %s user id", userID)
+	const deleteTokens = `DELETE FROM refresh_token WHERE USER_ID = $1`
+	_, err := r.db.ExecContext(ctx, deleteTokens, userID)
+	if err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail to delete tokens")
+	}
+	return err
+}
+
+// Delete removes the user with its roles and refresh tokens
+func (r *userRepositoryPostgres) Delete(ctx context.Context, userID string) error {
+	urLog(ctx, log.Info()).Msgf("Deleting user with %s id", userID)
+	const deleteUser = `DELETE FROM user_entity WHERE ID = $1`
+	result, err := r.db.ExecContext(ctx, deleteUser, userID)
+	if err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail to delete user")
+		return err
+	}
+	if deleted, _ := result.RowsAffected(); deleted == 0 {
+		return model.UserNotFound
+	}
+	return nil
+}
+
 func (r *userRepositoryPostgres) GetRefreshToken(ctx context.Context, token string) (model.RefreshToken, error) {
 	tx, err := r.db.BeginTxx(ctx, nil)
 	if err != nil {
@@ -132,19 +327,26 @@ func (r *userRepositoryPostgres) GetRefreshToken(ctx context.Context, token stri
 	const usersByLoginQuery = `SELECT * FROM refresh_token WHERE token = $1`
 	err = tx.SelectContext(ctx, &tokens, usersByLoginQuery, token)
 	if err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail to retrieve token")
+		utils.PanicOnError(tx.Rollback())
 		return model.RefreshToken{}, err
 	}
 	if len(tokens) == 0 {
+		utils.PanicOnError(tx.Rollback())
 		return model.RefreshToken{}, model.TokenNotFound
 	}
 	urLog(ctx, log.Debug()).Msgf("Deleting old token: %s", token)
 	const deleteToken = `DELETE FROM refresh_token WHERE ID = $1`
-	_, err = tx.Exec(deleteToken, tokens[0].ID)
+	_, err = tx.ExecContext(ctx, deleteToken, tokens[0].ID)
 	if err != nil {
 		urLog(ctx, log.Error()).Err(err).Msg("Fail to delete token")
 		utils.PanicOnError(tx.Rollback())
 		return model.RefreshToken{}, err
 	}
+	if err = tx.Commit(); err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail to commit token deletion")
+		return model.RefreshToken{}, err
+	}
 	result := model.RefreshToken{
 		Token:     token,
 		UserId:    tokens[0].UserId,
@@ -162,3 +364,53 @@ func (r *userRepositoryPostgres) InsertRefreshToken(ctx context.Context, refresh
 	}
 	return err
 }
+
+func userToModel(stored userEntity, roles []model.Role) model.User {
+	return model.User{
+		ID:        stored.ID,
+		Username:  stored.Username,
+		Email:     stored.Email,
+		Roles:     roles,
+		Disabled:  stored.Disabled,
+		Password:  stored.Password,
+		CreatedAt: stored.CreatedAt,
+		UpdatedAt: stored.UpdatedAt,
+	}
+}
+
+// InsertResetToken stores the token and removes unused tokens previously issued to the user
+func (r *userRepositoryPostgres) InsertResetToken(ctx context.Context, token model.ResetToken) error {
+	tx, err := r.db.BeginTxx(ctx, nil)
+	if err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Failed to begin transaction")
+		return err
+	}
+	urLog(ctx, log.Info()).Msgf("Inserting password reset token for %s user id", token.UserId)
+	const deleteUnused = `DELETE FROM password_reset_token WHERE USER_ID = $1 AND USED_AT IS NULL`
+	if _, err = tx.Exec(deleteUnused, token.UserId); err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail to delete unused reset tokens")
+		utils.PanicOnError(tx.Rollback())
+		return err
+	}
+	const insertResetToken = `INSERT INTO password_reset_token(USER_ID, TOKEN_HASH, EXPIRES_AT) VALUES ($1, $2, $3)`
+	if _, err = tx.Exec(insertResetToken, token.UserId, token.TokenHash, token.ExpiresAt); err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail on insert reset token!")
+		utils.PanicOnError(tx.Rollback())
+		return err
+	}
+	return tx.Commit()
+}
+
+// UseResetToken marks the token as used and returns it. Error is model.TokenNotFound if the token is unknown or already used
+func (r *userRepositoryPostgres) UseResetToken(ctx context.Context, tokenHash string) (model.ResetToken, error) {
+	var tokens []resetToken
+	const useResetToken = `UPDATE password_reset_token SET USED_AT = now() WHERE TOKEN_HASH = $1 AND USED_AT IS NULL RETURNING user_id, expires_at`
+	if err := r.db.SelectContext(ctx, &tokens, useResetToken, tokenHash); err != nil {
+		urLog(ctx, log.Error()).Err(err).Msg("Fail to use reset token")
+		return model.ResetToken{}, err
+	}
+	if len(tokens) == 0 {
+		return model.ResetToken{}, model.TokenNotFound
+	}
+	return model.ResetToken{UserId: tokens[0].UserId, TokenHash: tokenHash, ExpiresAt: tokens[0].ExpiresAt}, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/jmoiron/sqlx"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+	"time"
+)
+
+func TestUserFilters(t *testing.T) {
+	for _, td := range userFiltersTests {
+		t.Run(td.name, func(t *testing.T) {
+			filters := userFilters(td.query)
+			assert.Equal(t, td.expectedWhere, filters.where(), "Conditions should be equal")
+			assert.Equal(t, td.expectedArgs, filters.args, "Arguments should be equal")
+		})
+	}
+}
+
+var disabled = true
+
+var userFiltersTests = []struct {
+	name          string
+	query         model.UserQuery
+	expectedWhere string
+	expectedArgs  []any
+}{
+	{
+		name:  utils.TestName("no filters"),
+		query: model.UserQuery{},
+	},
+	{
+		name:          utils.TestName("search wildcards are escaped"),
+		query:         model.UserQuery{Search: `50%_off\`},
+		expectedWhere: " WHERE (U.username ILIKE $1 OR U.email ILIKE $2)",
+		expectedArgs:  []any{`%50\%\_off\\%`, `%50\%\_off\\%`},
+	},
+	{
+		name:          utils.TestName("role filter is bound to the user row"),
+		query:         model.UserQuery{Role: model.AdminRole, Disabled: &disabled},
+		expectedWhere: " WHERE EXISTS (SELECT 1 FROM user_roles UR WHERE UR.user_id = U.id AND UR.role = $1) AND U.disabled = $2",
+		expectedArgs:  []any{model.AdminRole, true},
+	},
+}
+
+func TestRefreshTokenIsConsumed(t *testing.T) {
+	store := &tokenStore{tokens: []refreshToken{
+		{ID: 1, UserId: "1", Token: "first", ExpiresAt: time.Now().Add(time.Hour)},
+		{ID: 2, UserId: "1", Token: "second", ExpiresAt: time.Now().Add(time.Hour)},
+	}}
+	db := sqlx.NewDb(sql.OpenDB(store), "postgres")
+	// Single connection makes a leaked transaction block the following statements
+	db.SetMaxOpenConns(1)
+	repo := NewUserRepository(db)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	token, err := repo.GetRefreshToken(ctx, "first")
+	assert.NoError(t, err, "Refresh token should be found")
+	assert.Equal(t, "1", token.UserId, "Refresh token should belong to the user")
+	_, err = repo.GetRefreshToken(ctx, "first")
+	assert.Equal(t, model.TokenNotFound, err, "Used refresh token should be consumed")
+	assert.NoError(t, repo.DeleteRefreshTokens(ctx, "1"), "Refresh tokens should be deleted after refresh")
+	assert.Empty(t, store.tokens, "No refresh tokens should remain")
+	assert.Equal(t, 0, store.open, "No transaction should be left open")
+}
+
+// tokenStore is an in-memory database/sql driver serving refresh_token statements
+type tokenStore struct {
+	tokens []refreshToken
+	open   int
+}
+
+func (s *tokenStore) Connect(context.Context) (driver.Conn, error) { return s, nil }
+func (s *tokenStore) Driver() driver.Driver                        { return s }
+func (s *tokenStore) Open(string) (driver.Conn, error)             { return s, nil }
+func (s *tokenStore) Close() error                                 { return nil }
+func (s *tokenStore) Commit() error                                { s.open--; return nil }
+func (s *tokenStore) Rollback() error                              { s.open--; return nil }
+
+func (s *tokenStore) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("prepared statements are not supported")
+}
+
+func (s *tokenStore) Begin() (driver.Tx, error) {
+	s.open++
+	return s, nil
+}
+
+func (s *tokenStore) QueryContext(_ context.Context, query string, args []driver.NamedValue) (driver.Rows, error) {
+	if query != `SELECT * FROM refresh_token WHERE token = $1` {
+		return nil, fmt.Errorf("unexpected query: %s", query)
+	}
+	rows := &tokenRows{}
+	for _, token := range s.tokens {
+		if token.Token == args[0].Value {
+			rows.tokens = append(rows.tokens, token)
+		}
+	}
+	return rows, nil
+}
+
+func (s *tokenStore) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	var matches func(token refreshToken) bool
+	switch query {
+	case `DELETE FROM refresh_token WHERE ID = $1`:
+		matches = func(token refreshToken) bool { return token.ID == args[0].Value }
+	case `DELETE FROM refresh_token WHERE USER_ID = $1`:
+		matches = func(token refreshToken) bool { return token.UserId == args[0].Value }
+	default:
+		return nil, fmt.Errorf("unexpected statement: %s", query)
+	}
+	remaining := s.tokens[:0]
+	for _, token := range s.tokens {
+		if !matches(token) {
+			remaining = append(remaining, token)
+		}
+	}
+	deleted := len(s.tokens) - len(remaining)
+	s.tokens = remaining
+	return driver.RowsAffected(deleted), nil
+}
+
+type tokenRows struct {
+	tokens []refreshToken
+}
+
+func (r *tokenRows) Columns() []string { return []string{"id", "user_id", "token", "expires_at"} }
+func (r *tokenRows) Close() error      { return nil }
+
+func (r *tokenRows) Next(dest []driver.Value) error {
+	if len(r.tokens) == 0 {
+		return io.EOF
+	}
+	token := r.tokens[0]
+	r.tokens = r.tokens[1:]
+	dest[0], dest[1], dest[2], dest[3] = token.ID, token.UserId, token.Token, token.ExpiresAt
+	return nil
+}
@@ -0,0 +1,110 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/repository"
+)
+
+// AccountService manages account of the authenticated user
+type AccountService interface {
+	Get(ctx context.Context, userID string) (model.User, error)
+	UpdateProfile(ctx context.Context, userID string, profile model.UpdateProfile) (model.User, error)
+	ChangePassword(ctx context.Context, userID string, change model.ChangePassword) error
+	Delete(ctx context.Context, userID string, password string) error
+}
+
+type accountServiceWithRepo struct {
+	repo         repository.UserRepository
+	hasher       *Hasher
+	auditService AuditService
+}
+
+func NewAccountService(repo repository.UserRepository, hasher *Hasher, auditService AuditService) AccountService {
+	return &accountServiceWithRepo{repo: repo, hasher: hasher, auditService: auditService}
+}
+
+func (s *accountServiceWithRepo) Get(ctx context.Context, userID string) (model.User, error) {
+	return s.repo.GetUserByID(ctx, userID)
+}
+
+// UpdateProfile changes username and email of the user. Error is model.UserAlreadyExists if another user has new username or email
+func (s *accountServiceWithRepo) UpdateProfile(ctx context.Context, userID string, profile model.UpdateProfile) (model.User, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return user, err
+	}
+	var username, email string
+	if profile.Username != "" && profile.Username != user.Username {
+		username = profile.Username
+	}
+	if profile.Email != "" && profile.Email != user.Email {
+		email = profile.Email
+	}
+	if username == "" && email == "" {
+		return user, nil
+	}
+	available, err := s.repo.CheckLoginIsAvailable(ctx, username, email)
+	if err != nil {
+		return user, err
+	}
+	if !available {
+		return user, model.UserAlreadyExists
+	}
+	if username != "" {
+		user.Username = username
+	}
+	if email != "" {
+		user.Email = email
+	}
+	if err = s.repo.Update(ctx, user); err != nil {
+		return user, err
+	}
+	go s.auditService.LogUserProfileUpdated(ctx, userID)
+	return s.repo.GetUserByID(ctx, userID)
+}
+
+// ChangePassword replaces password of the user if the current one matches and revokes refresh tokens of the user.
+// Error is model.WrongPassword if the current password doesn't match
+func (s *accountServiceWithRepo) ChangePassword(ctx context.Context, userID string, change model.ChangePassword) error {
+	user, err := s.verifiedUser(ctx, userID, change.CurrentPassword)
+	if err != nil {
+		return err
+	}
+	hash, err := s.hasher.Hash(change.NewPassword)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.UpdatePassword(ctx, user.ID, hash); err != nil {
+		return err
+	}
+	go s.auditService.LogUserPasswordChanged(ctx, userID)
+	return s.repo.DeleteRefreshTokens(ctx, userID)
+}
+
+// Delete removes the user if the password matches. Error is model.WrongPassword otherwise
+func (s *accountServiceWithRepo) Delete(ctx context.Context, userID string, password string) error {
+	if _, err := s.verifiedUser(ctx, userID, password); err != nil {
+		return err
+	}
+	if err := s.repo.Delete(ctx, userID); err != nil {
+		return err
+	}
+	go s.auditService.LogUserDeleted(ctx, userID)
+	return nil
+}
+
+func (s *accountServiceWithRepo) verifiedUser(ctx context.Context, userID string, password string) (model.User, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err != nil {
+		return user, err
+	}
+	match, _, err := s.hasher.Verify(password, user.Password)
+	if err != nil {
+		return user, err
+	}
+	if !match {
+		return user, model.WrongPassword
+	}
+	return user, nil
+}
@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestUpdateProfile(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewAccountService(mockRepo, testHasher, mockAudit)
+	stored := model.User{ID: "1", Username: "user", Email: "user@test.com", Password: argon2PasswordHash}
+	mockAudit.EXPECT().LogUserProfileUpdated(gomock.Any(), "1").AnyTimes()
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(stored, nil)
+	mockRepo.EXPECT().CheckLoginIsAvailable(gomock.Any(), "", "new@test.com").Return(true, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), model.User{ID: "1", Username: "user", Email: "new@test.com", Password: argon2PasswordHash}).Return(nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1", Username: "user", Email: "new@test.com"}, nil)
+	user, err := service.UpdateProfile(context.TODO(), "1", model.UpdateProfile{Username: "user", Email: "new@test.com"})
+	assert.NoError(t, err, "Only changed email should be checked and updated")
+	assert.Equal(t, "new@test.com", user.Email, "Updated user should be returned")
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(stored, nil)
+	mockRepo.EXPECT().CheckLoginIsAvailable(gomock.Any(), "admin", "").Return(false, nil)
+	_, err = service.UpdateProfile(context.TODO(), "1", model.UpdateProfile{Username: "admin"})
+	assert.Equal(t, model.UserAlreadyExists, err, "Username of another user should not be taken")
+
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(stored, nil)
+	mockRepo.EXPECT().CheckLoginIsAvailable(gomock.Any(), "admin", "").Return(true, nil)
+	mockRepo.EXPECT().Update(gomock.Any(), gomock.Any()).Return(model.UserAlreadyExists)
+	_, err = service.UpdateProfile(context.TODO(), "1", model.UpdateProfile{Username: "admin"})
+	assert.Equal(t, model.UserAlreadyExists, err, "Username taken concurrently should not be accepted")
+}
+
+func TestChangePassword(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewAccountService(mockRepo, testHasher, mockAudit)
+	mockAudit.EXPECT().LogUserPasswordChanged(gomock.Any(), "1").AnyTimes()
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1", Password: legacyPasswordHash}, nil).Times(2)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().UpdatePassword(gomock.Any(), "1", gomock.Any()).Do(func(_ context.Context, _ string, hash string) {
+			assert.True(t, strings.HasPrefix(hash, argon2Prefix), "New password should be hashed with argon2id")
+		}).Return(nil),
+		mockRepo.EXPECT().DeleteRefreshTokens(gomock.Any(), "1").Return(nil),
+	)
+	err := service.ChangePassword(context.TODO(), "1", model.ChangePassword{CurrentPassword: "password", NewPassword: "new_password"})
+	assert.NoError(t, err, "Password should be changed")
+
+	err = service.ChangePassword(context.TODO(), "1", model.ChangePassword{CurrentPassword: "Password", NewPassword: "new_password"})
+	assert.Equal(t, model.WrongPassword, err, "Password should not be changed without current one")
+}
+
+func TestDeleteAccount(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewAccountService(mockRepo, testHasher, mockAudit)
+	mockAudit.EXPECT().LogUserDeleted(gomock.Any(), "1").AnyTimes()
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1", Password: argon2PasswordHash}, nil).Times(2)
+
+	assert.Equal(t, model.WrongPassword, service.Delete(context.TODO(), "1", "Password"), "User should not be deleted without password")
+	mockRepo.EXPECT().Delete(gomock.Any(), "1").Return(nil)
+	assert.NoError(t, service.Delete(context.TODO(), "1", "password"), "User should be deleted")
+}
@@ -0,0 +1,201 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"math"
+	"sort"
+	"time"
+)
+
+const (
+	tradingDaysPerYear    = 252
+	minCorrelationReturns = 3
+)
+
+// AnalyticsService computes returns, volatility, drawdown and correlations from stored daily prices
+type AnalyticsService interface {
+	Get(ctx context.Context, symbol string, asOf time.Time) (model.SymbolAnalytics, error)
+	Correlation(ctx context.Context, symbols []string, from time.Time, to time.Time) (model.Correlation, error)
+}
+
+type analyticsServiceWithSymbols struct {
+	symbols SymbolService
+}
+
+func NewAnalyticsService(symbols SymbolService) AnalyticsService {
+	return &analyticsServiceWithSymbols{symbols: symbols}
+}
+
+func (s *analyticsServiceWithSymbols) dailyPrices(ctx context.Context, symbol string, from time.Time, to time.Time) ([]model.Price, error) {
+	prices, err := s.symbols.GetPrices(ctx, model.PriceQuery{Symbol: symbol, Interval: model.Interval1Day, From: from, To: to})
+	if err == model.SymbolNotFound {
+		return nil, fmt.Errorf("%w: %s", model.SymbolNotFound, symbol)
+	}
+	return prices, err
+}
+
+// Get computes analytics of the symbol from the latest price before asOf. Error wraps model.SymbolNotFound for unknown symbol
+// and is model.NotEnoughPrices if the symbol has no prices in the last year
+func (s *analyticsServiceWithSymbols) Get(ctx context.Context, symbol string, asOf time.Time) (model.SymbolAnalytics, error) {
+	result := model.SymbolAnalytics{Symbol: symbol}
+	// a week more than a year to find the price a year before the latest one on weekends and holidays
+	prices, err := s.dailyPrices(ctx, symbol, asOf.AddDate(-1, 0, -7), asOf)
+	if err != nil {
+		return result, err
+	}
+	if len(prices) == 0 {
+		return result, model.NotEnoughPrices
+	}
+	latest := prices[len(prices)-1]
+	latestDate, err := time.Parse(model.DateLayout, latest.Date)
+	if err != nil {
+		return result, err
+	}
+	result.Date, result.Close = latest.Date, latest.Close
+	closes := make([]float64, 0, len(prices))
+	for _, price := range prices {
+		closes = append(closes, price.Close.Float64())
+	}
+	yearStart := lastPriceOn(prices, latestDate.AddDate(-1, 0, 0))
+	result.Returns = model.Returns{
+		Day:   returnSince(closes, len(closes)-2),
+		Week:  returnSince(closes, lastPriceOn(prices, latestDate.AddDate(0, 0, -7))),
+		Month: returnSince(closes, lastPriceOn(prices, latestDate.AddDate(0, -1, 0))),
+		YTD:   returnSince(closes, lastPriceOn(prices, time.Date(latestDate.Year()-1, 12, 31, 0, 0, 0, 0, time.UTC))),
+		Year:  returnSince(closes, yearStart),
+	}
+	if yearStart < 0 {
+		yearStart = 0
+	}
+	result.Volatility = volatility(closes[yearStart:])
+	result.MaxDrawdown = maxDrawdown(closes[yearStart:])
+	return result, nil
+}
+
+// Correlation computes correlations of daily returns of symbols between from and to.
+// Returns of each pair are computed between consecutive dates both symbols have prices on
+func (s *analyticsServiceWithSymbols) Correlation(ctx context.Context, symbols []string, from time.Time, to time.Time) (model.Correlation, error) {
+	result := model.Correlation{Symbols: symbols, From: from.Format(model.DateLayout), To: to.Format(model.DateLayout)}
+	closes := make([]map[string]float64, len(symbols))
+	for i, symbol := range symbols {
+		prices, err := s.dailyPrices(ctx, symbol, from, to)
+		if err != nil {
+			return result, err
+		}
+		closes[i] = make(map[string]float64, len(prices))
+		for _, price := range prices {
+			closes[i][price.Date] = price.Close.Float64()
+		}
+	}
+	result.Matrix = make([][]*float64, len(symbols))
+	for i := range symbols {
+		result.Matrix[i] = make([]*float64, len(symbols))
+	}
+	for i := range symbols {
+		for j := i; j < len(symbols); j++ {
+			correlation := pearson(commonReturns(closes[i], closes[j]))
+			result.Matrix[i][j], result.Matrix[j][i] = correlation, correlation
+		}
+	}
+	return result, nil
+}
+
+// lastPriceOn returns index of the last price on or before the day and -1 if all prices are after the day
+func lastPriceOn(prices []model.Price, day time.Time) int {
+	date := day.Format(model.DateLayout)
+	return sort.Search(len(prices), func(i int) bool { return prices[i].Date > date }) - 1
+}
+
+// returnSince returns change of the last close relative to close at start. Nil if there is no start close
+func returnSince(closes []float64, start int) *float64 {
+	if start < 0 || start >= len(closes)-1 || closes[start] == 0 {
+		return nil
+	}
+	change := closes[len(closes)-1]/closes[start] - 1
+	return &change
+}
+
+// volatility is annualised sample standard deviation of daily log returns. Nil if there are less than 2 returns
+func volatility(closes []float64) *float64 {
+	returns := make([]float64, 0, len(closes))
+	for i := 1; i < len(closes); i++ {
+		if closes[i-1] > 0 && closes[i] > 0 {
+			returns = append(returns, math.Log(closes[i]/closes[i-1]))
+		}
+	}
+	if len(returns) < 2 {
+		return nil
+	}
+	mean := 0.0
+	for _, r := range returns {
+		mean += r
+	}
+	mean /= float64(len(returns))
+	variance := 0.0
+	for _, r := range returns {
+		variance += (r - mean) * (r - mean)
+	}
+	annualised := math.Sqrt(variance/float64(len(returns)-1)) * math.Sqrt(tradingDaysPerYear)
+	return &annualised
+}
+
+// maxDrawdown returns the largest fall of close from the preceding peak as a negative fraction of the peak
+func maxDrawdown(closes []float64) float64 {
+	peak, result := 0.0, 0.0
+	for _, close := range closes {
+		if close > peak {
+			peak = close
+		}
+		if peak > 0 && close/peak-1 < result {
+			result = close/peak - 1
+		}
+	}
+	return result
+}
+
+// commonReturns returns simple returns of both series between consecutive dates present in both of them
+func commonReturns(a map[string]float64, b map[string]float64) ([]float64, []float64) {
+	dates := make([]string, 0, len(a))
+	for date := range a {
+		if _, ok := b[date]; ok {
+			dates = append(dates, date)
+		}
+	}
+	sort.Strings(dates)
+	x, y := make([]float64, 0, len(dates)), make([]float64, 0, len(dates))
+	for i := 1; i < len(dates); i++ {
+		previousA, previousB := a[dates[i-1]], b[dates[i-1]]
+		if previousA == 0 || previousB == 0 {
+			continue
+		}
+		x = append(x, a[dates[i]]/previousA-1)
+		y = append(y, b[dates[i]]/previousB-1)
+	}
+	return x, y
+}
+
+// pearson returns correlation coefficient of x and y. Nil if there are not enough values or one of them is constant
+func pearson(x []float64, y []float64) *float64 {
+	if len(x) < minCorrelationReturns {
+		return nil
+	}
+	var meanX, meanY float64
+	for i := range x {
+		meanX += x[i]
+		meanY += y[i]
+	}
+	meanX, meanY = meanX/float64(len(x)), meanY/float64(len(y))
+	var covariance, varianceX, varianceY float64
+	for i := range x {
+		covariance += (x[i] - meanX) * (y[i] - meanY)
+		varianceX += (x[i] - meanX) * (x[i] - meanX)
+		varianceY += (y[i] - meanY) * (y[i] - meanY)
+	}
+	if varianceX == 0 || varianceY == 0 {
+		return nil
+	}
+	correlation := covariance / math.Sqrt(varianceX*varianceY)
+	return &correlation
+}
@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetAnalytics(t *testing.T) {
+	mockSymbols := mock.NewMockSymbolService(gomock.NewController(t))
+	service := NewAnalyticsService(mockSymbols)
+	asOf := time.Date(2023, 6, 3, 0, 0, 0, 0, time.UTC)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Day, From: time.Date(2022, 5, 27, 0, 0, 0, 0, time.UTC), To: asOf}).Return([]model.Price{
+		{Date: "2022-06-01", Close: "100"},
+		{Date: "2022-12-30", Close: "110"},
+		{Date: "2023-05-02", Close: "120"},
+		{Date: "2023-05-26", Close: "90"},
+		{Date: "2023-06-01", Close: "105"},
+		{Date: "2023-06-02", Close: "108"},
+	}, nil)
+	analytics, err := service.Get(context.TODO(), "AAPL", asOf)
+	assert.NoError(t, err, "Analytics should be computed")
+	assert.Equal(t, "2023-06-02", analytics.Date, "Analytics should be computed as of the latest price")
+	assert.Equal(t, model.Decimal("108"), analytics.Close, "Close should be the latest one")
+	for name, td := range map[string]struct {
+		actual   *float64
+		expected float64
+	}{
+		"1d":  {analytics.Returns.Day, 108.0/105 - 1},
+		"1w":  {analytics.Returns.Week, 0.2},
+		"1m":  {analytics.Returns.Month, -0.1},
+		"ytd": {analytics.Returns.YTD, 108.0/110 - 1},
+		"1y":  {analytics.Returns.Year, 0.08},
+	} {
+		if assert.NotNil(t, td.actual, "%s return should be computed", name) {
+			assert.InDelta(t, td.expected, *td.actual, 1e-9, "%s returns should be equal", name)
+		}
+	}
+	assert.NotNil(t, analytics.Volatility, "Volatility should be computed")
+	assert.InDelta(t, -0.25, analytics.MaxDrawdown, 1e-9, "Max drawdown should be from 120 to 90")
+
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), gomock.Any()).Return([]model.Price{}, nil)
+	_, err = service.Get(context.TODO(), "MSFT", asOf)
+	assert.Equal(t, model.NotEnoughPrices, err, "Symbol without prices should not have analytics")
+}
+
+func TestCorrelation(t *testing.T) {
+	mockSymbols := mock.NewMockSymbolService(gomock.NewController(t))
+	service := NewAnalyticsService(mockSymbols)
+	from, to := time.Date(2023, 5, 29, 0, 0, 0, 0, time.UTC), time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)
+	for symbol, closes := range map[string][]model.Decimal{
+		"AAPL": {"100", "110", "99", "108.9", "119.79"},
+		"MSFT": {"50", "55", "49.5", "54.45", "59.895"},
+		"GLD":  {"100", "90", "99", "89.1", "80.19"},
+	} {
+		prices := make([]model.Price, 0, len(closes))
+		for i, close := range closes {
+			prices = append(prices, model.Price{Date: from.AddDate(0, 0, i).Format(model.DateLayout), Close: close})
+		}
+		mockSymbols.EXPECT().GetPrices(gomock.Any(), model.PriceQuery{Symbol: symbol, Interval: model.Interval1Day, From: from, To: to}).Return(prices, nil)
+	}
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), model.PriceQuery{Symbol: "TSLA", Interval: model.Interval1Day, From: from, To: to}).
+		Return([]model.Price{{Date: "2023-06-02", Close: "213.97"}}, nil)
+	correlation, err := service.Correlation(context.TODO(), []string{"AAPL", "MSFT", "GLD", "TSLA"}, from, to)
+	assert.NoError(t, err, "Correlation should be computed")
+	assert.Equal(t, "2023-05-29", correlation.From, "From should be equal")
+	assert.InDelta(t, 1, *correlation.Matrix[0][0], 1e-9, "Symbol should correlate with itself")
+	assert.InDelta(t, 1, *correlation.Matrix[0][1], 1e-9, "Proportional prices should correlate")
+	assert.InDelta(t, -1, *correlation.Matrix[2][0], 1e-9, "Opposite prices should correlate negatively")
+	assert.Equal(t, correlation.Matrix[0][2], correlation.Matrix[2][0], "Matrix should be symmetric")
+	assert.Nil(t, correlation.Matrix[3][0], "Symbol without enough prices should not correlate")
+
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), gomock.Any()).Return(nil, model.SymbolNotFound)
+	_, err = service.Correlation(context.TODO(), []string{"UNKNOWN", "AAPL"}, from, to)
+	assert.EqualError(t, err, "symbol not found: UNKNOWN", "Unknown symbol should be reported")
+}
@@ -21,9 +21,23 @@ type AuditService interface {
 	LogSymbolCreated(ctx context.Context, symbol string)
 	LogSymbolUpdated(ctx context.Context, symbol string)
 	LogSymbolDeleted(ctx context.Context, symbol string)
+	LogExchangeCreated(ctx context.Context, micCode string)
+	LogExchangeUpdated(ctx context.Context, micCode string)
+	LogExchangeDeleted(ctx context.Context, micCode string)
+	LogExchangeSymbolAttached(ctx context.Context, micCode string, symbol string)
+	LogExchangeSymbolDetached(ctx context.Context, micCode string, symbol string)
 	LogUserSignUp(ctx context.Context, userID string)
 	LogUserSignIn(ctx context.Context, userID string)
 	LogUserRefreshToken(ctx context.Context, userID string)
+	LogUserViewed(ctx context.Context, userID string)
+	LogUsersListed(ctx context.Context, userIDs []string)
+	LogUserRolesUpdated(ctx context.Context, userID string)
+	LogUserDisabled(ctx context.Context, userID string)
+	LogUserEnabled(ctx context.Context, userID string)
+	LogUserLoggedOut(ctx context.Context, userID string)
+	LogUserProfileUpdated(ctx context.Context, userID string)
+	LogUserPasswordChanged(ctx context.Context, userID string)
+	LogUserDeleted(ctx context.Context, userID string)
 }
 
 var auditLog zerolog.Logger
@@ -79,6 +93,44 @@ func (s *auditServiceWithClientAndPublisher) LogSymbolDeleted(ctx context.Contex
 	})
 }
 
+const exchangeEntityPrefix = "exchange:"
+
+// exchangeRequest is a log request of an action on the exchange. Audit service has no exchange entity,
+// so exchanges are logged as symbol reference data with exchangeEntityPrefix before MIC code
+func exchangeRequest(ctx context.Context, action audit.LogRequest_Actions, micCode string) *audit.LogRequest {
+	return &audit.LogRequest{
+		Action:    action,
+		Entity:    audit.LogRequest_SYMBOL,
+		EntityId:  exchangeEntityPrefix + micCode,
+		Timestamp: timestamppb.Now(),
+		RequestId: utils.GetRequestId(ctx),
+	}
+}
+
+func (s *auditServiceWithClientAndPublisher) LogExchangeCreated(ctx context.Context, micCode string) {
+	s.sendRequest(ctx, exchangeRequest(ctx, audit.LogRequest_CREATE, micCode))
+}
+
+func (s *auditServiceWithClientAndPublisher) LogExchangeUpdated(ctx context.Context, micCode string) {
+	s.sendRequest(ctx, exchangeRequest(ctx, audit.LogRequest_UPDATE, micCode))
+}
+
+func (s *auditServiceWithClientAndPublisher) LogExchangeDeleted(ctx context.Context, micCode string) {
+	s.sendRequest(ctx, exchangeRequest(ctx, audit.LogRequest_DELETE, micCode))
+}
+
+// LogExchangeSymbolAttached is logged as update of both the exchange and the symbol
+func (s *auditServiceWithClientAndPublisher) LogExchangeSymbolAttached(ctx context.Context, micCode string, symbol string) {
+	s.sendRequest(ctx, exchangeRequest(ctx, audit.LogRequest_UPDATE, micCode))
+	s.LogSymbolUpdated(ctx, symbol)
+}
+
+// LogExchangeSymbolDetached is logged as update of both the exchange and the symbol
+func (s *auditServiceWithClientAndPublisher) LogExchangeSymbolDetached(ctx context.Context, micCode string, symbol string) {
+	s.sendRequest(ctx, exchangeRequest(ctx, audit.LogRequest_UPDATE, micCode))
+	s.LogSymbolUpdated(ctx, symbol)
+}
+
 func (s *auditServiceWithClientAndPublisher) LogUserSignUp(ctx context.Context, userID string) {
 	s.sendRequest(ctx, &audit.LogRequest{
 		Action:    audit.LogRequest_SIGN_UP,
@@ -108,3 +160,54 @@ func (s *auditServiceWithClientAndPublisher) LogUserRefreshToken(ctx context.Con
 		RequestId: utils.GetRequestId(ctx),
 	})
 }
+
+// userRequest is a log request of an action on the user
+func userRequest(ctx context.Context, action audit.LogRequest_Actions, userID string) *audit.LogRequest {
+	return &audit.LogRequest{
+		Action:    action,
+		Entity:    audit.LogRequest_USER,
+		EntityId:  userID,
+		Timestamp: timestamppb.Now(),
+		RequestId: utils.GetRequestId(ctx),
+	}
+}
+
+func (s *auditServiceWithClientAndPublisher) LogUserViewed(ctx context.Context, userID string) {
+	s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_GET, userID))
+}
+
+// LogUsersListed is logged as viewing of every listed user
+func (s *auditServiceWithClientAndPublisher) LogUsersListed(ctx context.Context, userIDs []string) {
+	for _, userID := range userIDs {
+		s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_GET, userID))
+	}
+}
+
+func (s *auditServiceWithClientAndPublisher) LogUserRolesUpdated(ctx context.Context, userID string) {
+	s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_UPDATE, userID))
+}
+
+func (s *auditServiceWithClientAndPublisher) LogUserDisabled(ctx context.Context, userID string) {
+	s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_UPDATE, userID))
+}
+
+func (s *auditServiceWithClientAndPublisher) LogUserEnabled(ctx context.Context, userID string) {
+	s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_UPDATE, userID))
+}
+
+// LogUserLoggedOut is logged as deletion of user refresh tokens
+func (s *auditServiceWithClientAndPublisher) LogUserLoggedOut(ctx context.Context, userID string) {
+	s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_DELETE, userID))
+}
+
+func (s *auditServiceWithClientAndPublisher) LogUserProfileUpdated(ctx context.Context, userID string) {
+	s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_UPDATE, userID))
+}
+
+func (s *auditServiceWithClientAndPublisher) LogUserPasswordChanged(ctx context.Context, userID string) {
+	s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_UPDATE, userID))
+}
+
+func (s *auditServiceWithClientAndPublisher) LogUserDeleted(ctx context.Context, userID string) {
+	s.sendRequest(ctx, userRequest(ctx, audit.LogRequest_DELETE, userID))
+}
@@ -2,11 +2,13 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/internal/repository"
+	pkg "github.com/galushkoart/finance-api/pkg/service"
+	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/gofrs/uuid/v5"
+	"github.com/rs/zerolog/log"
 	"math/rand"
 	"time"
 )
@@ -17,19 +19,27 @@ type authService struct {
 	jwtProducer         *JwtProducer
 	refreshTokenTimeout time.Duration
 	auditService        AuditService
+	notifier            pkg.Notifier
+	resetTokenTimeout   time.Duration
+	resetURL            string
 }
 
 type AuthService interface {
 	SignUp(ctx context.Context, signUp model.SignUp) error
 	SignIn(ctx context.Context, signIn model.SignIn) (string, string, time.Time, error)
 	RefreshToken(ctx context.Context, refreshToken string) (string, string, time.Time, error)
+	ForgotPassword(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, reset model.ResetPassword) error
 }
 
-func NewAuthService(repo repository.UserRepository, hasher *Hasher, jwtProducer *JwtProducer, refreshTokenTimeout time.Duration, auditService AuditService) AuthService {
-	return &authService{hasher: hasher, repo: repo, jwtProducer: jwtProducer, refreshTokenTimeout: refreshTokenTimeout, auditService: auditService}
+func NewAuthService(repo repository.UserRepository, hasher *Hasher, jwtProducer *JwtProducer, refreshTokenTimeout time.Duration, auditService AuditService,
+	notifier pkg.Notifier, resetTokenTimeout time.Duration, resetURL string) AuthService {
+	return &authService{hasher: hasher, repo: repo, jwtProducer: jwtProducer, refreshTokenTimeout: refreshTokenTimeout, auditService: auditService,
+		notifier: notifier, resetTokenTimeout: resetTokenTimeout, resetURL: resetURL}
 }
 
-var UserAlreadyExists = errors.New("user is already exists")
+// dummyHash is verified on sign in of unknown user
+const dummyHash = "$argon2id$v=19$m=65536,t=1,p=4$c29tZXNhbHRzb21lc2FsdA$Wz6B2bHbAkQOD3ITJzY1K0GBPUYF0ZbvXb5xIA5Kk3I"
 
 func (s *authService) SignUp(ctx context.Context, signUp model.SignUp) error {
 	available, err := s.repo.CheckLoginIsAvailable(ctx, signUp.Username, signUp.Email)
@@ -37,7 +47,7 @@ func (s *authService) SignUp(ctx context.Context, signUp model.SignUp) error {
 		return err
 	}
 	if !available {
-		return UserAlreadyExists
+		return model.UserAlreadyExists
 	}
 	id, err := uuid.NewV7()
 	if err != nil {
@@ -48,24 +58,60 @@ func (s *authService) SignUp(ctx context.Context, signUp model.SignUp) error {
 	if err != nil {
 		return err
 	}
-	return s.repo.Create(ctx, model.User{ID: id.String(), Username: signUp.Username, Email: signUp.Email, Role: model.ClientRole, Password: passHash})
+	return s.repo.Create(ctx, model.User{ID: id.String(), Username: signUp.Username, Email: signUp.Email, Roles: []model.Role{model.ClientRole}, Password: passHash})
 }
 
+// SignIn verifies password of the user found by login. Error is model.UserNotFound if there is no such user or password doesn't match
+// and model.UserDisabled if the user is disabled.
+// Password hash is upgraded to argon2id with current parameters on successful sign in
 func (s *authService) SignIn(ctx context.Context, signIn model.SignIn) (string, string, time.Time, error) {
-	passHash, err := s.hasher.Hash(signIn.Password)
-	if err != nil {
+	user, err := s.repo.GetUserByLogin(ctx, signIn.Login)
+	if err == model.UserNotFound {
+		// spend the same time as on existing user to not reveal registered logins
+		_, _, _ = s.hasher.Verify(signIn.Password, dummyHash)
+		return "", "", time.Time{}, err
+	} else if err != nil {
 		return "", "", time.Time{}, err
 	}
-	user, err := s.repo.GetUser(ctx, signIn.Login, passHash)
+	match, rehash, err := s.hasher.Verify(signIn.Password, user.Password)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
+	if !match {
+		return "", "", time.Time{}, model.UserNotFound
+	}
+	if user.Disabled {
+		return "", "", time.Time{}, model.UserDisabled
+	}
+	if rehash {
+		s.rehash(ctx, user.ID, signIn.Password)
+	}
 	go s.auditService.LogUserSignIn(ctx, user.ID)
-	return s.getTokens(ctx, user.ID, model.ClientRole)
+	return s.getTokens(ctx, user.ID)
+}
+
+// rehash replaces outdated password hash of the user. Failure is only logged as the password is already verified
+func (s *authService) rehash(ctx context.Context, userID string, password string) {
+	passHash, err := s.hasher.Hash(password)
+	if err == nil {
+		err = s.repo.UpdatePassword(ctx, userID, passHash)
+	}
+	if err != nil {
+		utils.LogRequest(ctx, log.Warn()).Err(err).Msgf("Failed to upgrade password hash of %s user", userID)
+	}
 }
 
-func (s *authService) getTokens(ctx context.Context, userId string, role model.Role) (string, string, time.Time, error) {
-	jwtToken, err := s.jwtProducer.GetToken(ctx, userId, role)
+// getTokens issues jwt token with current roles and permissions of the user and refresh token
+func (s *authService) getTokens(ctx context.Context, userId string) (string, string, time.Time, error) {
+	roles, err := s.repo.GetUserRoles(ctx, userId)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	permissions, err := s.repo.GetUserPermissions(ctx, userId)
+	if err != nil {
+		return "", "", time.Time{}, err
+	}
+	jwtToken, err := s.jwtProducer.GetToken(ctx, model.Principal{ID: userId, Roles: roles, Permissions: permissions})
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
@@ -89,12 +135,15 @@ func (s *authService) RefreshToken(ctx context.Context, refreshToken string) (st
 	if token.ExpiresAt.Before(time.Now()) {
 		return "", "", time.Time{}, model.TokenExpired
 	}
-	go s.auditService.LogUserRefreshToken(ctx, token.UserId)
-	role, err := s.repo.GetUserRole(ctx, token.UserId)
+	user, err := s.repo.GetUserByID(ctx, token.UserId)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
-	return s.getTokens(ctx, token.UserId, role)
+	if user.Disabled {
+		return "", "", time.Time{}, model.UserDisabled
+	}
+	go s.auditService.LogUserRefreshToken(ctx, token.UserId)
+	return s.getTokens(ctx, token.UserId)
 }
 
 func newRefreshToken() (string, error) {
@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignIn(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewAuthService(mockRepo, testHasher, NewJwtProducer("secret", time.Minute), time.Hour, mockAudit, nil, time.Hour, "")
+	mockAudit.EXPECT().LogUserSignIn(gomock.Any(), gomock.Any()).AnyTimes()
+	expectTokens := func(userID string) {
+		mockRepo.EXPECT().GetUserRoles(gomock.Any(), userID).Return([]model.Role{model.ClientRole}, nil)
+		mockRepo.EXPECT().GetUserPermissions(gomock.Any(), userID).Return([]model.Permission{}, nil)
+		mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+	}
+
+	mockRepo.EXPECT().GetUserByLogin(gomock.Any(), "legacy").Return(model.User{ID: "1", Username: "legacy", Password: legacyPasswordHash}, nil)
+	mockRepo.EXPECT().UpdatePassword(gomock.Any(), "1", gomock.Any()).Do(func(_ context.Context, _ string, hash string) {
+		assert.True(t, strings.HasPrefix(hash, "$argon2id$"), "Legacy hash should be upgraded to argon2id")
+		match, rehash, err := testHasher.Verify("password", hash)
+		assert.NoError(t, err, "Upgraded hash should be supported")
+		assert.True(t, match, "Upgraded hash should match the password")
+		assert.False(t, rehash, "Upgraded hash should be up to date")
+	}).Return(nil)
+	expectTokens("1")
+	token, refreshToken, _, err := service.SignIn(context.TODO(), model.SignIn{Login: "legacy", Password: "password"})
+	assert.NoError(t, err, "User with legacy hash should sign in")
+	assert.NotEmpty(t, token, "Token should be issued")
+	assert.NotEmpty(t, refreshToken, "Refresh token should be issued")
+
+	mockRepo.EXPECT().GetUserByLogin(gomock.Any(), "legacy").Return(model.User{ID: "1", Username: "legacy", Password: legacyPasswordHash}, nil)
+	_, _, _, err = service.SignIn(context.TODO(), model.SignIn{Login: "legacy", Password: "Password"})
+	assert.Equal(t, model.UserNotFound, err, "Wrong password should not be accepted nor upgrade legacy hash")
+
+	mockRepo.EXPECT().GetUserByLogin(gomock.Any(), "user").Return(model.User{ID: "2", Username: "user", Password: argon2PasswordHash}, nil)
+	expectTokens("2")
+	_, _, _, err = service.SignIn(context.TODO(), model.SignIn{Login: "user", Password: "password"})
+	assert.NoError(t, err, "Up to date hash should not be upgraded")
+
+	mockRepo.EXPECT().GetUserByLogin(gomock.Any(), "disabled").Return(model.User{ID: "3", Username: "disabled", Password: legacyPasswordHash, Disabled: true}, nil)
+	_, _, _, err = service.SignIn(context.TODO(), model.SignIn{Login: "disabled", Password: "password"})
+	assert.Equal(t, model.UserDisabled, err, "Disabled user should not sign in nor get hash upgraded")
+}
+
+func TestRefreshToken(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewAuthService(mockRepo, testHasher, NewJwtProducer("secret", time.Minute), time.Hour, mockAudit, nil, time.Hour, "")
+	mockAudit.EXPECT().LogUserRefreshToken(gomock.Any(), gomock.Any()).AnyTimes()
+	expiresAt := time.Now().Add(time.Hour)
+
+	mockRepo.EXPECT().GetRefreshToken(gomock.Any(), "active").Return(model.RefreshToken{Token: "active", UserId: "1", ExpiresAt: expiresAt}, nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1", Username: "user"}, nil)
+	mockRepo.EXPECT().GetUserRoles(gomock.Any(), "1").Return([]model.Role{model.ClientRole}, nil)
+	mockRepo.EXPECT().GetUserPermissions(gomock.Any(), "1").Return([]model.Permission{}, nil)
+	mockRepo.EXPECT().InsertRefreshToken(gomock.Any(), gomock.Any()).Return(nil)
+	token, refreshToken, _, err := service.RefreshToken(context.TODO(), "active")
+	assert.NoError(t, err, "Active refresh token should be accepted")
+	assert.NotEmpty(t, token, "Token should be issued")
+	assert.NotEmpty(t, refreshToken, "Refresh token should be issued")
+
+	mockRepo.EXPECT().GetRefreshToken(gomock.Any(), "disabled").Return(model.RefreshToken{Token: "disabled", UserId: "2", ExpiresAt: expiresAt}, nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "2").Return(model.User{ID: "2", Username: "disabled", Disabled: true}, nil)
+	_, _, _, err = service.RefreshToken(context.TODO(), "disabled")
+	assert.Equal(t, model.UserDisabled, err, "Disabled user should not get new tokens")
+}
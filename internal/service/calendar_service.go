@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/repository"
+	"gopkg.in/yaml.v3"
+	"os"
+	"sync"
+	"time"
+)
+
+// CalendarService knows regular sessions, holidays and half-days of exchanges by their MIC codes
+type CalendarService interface {
+	Get(micCode string) (model.TradingCalendar, error)
+	Save(ctx context.Context, calendar model.TradingCalendar) error
+	Status(micCode string, now time.Time) (model.MarketStatus, error)
+	LastCompletedDay(micCode string, now time.Time) (time.Time, bool)
+	Location(micCode string) (*time.Location, bool)
+}
+
+type calendarServiceWithRepo struct {
+	repo      repository.CalendarRepository
+	mu        sync.RWMutex
+	calendars map[string]*tradingCalendar
+}
+
+// LoadCalendars reads bundled calendars from yaml file with calendars by MIC codes
+func LoadCalendars(path string) ([]model.TradingCalendar, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var byMicCode map[string]model.TradingCalendar
+	if err = yaml.Unmarshal(content, &byMicCode); err != nil {
+		return nil, err
+	}
+	result := make([]model.TradingCalendar, 0, len(byMicCode))
+	for micCode, calendar := range byMicCode {
+		calendar.MicCode = micCode
+		result = append(result, calendar)
+	}
+	return result, nil
+}
+
+// NewCalendarService creates CalendarService with bundled calendars. Calendars saved by admins replace bundled ones
+func NewCalendarService(ctx context.Context, repo repository.CalendarRepository, bundled []model.TradingCalendar) (CalendarService, error) {
+	s := &calendarServiceWithRepo{repo: repo, calendars: make(map[string]*tradingCalendar, len(bundled))}
+	stored, err := repo.GetAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, calendar := range append(bundled, stored...) {
+		parsed, err := newTradingCalendar(calendar)
+		if err != nil {
+			return nil, err
+		}
+		s.calendars[calendar.MicCode] = parsed
+	}
+	return s, nil
+}
+
+func (s *calendarServiceWithRepo) calendar(micCode string) (*tradingCalendar, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	calendar, ok := s.calendars[micCode]
+	return calendar, ok
+}
+
+func (s *calendarServiceWithRepo) Get(micCode string) (model.TradingCalendar, error) {
+	calendar, ok := s.calendar(micCode)
+	if !ok {
+		return model.TradingCalendar{}, model.CalendarNotFound
+	}
+	return calendar.source, nil
+}
+
+// Save validates and stores the calendar. Error wraps model.InvalidCalendar if calendar is invalid
+func (s *calendarServiceWithRepo) Save(ctx context.Context, calendar model.TradingCalendar) error {
+	parsed, err := newTradingCalendar(calendar)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.Save(ctx, calendar); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.calendars[calendar.MicCode] = parsed
+	return nil
+}
+
+func (s *calendarServiceWithRepo) Status(micCode string, now time.Time) (model.MarketStatus, error) {
+	calendar, ok := s.calendar(micCode)
+	if !ok {
+		return model.MarketStatus{}, model.CalendarNotFound
+	}
+	return calendar.status(now), nil
+}
+
+// LastCompletedDay returns the day of the last session of the exchange closed before now.
+// ok is false if the exchange has no calendar
+func (s *calendarServiceWithRepo) LastCompletedDay(micCode string, now time.Time) (time.Time, bool) {
+	calendar, ok := s.calendar(micCode)
+	if !ok {
+		return time.Time{}, false
+	}
+	return calendar.lastCompletedDay(now)
+}
+
+func (s *calendarServiceWithRepo) Location(micCode string) (*time.Location, bool) {
+	calendar, ok := s.calendar(micCode)
+	if !ok {
+		return nil, false
+	}
+	return calendar.location, true
+}
@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// triangulationCurrency is used to resolve rates of currencies without direct or inverse fx symbol
+	triangulationCurrency = "USD"
+	// rateScale is the number of digits after decimal point of computed rates and converted values
+	rateScale = 10
+	// rateLookback is the number of days before the date searched for the last close of fx symbol
+	rateLookback = 7
+)
+
+// ConversionService converts amounts and prices between currencies with rates of stored fx symbols
+type ConversionService interface {
+	Rate(ctx context.Context, from string, to string, date time.Time) (model.Rate, error)
+	Convert(ctx context.Context, from string, to string, amount model.Decimal, date time.Time) (model.Conversion, error)
+	ConvertPrices(ctx context.Context, prices []model.Price, from string, to string) ([]model.Price, error)
+}
+
+type conversionServiceWithSymbols struct {
+	symbols SymbolService
+}
+
+func NewConversionService(symbols SymbolService) ConversionService {
+	return &conversionServiceWithSymbols{symbols: symbols}
+}
+
+// datedRate is the rate by the close of the date
+type datedRate struct {
+	date  string
+	value model.Decimal
+}
+
+// Rate returns the last rate on or before the date. Error is model.RateNotFound if currencies can't be converted
+func (s *conversionServiceWithSymbols) Rate(ctx context.Context, from string, to string, date time.Time) (model.Rate, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	result := model.Rate{From: from, To: to, Path: []string{}}
+	if from == to {
+		result.Date, result.Value = date.Format(model.DateLayout), "1"
+		return result, nil
+	}
+	rates, path, err := s.rates(ctx, from, to, date.AddDate(0, 0, -rateLookback), date)
+	if err != nil {
+		return result, err
+	}
+	latest := rates[len(rates)-1]
+	result.Date, result.Value, result.Path = latest.date, latest.value, path
+	return result, nil
+}
+
+func (s *conversionServiceWithSymbols) Convert(ctx context.Context, from string, to string, amount model.Decimal, date time.Time) (model.Conversion, error) {
+	rate, err := s.Rate(ctx, from, to, date)
+	if err != nil {
+		return model.Conversion{Rate: rate, Amount: amount}, err
+	}
+	return model.Conversion{Rate: rate, Amount: amount, Result: amount.Mul(rate.Value).Round(rateScale)}, nil
+}
+
+// ConvertPrices converts open, high, low and close of prices ordered by date with the last rate on or before the date of each price.
+// Intraday prices are converted with the rate by the close of their day
+func (s *conversionServiceWithSymbols) ConvertPrices(ctx context.Context, prices []model.Price, from string, to string) ([]model.Price, error) {
+	from, to = strings.ToUpper(from), strings.ToUpper(to)
+	if from == to || len(prices) == 0 {
+		return prices, nil
+	}
+	first, err := time.Parse(model.DateLayout, prices[0].Date[:len(model.DateLayout)])
+	if err != nil {
+		return nil, err
+	}
+	last, err := time.Parse(model.DateLayout, prices[len(prices)-1].Date[:len(model.DateLayout)])
+	if err != nil {
+		return nil, err
+	}
+	rates, _, err := s.rates(ctx, from, to, first.AddDate(0, 0, -rateLookback), last)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]model.Price, 0, len(prices))
+	for _, price := range prices {
+		rate, ok := rateOn(rates, price.Date)
+		if !ok {
+			return nil, model.RateNotFound
+		}
+		price.Open = price.Open.Mul(rate).Round(rateScale)
+		price.High = price.High.Mul(rate).Round(rateScale)
+		price.Low = price.Low.Mul(rate).Round(rateScale)
+		price.Close = price.Close.Mul(rate).Round(rateScale)
+		result = append(result, price)
+	}
+	return result, nil
+}
+
+// rates resolves rates of from currency in to currency between dates from direct or inverse fx symbol or through USD.
+// Stored fx symbols are tried first, unknown fx symbols are fetched from api only if stored ones can't resolve the rates.
+// Path contains resolved fx symbols
+func (s *conversionServiceWithSymbols) rates(ctx context.Context, from string, to string, fromDate time.Time, toDate time.Time) ([]datedRate, []string, error) {
+	rates, path, err := s.resolve(ctx, from, to, fromDate, toDate, false)
+	if err == model.RateNotFound {
+		return s.resolve(ctx, from, to, fromDate, toDate, true)
+	}
+	return rates, path, err
+}
+
+// resolve returns rates from direct or inverse fx symbol or through USD. Unknown fx symbols are fetched from api if fetch is set
+func (s *conversionServiceWithSymbols) resolve(ctx context.Context, from string, to string, fromDate time.Time, toDate time.Time, fetch bool) ([]datedRate, []string, error) {
+	rates, symbol, err := s.legRates(ctx, from, to, fromDate, toDate, fetch)
+	if err == nil {
+		return rates, []string{symbol}, nil
+	}
+	if err != model.RateNotFound || from == triangulationCurrency || to == triangulationCurrency {
+		return nil, nil, err
+	}
+	first, firstSymbol, err := s.legRates(ctx, from, triangulationCurrency, fromDate, toDate, fetch)
+	if err != nil {
+		return nil, nil, err
+	}
+	second, secondSymbol, err := s.legRates(ctx, triangulationCurrency, to, fromDate, toDate, fetch)
+	if err != nil {
+		return nil, nil, err
+	}
+	rates = make([]datedRate, 0, len(first))
+	for _, rate := range first {
+		if other, ok := rateOn(second, rate.date); ok {
+			rates = append(rates, datedRate{date: rate.date, value: rate.value.Mul(other).Round(rateScale)})
+		}
+	}
+	if len(rates) == 0 {
+		return nil, nil, model.RateNotFound
+	}
+	return rates, []string{firstSymbol, secondSymbol}, nil
+}
+
+// legRates returns rates of base currency in quote currency from base/quote symbol or inverted rates of quote/base symbol
+func (s *conversionServiceWithSymbols) legRates(ctx context.Context, base string, quote string, from time.Time, to time.Time, fetch bool) ([]datedRate, string, error) {
+	direct := base + "/" + quote
+	rates, err := s.pairRates(ctx, direct, from, to, fetch)
+	if err != nil && !errors.Is(err, model.SymbolNotFound) {
+		return nil, "", err
+	} else if err == nil && len(rates) > 0 {
+		return rates, direct, nil
+	}
+	inverse := quote + "/" + base
+	rates, err = s.pairRates(ctx, inverse, from, to, fetch)
+	if err != nil && !errors.Is(err, model.SymbolNotFound) {
+		return nil, "", err
+	} else if err != nil || len(rates) == 0 {
+		return nil, "", model.RateNotFound
+	}
+	for i := range rates {
+		// pairRates skips non-positive closes, so division can't fail
+		rates[i].value, _ = model.Decimal("1").Div(rates[i].value, rateScale)
+	}
+	return rates, inverse, nil
+}
+
+// pairRates returns daily closes of fx symbol between dates. Symbol unknown to repo is fetched from api first if fetch is set
+func (s *conversionServiceWithSymbols) pairRates(ctx context.Context, symbol string, from time.Time, to time.Time, fetch bool) ([]datedRate, error) {
+	query := model.PriceQuery{Symbol: symbol, Interval: model.Interval1Day, From: from, To: to}
+	prices, err := s.symbols.GetPrices(ctx, query)
+	if err == model.SymbolNotFound && fetch {
+		if _, err = s.symbols.GetBySymbol(ctx, symbol); err != nil {
+			return nil, err
+		}
+		prices, err = s.symbols.GetPrices(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+	rates := make([]datedRate, 0, len(prices))
+	for _, price := range prices {
+		if price.Close.Sign() > 0 {
+			rates = append(rates, datedRate{date: price.Date, value: price.Close})
+		}
+	}
+	return rates, nil
+}
+
+// rateOn returns the last of rates ordered by date on or before the date
+func rateOn(rates []datedRate, date string) (model.Decimal, bool) {
+	i := sort.Search(len(rates), func(i int) bool { return rates[i].date > date }) - 1
+	if i < 0 {
+		return "", false
+	}
+	return rates[i].value, true
+}
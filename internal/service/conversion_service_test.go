@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestConvert(t *testing.T) {
+	mockSymbols := mock.NewMockSymbolService(gomock.NewController(t))
+	service := NewConversionService(mockSymbols)
+	date := time.Date(2023, 6, 4, 0, 0, 0, 0, time.UTC)
+	pricesOf := func(symbol string) model.PriceQuery {
+		return model.PriceQuery{Symbol: symbol, Interval: model.Interval1Day, From: date.AddDate(0, 0, -rateLookback), To: date}
+	}
+
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("EUR/USD")).Return([]model.Price{{Date: "2023-06-01", Close: "1.07"}, {Date: "2023-06-02", Close: "1.08"}}, nil)
+	conversion, err := service.Convert(context.TODO(), "eur", "USD", "10", date)
+	assert.NoError(t, err, "Direct rate should be resolved")
+	assert.Equal(t, model.Rate{From: "EUR", To: "USD", Date: "2023-06-02", Value: "1.08", Path: []string{"EUR/USD"}}, conversion.Rate, "Rate should be the last close")
+	assert.Equal(t, 0, conversion.Result.Cmp("10.8"), "Amount should be converted with the rate")
+
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("USD/EUR")).Return(nil, model.SymbolNotFound)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("EUR/USD")).Return([]model.Price{{Date: "2023-06-02", Close: "1.25"}}, nil)
+	rate, err := service.Rate(context.TODO(), "USD", "EUR", date)
+	assert.NoError(t, err, "Inverse rate should be resolved")
+	assert.Equal(t, 0, rate.Value.Cmp("0.8"), "Inverse rate should be reciprocal of close")
+	assert.Equal(t, []string{"EUR/USD"}, rate.Path, "Path should contain inverse symbol")
+
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("EUR/JPY")).Return(nil, model.SymbolNotFound)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("JPY/EUR")).Return([]model.Price{}, nil)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("EUR/USD")).Return([]model.Price{{Date: "2023-06-01", Close: "1.07"}, {Date: "2023-06-02", Close: "1.08"}}, nil)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("USD/JPY")).Return([]model.Price{{Date: "2023-06-01", Close: "140"}}, nil)
+	rate, err = service.Rate(context.TODO(), "EUR", "JPY", date)
+	assert.NoError(t, err, "Rate should be triangulated via USD without fetching direct symbol")
+	assert.Equal(t, "2023-06-02", rate.Date, "Date should be the last one of the first leg")
+	assert.Equal(t, 0, rate.Value.Cmp("151.2"), "Rate should be product of legs on the last common date")
+	assert.Equal(t, []string{"EUR/USD", "USD/JPY"}, rate.Path, "Path should contain both legs")
+
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("GBP/USD")).Return(nil, model.SymbolNotFound).Times(2)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("USD/GBP")).Return(nil, model.SymbolNotFound)
+	mockSymbols.EXPECT().GetBySymbol(gomock.Any(), "GBP/USD").Return(model.Symbol{Symbol: "GBP/USD"}, nil)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), pricesOf("GBP/USD")).Return([]model.Price{{Date: "2023-06-02", Close: "1.25"}}, nil)
+	rate, err = service.Rate(context.TODO(), "GBP", "USD", date)
+	assert.NoError(t, err, "Rate should be resolved from fetched symbol")
+	assert.Equal(t, []string{"GBP/USD"}, rate.Path, "Path should contain fetched symbol")
+
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), gomock.Any()).Return([]model.Price{}, nil).Times(4)
+	_, err = service.Rate(context.TODO(), "GBP", "USD", date)
+	assert.Equal(t, model.RateNotFound, err, "Rate without prices should not be found")
+}
+
+func TestConvertPrices(t *testing.T) {
+	mockSymbols := mock.NewMockSymbolService(gomock.NewController(t))
+	service := NewConversionService(mockSymbols)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), model.PriceQuery{Symbol: "USD/EUR", Interval: model.Interval1Day,
+		From: time.Date(2023, 5, 25, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)}).
+		Return([]model.Price{{Date: "2023-05-31", Close: "0.9"}, {Date: "2023-06-02", Close: "0.95"}}, nil)
+	prices, err := service.ConvertPrices(context.TODO(), []model.Price{
+		{Date: "2023-06-01", Open: "100", High: "110", Low: "90", Close: "105", Volume: "1000"},
+		{Date: "2023-06-02 10:30:00", Open: "100", High: "100", Low: "100", Close: "100", Volume: "2000"},
+	}, "USD", "EUR")
+	assert.NoError(t, err, "Prices should be converted")
+	if assert.Len(t, prices, 2, "All prices should be converted") {
+		assert.Equal(t, 0, prices[0].Close.Cmp("94.5"), "Price should be converted with the last rate before its date")
+		assert.Equal(t, 0, prices[0].High.Cmp("99"), "High should be converted")
+		assert.Equal(t, model.Decimal("1000"), prices[0].Volume, "Volume should not be converted")
+		assert.Equal(t, 0, prices[1].Open.Cmp("95"), "Intraday price should be converted with the rate of its day")
+	}
+
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), gomock.Any()).Return([]model.Price{{Date: "2023-06-02", Close: "0.95"}}, nil)
+	_, err = service.ConvertPrices(context.TODO(), []model.Price{{Date: "2023-06-01", Close: "105"}}, "USD", "EUR")
+	assert.Equal(t, model.RateNotFound, err, "Price without rate should not be converted")
+}
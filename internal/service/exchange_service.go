@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/repository"
+)
+
+type ExchangeService interface {
+	GetAll(ctx context.Context) ([]model.Exchange, error)
+	GetByMicCode(ctx context.Context, micCode string) (model.Exchange, error)
+	Add(ctx context.Context, exchange model.Exchange) error
+	Update(ctx context.Context, micCode string, exchange model.UpdateExchange) error
+	Delete(ctx context.Context, micCode string) error
+	GetSymbols(ctx context.Context, micCode string) ([]model.Symbol, error)
+	AttachSymbol(ctx context.Context, micCode string, symbolName string) error
+	DetachSymbol(ctx context.Context, micCode string, symbolName string) error
+}
+
+type exchangeServiceWithRepo struct {
+	repo         repository.ExchangeRepository
+	auditService AuditService
+}
+
+func NewExchangeService(repo repository.ExchangeRepository, auditService AuditService) ExchangeService {
+	return &exchangeServiceWithRepo{repo: repo, auditService: auditService}
+}
+
+func (s *exchangeServiceWithRepo) GetAll(ctx context.Context) ([]model.Exchange, error) {
+	return s.repo.GetAll(ctx)
+}
+
+func (s *exchangeServiceWithRepo) GetByMicCode(ctx context.Context, micCode string) (model.Exchange, error) {
+	return s.repo.GetByMicCode(ctx, micCode)
+}
+
+func (s *exchangeServiceWithRepo) Add(ctx context.Context, exchange model.Exchange) error {
+	if err := s.repo.Add(ctx, exchange); err != nil {
+		return err
+	}
+	go s.auditService.LogExchangeCreated(ctx, exchange.MicCode)
+	return nil
+}
+
+func (s *exchangeServiceWithRepo) Update(ctx context.Context, micCode string, exchange model.UpdateExchange) error {
+	if err := s.repo.Update(ctx, micCode, exchange); err != nil {
+		return err
+	}
+	go s.auditService.LogExchangeUpdated(ctx, micCode)
+	return nil
+}
+
+func (s *exchangeServiceWithRepo) Delete(ctx context.Context, micCode string) error {
+	if err := s.repo.Delete(ctx, micCode); err != nil {
+		return err
+	}
+	go s.auditService.LogExchangeDeleted(ctx, micCode)
+	return nil
+}
+
+func (s *exchangeServiceWithRepo) GetSymbols(ctx context.Context, micCode string) ([]model.Symbol, error) {
+	return s.repo.GetSymbols(ctx, micCode)
+}
+
+func (s *exchangeServiceWithRepo) AttachSymbol(ctx context.Context, micCode string, symbolName string) error {
+	if err := s.repo.AttachSymbol(ctx, micCode, symbolName); err != nil {
+		return err
+	}
+	go s.auditService.LogExchangeSymbolAttached(ctx, micCode, symbolName)
+	return nil
+}
+
+func (s *exchangeServiceWithRepo) DetachSymbol(ctx context.Context, micCode string, symbolName string) error {
+	if err := s.repo.DetachSymbol(ctx, micCode, symbolName); err != nil {
+		return err
+	}
+	go s.auditService.LogExchangeSymbolDetached(ctx, micCode, symbolName)
+	return nil
+}
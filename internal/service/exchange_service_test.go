@@ -0,0 +1,58 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/exchange_repository_mock.go -source=../repository/exchange_repository.go ExchangeRepository
+
+func TestExchangeWritesAreAudited(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockExchangeRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewExchangeService(mockRepo, mockAudit)
+	name := "Nasdaq"
+	audited := make(chan string, 5)
+	mockRepo.EXPECT().Add(gomock.Any(), model.Exchange{Name: "NASDAQ", MicCode: "XNGS"}).Return(nil)
+	mockRepo.EXPECT().Update(gomock.Any(), "XNGS", model.UpdateExchange{Name: &name}).Return(nil)
+	mockRepo.EXPECT().AttachSymbol(gomock.Any(), "XNGS", "AAPL").Return(nil)
+	mockRepo.EXPECT().DetachSymbol(gomock.Any(), "XNGS", "AAPL").Return(nil)
+	mockRepo.EXPECT().Delete(gomock.Any(), "XNGS").Return(nil)
+	mockAudit.EXPECT().LogExchangeCreated(gomock.Any(), "XNGS").Do(func(_, _ any) { audited <- "created" })
+	mockAudit.EXPECT().LogExchangeUpdated(gomock.Any(), "XNGS").Do(func(_, _ any) { audited <- "updated" })
+	mockAudit.EXPECT().LogExchangeSymbolAttached(gomock.Any(), "XNGS", "AAPL").Do(func(_, _, _ any) { audited <- "attached" })
+	mockAudit.EXPECT().LogExchangeSymbolDetached(gomock.Any(), "XNGS", "AAPL").Do(func(_, _, _ any) { audited <- "detached" })
+	mockAudit.EXPECT().LogExchangeDeleted(gomock.Any(), "XNGS").Do(func(_, _ any) { audited <- "deleted" })
+
+	assert.NoError(t, service.Add(context.TODO(), model.Exchange{Name: "NASDAQ", MicCode: "XNGS"}))
+	assert.NoError(t, service.Update(context.TODO(), "XNGS", model.UpdateExchange{Name: &name}))
+	assert.NoError(t, service.AttachSymbol(context.TODO(), "XNGS", "AAPL"))
+	assert.NoError(t, service.DetachSymbol(context.TODO(), "XNGS", "AAPL"))
+	assert.NoError(t, service.Delete(context.TODO(), "XNGS"))
+	var actions []string
+	for range [5]struct{}{} {
+		select {
+		case action := <-audited:
+			actions = append(actions, action)
+		case <-time.After(time.Second):
+			t.Fatal("exchange write is not audited")
+		}
+	}
+	assert.ElementsMatch(t, []string{"created", "updated", "attached", "detached", "deleted"}, actions)
+}
+
+func TestFailedExchangeWriteIsNotAudited(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockExchangeRepository(controller)
+	service := NewExchangeService(mockRepo, mock.NewMockAuditService(controller))
+	mockRepo.EXPECT().Delete(gomock.Any(), "XNGS").Return(model.ExchangeNotFound)
+	assert.True(t, errors.Is(service.Delete(context.TODO(), "XNGS"), model.ExchangeNotFound))
+}
@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// flightGroup deduplicates concurrent calls with the same key: callers arriving while the call is running
+// wait for it and share its result instead of making their own call.
+// Call is cancelled after timeout if it is positive or once every caller has stopped waiting
+type flightGroup[T any] struct {
+	mu      sync.Mutex
+	calls   map[string]*flight[T]
+	timeout time.Duration
+	// joined is called under the lock when caller starts or joins the call. It is set by tests only
+	joined func(key string)
+}
+
+type flight[T any] struct {
+	done   chan struct{}
+	result T
+	err    error
+	call   *call
+}
+
+// call is the running fn shared by flights of its keys
+type call struct {
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// start creates context of the call which keeps values of the caller context
+func (g *flightGroup[T]) start(ctx context.Context) (context.Context, *call) {
+	var callCtx context.Context
+	var cancel context.CancelFunc
+	if g.timeout > 0 {
+		callCtx, cancel = context.WithTimeout(detachedContext{ctx}, g.timeout)
+	} else {
+		callCtx, cancel = context.WithCancel(detachedContext{ctx})
+	}
+	return callCtx, &call{cancel: cancel}
+}
+
+// leave is called when caller stops waiting for the flight before it is done. The call is cancelled with the last caller
+func (g *flightGroup[T]) leave(f *flight[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	f.call.waiters--
+	if f.call.waiters == 0 {
+		f.call.cancel()
+	}
+}
+
+// Do runs fn once for all concurrent callers with the key. fn is not cancelled while any caller is waiting,
+// so waiting callers still get the result if the first one leaves. Every caller stops waiting when its own context is done
+func (g *flightGroup[T]) Do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight[T])
+	}
+	f, running := g.calls[key]
+	if !running {
+		callCtx, c := g.start(ctx)
+		f = &flight[T]{done: make(chan struct{}), call: c}
+		g.calls[key] = f
+		go func() {
+			defer c.cancel()
+			f.result, f.err = fn(callCtx)
+			g.mu.Lock()
+			delete(g.calls, key)
+			g.mu.Unlock()
+			close(f.done)
+		}()
+	}
+	f.call.waiters++
+	if g.joined != nil {
+		g.joined(key)
+	}
+	g.mu.Unlock()
+	select {
+	case <-f.done:
+		return f.result, f.err
+	case <-ctx.Done():
+		g.leave(f)
+		var empty T
+		return empty, ctx.Err()
+	}
+}
+
+// DoAll runs fn once for all keys without running call and joins running calls of other keys, so batch callers
+// share results with single key callers. fn gets the keys it is responsible for and returns result of every key.
+// Results of keys missing in fn result have the error of fn. Waiting stops when caller context is done
+func (g *flightGroup[T]) DoAll(ctx context.Context, keys []string, fn func(ctx context.Context, keys []string) (map[string]T, map[string]error, error)) (map[string]T, map[string]error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = make(map[string]*flight[T])
+	}
+	flights := make(map[string]*flight[T], len(keys))
+	started := make(map[string]*flight[T])
+	own := make([]string, 0, len(keys))
+	callCtx, c := g.start(ctx)
+	for _, key := range keys {
+		f, running := g.calls[key]
+		if !running {
+			f = &flight[T]{done: make(chan struct{}), call: c}
+			g.calls[key] = f
+			started[key] = f
+			own = append(own, key)
+		}
+		f.call.waiters++
+		flights[key] = f
+		if g.joined != nil {
+			g.joined(key)
+		}
+	}
+	g.mu.Unlock()
+	if len(own) == 0 {
+		c.cancel()
+	} else {
+		go func() {
+			defer c.cancel()
+			results, errs, err := fn(callCtx, own)
+			g.mu.Lock()
+			for key, f := range started {
+				f.result, f.err = results[key], errs[key]
+				if _, ok := results[key]; !ok && f.err == nil {
+					f.err = err
+				}
+				delete(g.calls, key)
+			}
+			g.mu.Unlock()
+			for _, f := range started {
+				close(f.done)
+			}
+		}()
+	}
+	results := make(map[string]T, len(keys))
+	errs := make(map[string]error)
+	for key, f := range flights {
+		select {
+		case <-f.done:
+			if f.err != nil {
+				errs[key] = f.err
+			} else {
+				results[key] = f.result
+			}
+		case <-ctx.Done():
+			g.leave(f)
+			errs[key] = ctx.Err()
+		}
+	}
+	return results, errs
+}
+
+// detachedContext keeps values of the parent context like request id but is never cancelled
+type detachedContext struct {
+	parent context.Context
+}
+
+func (c detachedContext) Deadline() (time.Time, bool) {
+	return time.Time{}, false
+}
+
+func (c detachedContext) Done() <-chan struct{} {
+	return nil
+}
+
+func (c detachedContext) Err() error {
+	return nil
+}
+
+func (c detachedContext) Value(key any) any {
+	return c.parent.Value(key)
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/rs/zerolog/log"
+	"strings"
+	"sync"
+	"time"
+)
+
+type MarketType string
+
+const (
+	EquityMarket MarketType = "equity"
+	FXMarket     MarketType = "fx"
+	CryptoMarket MarketType = "crypto"
+)
+
+// TradingSession describes when daily session of the market is completed.
+// Session of the day is completed at SessionClose after midnight in Timezone
+type TradingSession struct {
+	Timezone     string
+	SessionClose time.Duration
+	Weekends     bool
+}
+
+// TradingDays knows the last completed trading day and timezone of exchanges with calendars
+type TradingDays interface {
+	LastCompletedDay(micCode string, now time.Time) (time.Time, bool)
+	Location(micCode string) (*time.Location, bool)
+}
+
+// FreshnessPolicy decides whether the latest stored price of the symbol is older than the last completed trading session
+type FreshnessPolicy struct {
+	sessions    map[MarketType]TradingSession
+	tradingDays TradingDays
+	locations   sync.Map
+}
+
+// NewFreshnessPolicy creates FreshnessPolicy. Calendar of the symbol exchange from tradingDays has priority over sessions
+func NewFreshnessPolicy(sessions map[MarketType]TradingSession, tradingDays TradingDays) *FreshnessPolicy {
+	return &FreshnessPolicy{sessions: sessions, tradingDays: tradingDays}
+}
+
+// MarketTypeOf detects market of the symbol by TwelveData instrument type
+func MarketTypeOf(symbol model.Symbol) MarketType {
+	symbolType := strings.ToLower(symbol.Type)
+	switch {
+	case strings.Contains(symbolType, "digital currency"):
+		return CryptoMarket
+	case strings.Contains(symbolType, "physical currency") || symbol.CurrencyBase != "":
+		return FXMarket
+	default:
+		return EquityMarket
+	}
+}
+
+// LastCompletedSession returns the day of the last trading session of the symbol completed before now.
+// Trading calendar of the symbol exchange is used if it is known. Otherwise, exchange timezone of the symbol
+// has priority over the configured market timezone
+func (p *FreshnessPolicy) LastCompletedSession(symbol model.Symbol, now time.Time) time.Time {
+	if p.tradingDays != nil && len(symbol.Exchanges) > 0 && symbol.Exchanges[0].MicCode != "" {
+		if day, ok := p.tradingDays.LastCompletedDay(symbol.Exchanges[0].MicCode, now); ok {
+			return day
+		}
+	}
+	session := p.sessions[MarketTypeOf(symbol)]
+	timezone := session.Timezone
+	if len(symbol.Exchanges) > 0 && symbol.Exchanges[0].Timezone != "" {
+		timezone = symbol.Exchanges[0].Timezone
+	}
+	local := now.In(p.location(timezone))
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, local.Location())
+	for {
+		weekend := day.Weekday() == time.Saturday || day.Weekday() == time.Sunday
+		if (session.Weekends || !weekend) && !day.Add(session.SessionClose).After(local) {
+			return day
+		}
+		day = day.AddDate(0, 0, -1)
+	}
+}
+
+// IsStale reports whether the latest price of the symbol is older than the last completed trading session
+func (p *FreshnessPolicy) IsStale(symbol model.Symbol, now time.Time) bool {
+	if p == nil || len(symbol.Values) == 0 {
+		return false
+	}
+	return symbol.Values[0].Date < p.LastCompletedSession(symbol, now).Format(model.DateLayout)
+}
+
+// Location returns timezone of the symbol exchange. Trading calendar of the exchange has priority over exchange
+// timezone of the symbol and the configured market timezone. UTC is returned for nil policy
+func (p *FreshnessPolicy) Location(symbol model.Symbol) *time.Location {
+	if p == nil {
+		return time.UTC
+	}
+	if p.tradingDays != nil && len(symbol.Exchanges) > 0 && symbol.Exchanges[0].MicCode != "" {
+		if location, ok := p.tradingDays.Location(symbol.Exchanges[0].MicCode); ok {
+			return location
+		}
+	}
+	if len(symbol.Exchanges) > 0 && symbol.Exchanges[0].Timezone != "" {
+		return p.location(symbol.Exchanges[0].Timezone)
+	}
+	return p.location(p.sessions[MarketTypeOf(symbol)].Timezone)
+}
+
+func (p *FreshnessPolicy) location(timezone string) *time.Location {
+	if loc, ok := p.locations.Load(timezone); ok {
+		return loc.(*time.Location)
+	}
+	loc, err := time.LoadLocation(timezone)
+	if err != nil {
+		log.Warn().Err(err).Str("from", "freshnessPolicy").Msgf("Unknown timezone %s. UTC is used instead", timezone)
+		loc = time.UTC
+	}
+	p.locations.Store(timezone, loc)
+	return loc
+}
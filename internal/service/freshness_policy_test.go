@@ -0,0 +1,66 @@
+package service
+
+import (
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var testFreshnessPolicy = NewFreshnessPolicy(map[MarketType]TradingSession{
+	EquityMarket: {Timezone: "America/New_York", SessionClose: 16 * time.Hour},
+	FXMarket:     {Timezone: "America/New_York", SessionClose: 17 * time.Hour},
+	CryptoMarket: {Timezone: "UTC", SessionClose: 24 * time.Hour, Weekends: true},
+}, nil)
+
+func TestMarketTypeOf(t *testing.T) {
+	assert.Equal(t, EquityMarket, MarketTypeOf(model.Symbol{Type: "Common Stock"}))
+	assert.Equal(t, FXMarket, MarketTypeOf(model.Symbol{Type: "Physical Currency", CurrencyBase: "Euro"}))
+	assert.Equal(t, CryptoMarket, MarketTypeOf(model.Symbol{Type: "Digital Currency", CurrencyBase: "Bitcoin"}))
+}
+
+func TestLastCompletedSession(t *testing.T) {
+	for _, td := range lastCompletedSessionTests {
+		t.Run(td.name, func(t *testing.T) {
+			session := testFreshnessPolicy.LastCompletedSession(td.symbol, td.now)
+			assert.Equal(t, td.expected, session.Format(model.DateLayout))
+		})
+	}
+}
+
+var (
+	stock  = model.Symbol{Symbol: "AAPL", Type: "Common Stock"}
+	fx     = model.Symbol{Symbol: "EUR/USD", Type: "Physical Currency", CurrencyBase: "Euro", CurrencyQuote: "US Dollar"}
+	crypto = model.Symbol{Symbol: "BTC/USD", Type: "Digital Currency", CurrencyBase: "Bitcoin", CurrencyQuote: "US Dollar"}
+)
+
+var lastCompletedSessionTests = []struct {
+	name     string
+	symbol   model.Symbol
+	now      time.Time
+	expected string
+}{
+	// 2023-06-07 is Wednesday, 2023-06-10 is Saturday
+	{utils.TestName("equity before close"), stock, time.Date(2023, 6, 7, 15, 0, 0, 0, time.UTC), "2023-06-06"},
+	{utils.TestName("equity after close"), stock, time.Date(2023, 6, 7, 21, 0, 0, 0, time.UTC), "2023-06-07"},
+	{utils.TestName("equity on weekend"), stock, time.Date(2023, 6, 11, 12, 0, 0, 0, time.UTC), "2023-06-09"},
+	{utils.TestName("equity on monday before close"), stock, time.Date(2023, 6, 12, 12, 0, 0, 0, time.UTC), "2023-06-09"},
+	{utils.TestName("equity with exchange timezone"), model.Symbol{Type: "Common Stock", Exchanges: []model.Exchange{{Timezone: "Europe/Berlin"}}}, time.Date(2023, 6, 7, 15, 0, 0, 0, time.UTC), "2023-06-07"},
+	{utils.TestName("fx after close"), fx, time.Date(2023, 6, 7, 22, 0, 0, 0, time.UTC), "2023-06-07"},
+	{utils.TestName("crypto on weekend"), crypto, time.Date(2023, 6, 11, 12, 0, 0, 0, time.UTC), "2023-06-10"},
+}
+
+func TestIsStale(t *testing.T) {
+	now := time.Date(2023, 6, 7, 21, 0, 0, 0, time.UTC)
+	withLatest := func(symbol model.Symbol, date string) model.Symbol {
+		symbol.Values = []model.Price{{Date: date}}
+		return symbol
+	}
+	assert.False(t, testFreshnessPolicy.IsStale(withLatest(stock, "2023-06-07"), now))
+	assert.True(t, testFreshnessPolicy.IsStale(withLatest(stock, "2023-06-06"), now))
+	assert.True(t, testFreshnessPolicy.IsStale(withLatest(crypto, "2023-06-05"), now))
+	assert.False(t, testFreshnessPolicy.IsStale(stock, now))
+	var disabled *FreshnessPolicy
+	assert.False(t, disabled.IsStale(withLatest(stock, "2020-01-01"), now))
+}
@@ -1,26 +1,104 @@
 package service
 
 import (
+	"crypto/rand"
 	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
 	"fmt"
 	"github.com/rs/zerolog/log"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
 )
 
+const (
+	argon2Prefix = "$argon2id$"
+	// legacyPrefix marks salted sha256 hashes of the users created before argon2id. See 000009 migration
+	legacyPrefix   = "$sha256$"
+	argon2Time     = 1
+	argon2Memory   = 64 * 1024
+	argon2Threads  = 4
+	argon2KeyLen   = 32
+	argon2SaltSize = 16
+)
+
+var UnsupportedHash = errors.New("unsupported password hash")
+
+// Hasher hashes passwords with argon2id and verifies argon2id, bcrypt and legacy salted sha256 hashes
 type Hasher struct {
-	salt []byte
+	legacySalt []byte
 }
 
-func NewHasher(salt string) *Hasher {
-	if len(salt) == 0 {
-		log.Panic().Msg("salt for hasher cannot be empty!")
+// NewHasher creates hasher. Salt is only used to verify legacy sha256 hashes
+func NewHasher(legacySalt string) *Hasher {
+	if len(legacySalt) == 0 {
+		log.Warn().Msg("salt for legacy password hashes is empty! Users with such hashes won't be able to sign in")
 	}
-	return &Hasher{salt: []byte(salt)}
+	return &Hasher{legacySalt: []byte(legacySalt)}
 }
 
+// Hash returns argon2id hash of the input with random salt in PHC string format
 func (h *Hasher) Hash(input string) (string, error) {
-	hash := sha256.New()
-	if _, err := hash.Write([]byte(input)); err != nil {
+	salt := make([]byte, argon2SaltSize)
+	if _, err := rand.Read(salt); err != nil {
 		return "", err
 	}
-	return fmt.Sprintf("%x", hash.Sum(h.salt)), nil
+	key := argon2.IDKey([]byte(input), salt, argon2Time, argon2Memory, argon2Threads, argon2KeyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s", argon2Prefix, argon2.Version, argon2Memory, argon2Time, argon2Threads,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+// Verify checks the input against the hash. Rehash is true if the input matches, but the hash isn't argon2id with current parameters
+func (h *Hasher) Verify(input string, hash string) (match bool, rehash bool, err error) {
+	switch {
+	case strings.HasPrefix(hash, argon2Prefix):
+		return h.verifyArgon2(input, hash)
+	case strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$"):
+		err = bcrypt.CompareHashAndPassword([]byte(hash), []byte(input))
+		if err == bcrypt.ErrMismatchedHashAndPassword {
+			return false, false, nil
+		}
+		return err == nil, err == nil, err
+	case strings.HasPrefix(hash, legacyPrefix):
+		if len(h.legacySalt) == 0 {
+			return false, false, UnsupportedHash
+		}
+		digest := sha256.Sum256([]byte(input))
+		expected := fmt.Sprintf("%x%x", h.legacySalt, digest)
+		match = subtle.ConstantTimeCompare([]byte(expected), []byte(strings.TrimPrefix(hash, legacyPrefix))) == 1
+		return match, match, nil
+	}
+	return false, false, UnsupportedHash
+}
+
+func (h *Hasher) verifyArgon2(input string, hash string) (bool, bool, error) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 {
+		return false, false, UnsupportedHash
+	}
+	var version int
+	var memory, time uint32
+	var threads uint8
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return false, false, UnsupportedHash
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &memory, &time, &threads); err != nil {
+		return false, false, UnsupportedHash
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return false, false, UnsupportedHash
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil {
+		return false, false, UnsupportedHash
+	}
+	actual := argon2.IDKey([]byte(input), salt, time, memory, threads, uint32(len(key)))
+	if subtle.ConstantTimeCompare(actual, key) != 1 {
+		return false, false, nil
+	}
+	outdated := memory != argon2Memory || time != argon2Time || threads != argon2Threads || len(key) != argon2KeyLen || len(salt) != argon2SaltSize
+	return true, outdated, nil
 }
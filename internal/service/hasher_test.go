@@ -0,0 +1,98 @@
+package service
+
+import (
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+var testHasher = NewHasher("some_salt")
+
+func TestHash(t *testing.T) {
+	first, err := testHasher.Hash("password")
+	assert.NoError(t, err, "Password should be hashed")
+	assert.True(t, strings.HasPrefix(first, "$argon2id$v=19$m=65536,t=1,p=4$"), "Hash should contain argon2id parameters")
+	second, _ := testHasher.Hash("password")
+	assert.NotEqual(t, first, second, "Hashes of the same password should have different salts")
+}
+
+const (
+	argon2PasswordHash = "$argon2id$v=19$m=65536,t=1,p=4$c29tZXNhbHRzb21lc2FsdA$z0z532WG3Ej2Lcmtcn3WAdfL6IfQYwUi7vPTkoozU40"
+	legacyPasswordHash = "$sha256$736f6d655f73616c745e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8"
+)
+
+func TestVerify(t *testing.T) {
+	for _, td := range verifyTests {
+		t.Run(td.name, func(t *testing.T) {
+			match, rehash, err := testHasher.Verify(td.password, td.hash)
+			assert.Equal(t, td.expectedError, err)
+			assert.Equal(t, td.expectedMatch, match, "Match should be equal")
+			assert.Equal(t, td.expectedRehash, rehash, "Rehash should be equal")
+		})
+	}
+}
+
+var verifyTests = []struct {
+	name           string
+	password       string
+	hash           string
+	expectedMatch  bool
+	expectedRehash bool
+	expectedError  error
+}{
+	{
+		name:          utils.TestName("current argon2id hash"),
+		password:      "password",
+		hash:          argon2PasswordHash,
+		expectedMatch: true,
+	},
+	{
+		name:     utils.TestName("wrong password"),
+		password: "Password",
+		hash:     argon2PasswordHash,
+	},
+	{
+		name:           utils.TestName("argon2id hash with outdated parameters"),
+		password:       "password",
+		hash:           "$argon2id$v=19$m=16384,t=2,p=1$c29tZXNhbHRzb21lc2FsdA$hr6tIZjippRBBcq7etN3TZy+L1awu/PtNMKWpKxlc9Y",
+		expectedMatch:  true,
+		expectedRehash: true,
+	},
+	{
+		name:           utils.TestName("bcrypt hash"),
+		password:       "password",
+		hash:           "$2a$04$iG60MGuglJW7CmfNBqwB1elHio1s0s3IMOvp5HSmIiDXUouSJGSGm",
+		expectedMatch:  true,
+		expectedRehash: true,
+	},
+	{
+		name:     utils.TestName("wrong password of bcrypt hash"),
+		password: "Password",
+		hash:     "$2a$04$iG60MGuglJW7CmfNBqwB1elHio1s0s3IMOvp5HSmIiDXUouSJGSGm",
+	},
+	{
+		name:           utils.TestName("legacy sha256 hash"),
+		password:       "password",
+		hash:           legacyPasswordHash,
+		expectedMatch:  true,
+		expectedRehash: true,
+	},
+	{
+		name:     utils.TestName("wrong password of legacy hash"),
+		password: "Password",
+		hash:     legacyPasswordHash,
+	},
+	{
+		name:          utils.TestName("unmarked hash"),
+		password:      "password",
+		hash:          "5e884898da28047151d0e56f8dc6292773603d0d6aabbdd62a11ef721d1542d8",
+		expectedError: UnsupportedHash,
+	},
+}
+
+func TestDummyHash(t *testing.T) {
+	match, _, err := testHasher.Verify("password", dummyHash)
+	assert.NoError(t, err, "Dummy hash should be valid")
+	assert.False(t, match, "Dummy hash should not match")
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/indicators"
+	"math"
+)
+
+// IndicatorService computes technical indicators from stored prices
+type IndicatorService interface {
+	Get(ctx context.Context, query model.PriceQuery, name string, params indicators.Params) (model.Indicator, error)
+}
+
+type indicatorServiceWithSymbols struct {
+	symbols SymbolService
+}
+
+func NewIndicatorService(symbols SymbolService) IndicatorService {
+	return &indicatorServiceWithSymbols{symbols: symbols}
+}
+
+// Get computes indicator from close prices in the query range warmed up by prices preceding the range.
+// Values start from the first price in the range with enough preceding prices and query.Limit is applied to values,
+// not to prices the indicator is computed from
+func (s *indicatorServiceWithSymbols) Get(ctx context.Context, query model.PriceQuery, name string, params indicators.Params) (model.Indicator, error) {
+	result := model.Indicator{Symbol: query.Symbol, Name: name, Interval: query.Interval.OrDefault(), Params: params, Values: make([]model.IndicatorValue, 0)}
+	if err := params.Validate(name); err != nil {
+		return result, err
+	}
+	limit := query.Limit
+	query.Limit = 0
+	warmUp, err := s.symbols.GetPrices(ctx, warmUpQuery(query, params.Lookback(name)))
+	if err != nil {
+		return result, err
+	}
+	prices, err := s.symbols.GetPrices(ctx, query)
+	if err != nil {
+		return result, err
+	}
+	closes := make([]float64, 0, len(warmUp)+len(prices))
+	for _, price := range append(warmUp, prices...) {
+		closes = append(closes, price.Close.Float64())
+	}
+	lines, err := indicators.Compute(name, closes, params)
+	if err != nil {
+		return result, err
+	}
+	for line, values := range lines {
+		lines[line] = values[len(warmUp):]
+	}
+	for i, price := range prices {
+		value := model.IndicatorValue{Date: price.Date, Lines: make(map[string]float64, len(lines))}
+		for line, values := range lines {
+			if !math.IsNaN(values[i]) {
+				value.Lines[line] = values[i]
+			}
+		}
+		if len(value.Lines) == len(lines) {
+			result.Values = append(result.Values, value)
+		}
+	}
+	if limit > 0 && len(result.Values) > limit {
+		result.Values = result.Values[len(result.Values)-limit:]
+	}
+	return result, nil
+}
+
+// warmUpQuery returns query of lookback prices preceding the query range. Prices of resampled intervals precede
+// the first period of the range, which starts before query.From
+func warmUpQuery(query model.PriceQuery, lookback int) model.PriceQuery {
+	warmUp := model.PriceQuery{Symbol: query.Symbol, Interval: query.Interval, To: query.From.AddDate(0, 0, -1), Limit: lookback}
+	if !query.Interval.IsResampled() {
+		return warmUp
+	}
+	warmUp.To = periodStart(query.From, query.Interval).AddDate(0, 0, -1)
+	switch query.Interval {
+	case model.Interval1Week:
+		warmUp.From = periodStart(warmUp.To, query.Interval).AddDate(0, 0, -7*(lookback-1))
+	case model.Interval1Month:
+		warmUp.From = periodStart(warmUp.To, query.Interval).AddDate(0, -(lookback - 1), 0)
+	case model.Interval1Quarter:
+		warmUp.From = periodStart(warmUp.To, query.Interval).AddDate(0, -3*(lookback-1), 0)
+	}
+	return warmUp
+}
@@ -0,0 +1,78 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/indicators"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestGetIndicator(t *testing.T) {
+	mockSymbols := mock.NewMockSymbolService(gomock.NewController(t))
+	service := NewIndicatorService(mockSymbols)
+	from := time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC)
+	query := model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Day, From: from, To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC), Limit: 2}
+	unlimited := query
+	unlimited.Limit = 0
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Day, To: time.Date(2023, 5, 30, 0, 0, 0, 0, time.UTC), Limit: 2}).Return([]model.Price{
+		{Date: "2023-05-26", Close: "175.43"},
+		{Date: "2023-05-30", Close: "177.3"},
+	}, nil)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), unlimited).Return([]model.Price{
+		{Date: "2023-05-31", Close: "177.25"},
+		{Date: "2023-06-01", Close: "180.09"},
+		{Date: "2023-06-02", Close: "180.95"},
+	}, nil)
+	indicator, err := service.Get(context.TODO(), query, indicators.NameSMA, indicators.Params{Period: 2})
+	assert.NoError(t, err, "Indicator should be computed")
+	assert.Equal(t, "AAPL", indicator.Symbol, "Symbols should be equal")
+	assert.Len(t, indicator.Values, 2, "Values should be limited")
+	assert.Equal(t, "2023-06-01", indicator.Values[0].Date, "The latest values should be returned")
+	assert.InDelta(t, 178.67, indicator.Values[0].Lines[indicators.NameSMA], 1e-9, "SMA should be equal")
+	assert.InDelta(t, 180.52, indicator.Values[1].Lines[indicators.NameSMA], 1e-9, "SMA should be equal")
+
+	_, err = service.Get(context.TODO(), query, indicators.NameSMA, indicators.Params{Period: 1})
+	assert.EqualError(t, err, "'period' must be a number between 2 and 500", "Params should be validated before reading prices")
+}
+
+func TestGetIndicatorWarmUp(t *testing.T) {
+	mockSymbols := mock.NewMockSymbolService(gomock.NewController(t))
+	service := NewIndicatorService(mockSymbols)
+	query := model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Day, From: time.Date(2023, 5, 31, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)}
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), gomock.Any()).Return([]model.Price{
+		{Date: "2023-05-26", Close: "175.43"},
+		{Date: "2023-05-30", Close: "177.3"},
+	}, nil)
+	mockSymbols.EXPECT().GetPrices(gomock.Any(), query).Return([]model.Price{
+		{Date: "2023-05-31", Close: "177.25"},
+		{Date: "2023-06-01", Close: "180.09"},
+	}, nil)
+	indicator, err := service.Get(context.TODO(), query, indicators.NameSMA, indicators.Params{Period: 3})
+	assert.NoError(t, err, "Indicator should be computed")
+	assert.Equal(t, []string{"2023-05-31", "2023-06-01"}, []string{indicator.Values[0].Date, indicator.Values[1].Date}, "Values should start from the range start")
+	assert.InDelta(t, 176.66, indicator.Values[0].Lines[indicators.NameSMA], 1e-9, "SMA should be warmed up by preceding prices")
+}
+
+func TestWarmUpQuery(t *testing.T) {
+	from := time.Date(2023, 5, 17, 0, 0, 0, 0, time.UTC)
+	for _, td := range []struct {
+		name     string
+		interval model.Interval
+		expected model.PriceQuery
+	}{
+		{utils.TestName("daily"), model.Interval1Day, model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Day, To: time.Date(2023, 5, 16, 0, 0, 0, 0, time.UTC), Limit: 3}},
+		{utils.TestName("weekly"), model.Interval1Week, model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Week, From: time.Date(2023, 4, 24, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 5, 14, 0, 0, 0, 0, time.UTC), Limit: 3}},
+		{utils.TestName("monthly"), model.Interval1Month, model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Month, From: time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 4, 30, 0, 0, 0, 0, time.UTC), Limit: 3}},
+		{utils.TestName("quarterly"), model.Interval1Quarter, model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Quarter, From: time.Date(2022, 7, 1, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 3, 31, 0, 0, 0, 0, time.UTC), Limit: 3}},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			query := model.PriceQuery{Symbol: "AAPL", Interval: td.interval, From: from, To: time.Date(2023, 6, 2, 0, 0, 0, 0, time.UTC)}
+			assert.Equal(t, td.expected, warmUpQuery(query, 3), "Warm up queries should be equal")
+		})
+	}
+}
@@ -15,7 +15,7 @@ func NewJwtParser(hmacSecret string) *JwtParser {
 	return &JwtParser{hmacSecret: []byte(hmacSecret)}
 }
 
-func (s *JwtParser) ParseToken(token string) (string, model.Role, error) {
+func (s *JwtParser) ParseToken(token string) (model.Principal, error) {
 	claims := Claims{}
 	t, err := jwt.ParseWithClaims(token, &claims, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
@@ -25,10 +25,10 @@ func (s *JwtParser) ParseToken(token string) (string, model.Role, error) {
 		return s.hmacSecret, nil
 	})
 	if err != nil {
-		return "", model.ClientRole, err
+		return model.Principal{}, err
 	}
 	if !t.Valid || claims.Issuer != issuer {
-		return "", model.ClientRole, errors.New("invalid token")
+		return model.Principal{}, errors.New("invalid token")
 	}
-	return claims.Subject, model.Role(claims.Role), nil
+	return model.Principal{ID: claims.Subject, Roles: claims.Roles, Permissions: claims.Permissions}, nil
 }
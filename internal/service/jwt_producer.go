@@ -12,7 +12,8 @@ import (
 const issuer = "financeapi.io"
 
 type Claims struct {
-	Role string `json:"role"`
+	Roles       []model.Role       `json:"roles"`
+	Permissions []model.Permission `json:"permissions"`
 	jwt.RegisteredClaims
 }
 
@@ -25,11 +26,12 @@ func NewJwtProducer(hmacSecret string, expiryTimeout time.Duration) *JwtProducer
 	return &JwtProducer{hmacSecret: []byte(hmacSecret), expiryTimeout: expiryTimeout}
 }
 
-func (s *JwtProducer) GetToken(ctx context.Context, id string, role model.Role) (string, error) {
+func (s *JwtProducer) GetToken(ctx context.Context, principal model.Principal) (string, error) {
 	claims := &Claims{
-		Role: string(role),
+		Roles:       principal.Roles,
+		Permissions: principal.Permissions,
 		RegisteredClaims: jwt.RegisteredClaims{
-			Subject:   id,
+			Subject:   principal.ID,
 			Issuer:    issuer,
 			NotBefore: jwt.NewNumericDate(time.Now()),
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.expiryTimeout)),
@@ -6,24 +6,55 @@ import (
 )
 
 func timeSeriesToModel(timeSeries apiclient.TimeSeries) model.Symbol {
-	return model.Symbol{
+	symbol := model.Symbol{
 		Symbol:        timeSeries.Meta.Symbol,
 		Type:          timeSeries.Meta.Type,
 		Currency:      timeSeries.Meta.Currency,
 		CurrencyBase:  timeSeries.Meta.CurrencyBase,
 		CurrencyQuote: timeSeries.Meta.CurrencyQuote,
-		Exchanges: []model.Exchange{{
+		Values:        seriesValuesToModel(model.Interval(timeSeries.Meta.Interval), timeSeries.Meta.Provider, timeSeries.Values),
+	}
+	// Exchanges are identified by MIC code, providers without it (e.g. alpha vantage) don't link any
+	if timeSeries.Meta.MicCode != "" {
+		symbol.Exchanges = []model.Exchange{{
 			Name:     timeSeries.Meta.Exchange,
 			Timezone: timeSeries.Meta.ExchangeTimezone,
 			MicCode:  timeSeries.Meta.MicCode,
-		}},
-		Values: []model.Price{{
-			Date:   timeSeries.Values[0].Datetime,
-			Open:   timeSeries.Values[0].Open,
-			Close:  timeSeries.Values[0].Close,
-			High:   timeSeries.Values[0].High,
-			Low:    timeSeries.Values[0].Low,
-			Volume: timeSeries.Values[0].Volume,
-		}},
+		}}
+	}
+	return symbol
+}
+
+func seriesValuesToModel(interval model.Interval, provider string, values []apiclient.SeriesValue) []model.Price {
+	result := make([]model.Price, 0, len(values))
+	for _, value := range values {
+		result = append(result, model.Price{
+			Interval: interval.OrDefault(),
+			Date:     value.Datetime,
+			Open:     value.Open,
+			Close:    value.Close,
+			High:     value.High,
+			Low:      value.Low,
+			Volume:   value.Volume,
+			Provider: provider,
+		})
+	}
+	return result
+}
+
+func searchResultsToModel(results []apiclient.SymbolSearchResult) []model.SymbolMatch {
+	matches := make([]model.SymbolMatch, 0, len(results))
+	for _, result := range results {
+		matches = append(matches, model.SymbolMatch{
+			Symbol:   result.Symbol,
+			Name:     result.InstrumentName,
+			Type:     result.InstrumentType,
+			Currency: result.Currency,
+			Exchange: result.Exchange,
+			MicCode:  result.MicCode,
+			Country:  result.Country,
+			Source:   apiclient.TwelveDataProvider,
+		})
 	}
+	return matches
 }
@@ -0,0 +1,34 @@
+package service
+
+import (
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/apiclient"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestTimeSeriesToModel(t *testing.T) {
+	for _, td := range timeSeriesToModelTests {
+		t.Run(td.name, func(t *testing.T) {
+			symbol := timeSeriesToModel(apiclient.TimeSeries{Meta: td.meta})
+			assert.Equal(t, td.expected, symbol.Exchanges, "Exchanges should be equal")
+		})
+	}
+}
+
+var timeSeriesToModelTests = []struct {
+	name     string
+	meta     apiclient.Meta
+	expected []model.Exchange
+}{
+	{
+		name:     utils.TestName("exchange with mic code"),
+		meta:     apiclient.Meta{Symbol: "AAPL", Exchange: "NASDAQ", MicCode: "XNGS", ExchangeTimezone: "America/New_York", Provider: "twelvedata"},
+		expected: []model.Exchange{{Name: "NASDAQ", MicCode: "XNGS", Timezone: "America/New_York"}},
+	},
+	{
+		name: utils.TestName("alpha vantage metadata without exchange"),
+		meta: apiclient.Meta{Symbol: "IBM", ExchangeTimezone: "America/New_York", Provider: "alphavantage"},
+	},
+}
@@ -0,0 +1,96 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+const resetPasswordSubject = "Password reset"
+
+// ForgotPassword sends single-use reset token to the user with such email in background.
+// Error is nil if there is no such active user and the token is issued after the response, so neither the response
+// nor its time reveal registered emails
+func (s *authService) ForgotPassword(ctx context.Context, email string) error {
+	user, err := s.repo.GetUserByLogin(ctx, email)
+	if err == model.UserNotFound || err == nil && (user.Email != email || user.Disabled) {
+		utils.LogRequest(ctx, log.Info()).Msg("Password reset is requested for unknown or disabled user")
+		return nil
+	} else if err != nil {
+		return err
+	}
+	go s.sendResetToken(detachedContext{ctx}, user)
+	return nil
+}
+
+// sendResetToken stores hash of new reset token of the user and sends the token. Failures are only logged
+func (s *authService) sendResetToken(ctx context.Context, user model.User) {
+	token, err := newResetToken()
+	if err != nil {
+		utils.LogRequest(ctx, log.Error()).Err(err).Msgf("Failed to generate reset token for %s user", user.ID)
+		return
+	}
+	expiresAt := time.Now().Add(s.resetTokenTimeout)
+	err = s.repo.InsertResetToken(ctx, model.ResetToken{UserId: user.ID, TokenHash: hashResetToken(token), ExpiresAt: expiresAt})
+	if err != nil {
+		utils.LogRequest(ctx, log.Error()).Err(err).Msgf("Failed to store reset token of %s user", user.ID)
+		return
+	}
+	body := fmt.Sprintf("Hello, %s!\n\nFollow the link to set a new password: %s?token=%s\nThe link expires at %s.\n"+
+		"If you didn't request password reset, ignore this message.", user.Username, s.resetURL, token, expiresAt.UTC().Format(time.RFC1123))
+	if err = s.notifier.Notify(ctx, user.Email, resetPasswordSubject, body); err != nil {
+		utils.LogRequest(ctx, log.Error()).Err(err).Msgf("Failed to send reset token to %s user", user.ID)
+	}
+}
+
+// ResetPassword replaces password of the token owner and revokes refresh tokens of the user.
+// Error is model.TokenNotFound if the token is unknown or already used, model.TokenExpired if it is expired
+// and model.UserDisabled if the owner is disabled
+func (s *authService) ResetPassword(ctx context.Context, reset model.ResetPassword) error {
+	token, err := s.repo.UseResetToken(ctx, hashResetToken(reset.Token))
+	if err != nil {
+		return err
+	}
+	if token.ExpiresAt.Before(time.Now()) {
+		return model.TokenExpired
+	}
+	user, err := s.repo.GetUserByID(ctx, token.UserId)
+	if err == model.UserNotFound {
+		return model.TokenNotFound
+	} else if err != nil {
+		return err
+	}
+	if user.Disabled {
+		return model.UserDisabled
+	}
+	hash, err := s.hasher.Hash(reset.Password)
+	if err != nil {
+		return err
+	}
+	if err = s.repo.UpdatePassword(ctx, user.ID, hash); err != nil {
+		return err
+	}
+	go s.auditService.LogUserPasswordChanged(ctx, user.ID)
+	return s.repo.DeleteRefreshTokens(ctx, user.ID)
+}
+
+// newResetToken generates 32 random bytes encoded as hex
+func newResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// hashResetToken returns hex encoded sha256 of the token. Only hashes are stored in the database
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/notifier_mock.go -source=../../pkg/service/notifier.go Notifier
+
+func TestForgotPassword(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockNotifier := mock.NewMockNotifier(controller)
+	service := NewAuthService(mockRepo, testHasher, nil, time.Hour, mock.NewMockAuditService(controller), mockNotifier, time.Hour, "http://test.com/reset")
+
+	var storedHash string
+	sent := make(chan struct{})
+	mockRepo.EXPECT().GetUserByLogin(gomock.Any(), "user@test.com").Return(model.User{ID: "1", Username: "user", Email: "user@test.com"}, nil)
+	mockRepo.EXPECT().InsertResetToken(gomock.Any(), gomock.Any()).Do(func(_ context.Context, token model.ResetToken) {
+		assert.Equal(t, "1", token.UserId, "Token should be issued to the user")
+		assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Minute, "Token should expire after timeout")
+		storedHash = token.TokenHash
+	}).Return(nil)
+	mockNotifier.EXPECT().Notify(gomock.Any(), "user@test.com", resetPasswordSubject, gomock.Any()).Do(func(_ context.Context, _, _, body string) {
+		token := regexp.MustCompile(`http://test\.com/reset\?token=([0-9a-f]{64})`).FindStringSubmatch(body)
+		assert.Len(t, token, 2, "Message should contain reset link")
+		assert.Equal(t, storedHash, hashResetToken(token[1]), "Only hash of the sent token should be stored")
+		close(sent)
+	}).Return(nil)
+	assert.NoError(t, service.ForgotPassword(context.TODO(), "user@test.com"), "Reset token should be sent")
+	<-sent
+
+	mockRepo.EXPECT().GetUserByLogin(gomock.Any(), "unknown@test.com").Return(model.User{}, model.UserNotFound)
+	assert.NoError(t, service.ForgotPassword(context.TODO(), "unknown@test.com"), "Unknown email should not be revealed")
+
+	mockRepo.EXPECT().GetUserByLogin(gomock.Any(), "disabled@test.com").Return(model.User{ID: "2", Email: "disabled@test.com", Disabled: true}, nil)
+	assert.NoError(t, service.ForgotPassword(context.TODO(), "disabled@test.com"), "Disabled user should not get reset token")
+}
+
+func TestResetPassword(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewAuthService(mockRepo, testHasher, nil, time.Hour, mockAudit, mock.NewMockNotifier(controller), time.Hour, "")
+	mockAudit.EXPECT().LogUserPasswordChanged(gomock.Any(), "1").AnyTimes()
+	token := strings.Repeat("ab", 32)
+
+	gomock.InOrder(
+		mockRepo.EXPECT().UseResetToken(gomock.Any(), hashResetToken(token)).Return(model.ResetToken{UserId: "1", ExpiresAt: time.Now().Add(time.Minute)}, nil),
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1", Password: argon2PasswordHash}, nil),
+		mockRepo.EXPECT().UpdatePassword(gomock.Any(), "1", gomock.Any()).Do(func(_ context.Context, _ string, hash string) {
+			match, _, _ := testHasher.Verify("new_password", hash)
+			assert.True(t, match, "New password should be set")
+		}).Return(nil),
+		mockRepo.EXPECT().DeleteRefreshTokens(gomock.Any(), "1").Return(nil),
+	)
+	assert.NoError(t, service.ResetPassword(context.TODO(), model.ResetPassword{Token: token, Password: "new_password"}), "Password should be reset")
+
+	mockRepo.EXPECT().UseResetToken(gomock.Any(), hashResetToken(token)).Return(model.ResetToken{}, model.TokenNotFound)
+	err := service.ResetPassword(context.TODO(), model.ResetPassword{Token: token, Password: "new_password"})
+	assert.Equal(t, model.TokenNotFound, err, "Used token should not be accepted")
+
+	mockRepo.EXPECT().UseResetToken(gomock.Any(), hashResetToken(token)).Return(model.ResetToken{UserId: "1", ExpiresAt: time.Now().Add(-time.Minute)}, nil)
+	err = service.ResetPassword(context.TODO(), model.ResetPassword{Token: token, Password: "new_password"})
+	assert.Equal(t, model.TokenExpired, err, "Expired token should not be accepted")
+
+	mockRepo.EXPECT().UseResetToken(gomock.Any(), hashResetToken(token)).Return(model.ResetToken{UserId: "2", ExpiresAt: time.Now().Add(time.Minute)}, nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "2").Return(model.User{ID: "2", Password: argon2PasswordHash, Disabled: true}, nil)
+	err = service.ResetPassword(context.TODO(), model.ResetPassword{Token: token, Password: "new_password"})
+	assert.Equal(t, model.UserDisabled, err, "Password of disabled user should not be reset")
+}
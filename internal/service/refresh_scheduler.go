@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"github.com/GalushkoArt/simpleCache"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/repository"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+// RefreshScheduler periodically loads new daily prices for every stored symbol
+type RefreshScheduler struct {
+	repo    repository.SymbolRepository
+	service SymbolService
+	cache   simpleCache.GenericCache[model.Symbol]
+	period  time.Duration
+	cancel  context.CancelFunc
+	done    chan struct{}
+}
+
+var schedulerLog zerolog.Logger
+
+func NewRefreshScheduler(repo repository.SymbolRepository, service SymbolService, cache simpleCache.GenericCache[model.Symbol], period time.Duration) *RefreshScheduler {
+	schedulerLog = log.With().Str("from", "refreshScheduler").Logger()
+	return &RefreshScheduler{repo: repo, service: service, cache: cache, period: period}
+}
+
+// Start runs refresh immediately and then every period until Stop is called
+func (s *RefreshScheduler) Start() {
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+	go func() {
+		defer close(s.done)
+		ticker := time.NewTicker(s.period)
+		defer ticker.Stop()
+		for {
+			s.refreshAll(ctx)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	schedulerLog.Info().Msgf("Refresh scheduler started with %s period", s.period)
+}
+
+// Stop cancels running refresh and waits for it to finish
+func (s *RefreshScheduler) Stop() {
+	if s == nil || s.cancel == nil {
+		return
+	}
+	s.cancel()
+	<-s.done
+	schedulerLog.Info().Msg("Refresh scheduler stopped")
+}
+
+func (s *RefreshScheduler) refreshAll(ctx context.Context) {
+	latestDates, err := s.repo.GetLatestDates(ctx, model.Interval1Day)
+	if err != nil {
+		schedulerLog.Error().Err(err).Msg("Couldn't get symbols to refresh!")
+		return
+	}
+	schedulerLog.Info().Msgf("Refreshing %d symbols", len(latestDates))
+	refreshed := 0
+	for name, since := range latestDates {
+		if ctx.Err() != nil {
+			schedulerLog.Info().Msg("Refresh cancelled")
+			return
+		}
+		stored, err := s.service.Refresh(ctx, name, since)
+		if err != nil {
+			schedulerLog.Warn().Err(err).Msgf("Failed to refresh %s symbol", name)
+			continue
+		}
+		if stored > 0 {
+			s.cache.Delete(name)
+			refreshed++
+		}
+	}
+	schedulerLog.Info().Msgf("Refreshed %d symbols", refreshed)
+}
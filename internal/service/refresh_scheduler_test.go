@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/golang/mock/gomock"
+	"testing"
+	"time"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/symbol_repository_mock.go -source=../repository/symbol_repository.go SymbolRepository
+
+func TestRefreshAll(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockService := mock.NewMockSymbolService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	scheduler := NewRefreshScheduler(mockRepo, mockService, mockCache, time.Hour)
+	latest := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	mockRepo.EXPECT().GetLatestDates(gomock.Any(), model.Interval1Day).Return(map[string]time.Time{
+		"AAPL":    latest,
+		"EUR/USD": latest,
+		"MSFT":    latest,
+		"NEW":     {},
+	}, nil)
+	mockService.EXPECT().Refresh(gomock.Any(), "AAPL", latest).Return(2, nil)
+	mockService.EXPECT().Refresh(gomock.Any(), "EUR/USD", latest).Return(0, nil)
+	mockService.EXPECT().Refresh(gomock.Any(), "MSFT", latest).Return(0, errors.New("api error"))
+	mockService.EXPECT().Refresh(gomock.Any(), "NEW", time.Time{}).Return(30, nil)
+	mockCache.EXPECT().Delete("AAPL").Return(nil)
+	mockCache.EXPECT().Delete("NEW").Return(nil)
+	scheduler.refreshAll(context.Background())
+}
+
+func TestRefreshAllCancelled(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockService := mock.NewMockSymbolService(controller)
+	mockCache := mock.NewMockGenericCache[model.Symbol](controller)
+	scheduler := NewRefreshScheduler(mockRepo, mockService, mockCache, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	mockRepo.EXPECT().GetLatestDates(gomock.Any(), model.Interval1Day).Return(map[string]time.Time{"AAPL": {}}, nil)
+	scheduler.refreshAll(ctx)
+}
+
+func TestRefreshSchedulerStop(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockRepo.EXPECT().GetLatestDates(gomock.Any(), model.Interval1Day).Return(map[string]time.Time{}, nil).MinTimes(1)
+	scheduler := NewRefreshScheduler(mockRepo, mock.NewMockSymbolService(controller), mock.NewMockGenericCache[model.Symbol](controller), time.Hour)
+	scheduler.Start()
+	stopped := make(chan struct{})
+	go func() {
+		scheduler.Stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(5 * time.Second):
+		t.Fatal("scheduler didn't stop in time")
+	}
+}
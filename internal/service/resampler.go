@@ -0,0 +1,62 @@
+package service
+
+import (
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"time"
+)
+
+// periodStart returns the first day of the week, month or quarter the day belongs to. Weeks start on Monday
+func periodStart(day time.Time, interval model.Interval) time.Time {
+	switch interval {
+	case model.Interval1Week:
+		return time.Date(day.Year(), day.Month(), day.Day()-(int(day.Weekday())+6)%7, 0, 0, 0, 0, day.Location())
+	case model.Interval1Month:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, day.Location())
+	case model.Interval1Quarter:
+		return time.Date(day.Year(), day.Month()-(day.Month()-1)%3, 1, 0, 0, 0, 0, day.Location())
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, day.Location())
+}
+
+// resample aggregates daily prices ordered by date into prices of the interval dated by the first day of the period.
+// Dates of daily prices are trading days in location of the exchange, so periods follow the exchange calendar
+func resample(daily []model.Price, interval model.Interval, location *time.Location) ([]model.Price, error) {
+	result := make([]model.Price, 0)
+	var current *model.Price
+	for _, price := range daily {
+		day, err := time.ParseInLocation(model.DateLayout, price.Date, location)
+		if err != nil {
+			return nil, fmt.Errorf("cannot resample price of %s: %w", price.Date, err)
+		}
+		start := periodStart(day, interval).Format(model.DateLayout)
+		if current == nil || current.Date != start {
+			result = append(result, model.Price{
+				Interval: interval,
+				Date:     start,
+				Open:     price.Open,
+				High:     price.High,
+				Low:      price.Low,
+				Close:    price.Close,
+				Volume:   price.Volume,
+				Provider: price.Provider,
+			})
+			current = &result[len(result)-1]
+			continue
+		}
+		if price.High.Cmp(current.High) > 0 {
+			current.High = price.High
+		}
+		if price.Low.Cmp(current.Low) < 0 {
+			current.Low = price.Low
+		}
+		current.Close = price.Close
+		if !price.Volume.IsEmpty() {
+			current.Volume = current.Volume.Add(price.Volume)
+		}
+		if current.Provider != price.Provider {
+			current.Provider = ""
+		}
+	}
+	return result, nil
+}
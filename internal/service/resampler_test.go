@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var dailyPrices = []model.Price{
+	{Interval: model.Interval1Day, Date: "2023-03-30", Open: "100", High: "104", Low: "99", Close: "103", Volume: "1000", Provider: "twelvedata"},
+	{Interval: model.Interval1Day, Date: "2023-03-31", Open: "103", High: "103.5", Low: "101", Close: "102", Volume: "1500", Provider: "twelvedata"},
+	{Interval: model.Interval1Day, Date: "2023-04-03", Open: "102", High: "106.25", Low: "101.75", Close: "106", Volume: "2000.5", Provider: "twelvedata"},
+	{Interval: model.Interval1Day, Date: "2023-04-04", Open: "106", High: "106", Low: "98", Close: "99", Provider: "alphavantage"},
+	{Interval: model.Interval1Day, Date: "2023-04-10", Open: "99", High: "100", Low: "97", Close: "98", Volume: "500", Provider: "twelvedata"},
+}
+
+func TestResample(t *testing.T) {
+	for _, td := range resampleTests {
+		t.Run(td.name, func(t *testing.T) {
+			resampled, err := resample(dailyPrices, td.interval, time.UTC)
+			assert.NoError(t, err, "Prices should be resampled")
+			assert.Equal(t, td.expected, resampled, "Prices should be equal")
+		})
+	}
+}
+
+var resampleTests = []struct {
+	name     string
+	interval model.Interval
+	expected []model.Price
+}{
+	{
+		name:     utils.TestName("weekly prices start on monday"),
+		interval: model.Interval1Week,
+		expected: []model.Price{
+			{Interval: model.Interval1Week, Date: "2023-03-27", Open: "100", High: "104", Low: "99", Close: "102", Volume: "2500", Provider: "twelvedata"},
+			{Interval: model.Interval1Week, Date: "2023-04-03", Open: "102", High: "106.25", Low: "98", Close: "99", Volume: "2000.5"},
+			{Interval: model.Interval1Week, Date: "2023-04-10", Open: "99", High: "100", Low: "97", Close: "98", Volume: "500", Provider: "twelvedata"},
+		},
+	},
+	{
+		name:     utils.TestName("monthly prices"),
+		interval: model.Interval1Month,
+		expected: []model.Price{
+			{Interval: model.Interval1Month, Date: "2023-03-01", Open: "100", High: "104", Low: "99", Close: "102", Volume: "2500", Provider: "twelvedata"},
+			{Interval: model.Interval1Month, Date: "2023-04-01", Open: "102", High: "106.25", Low: "97", Close: "98", Volume: "2500.5"},
+		},
+	},
+	{
+		name:     utils.TestName("quarterly prices"),
+		interval: model.Interval1Quarter,
+		expected: []model.Price{
+			{Interval: model.Interval1Quarter, Date: "2023-01-01", Open: "100", High: "104", Low: "99", Close: "102", Volume: "2500", Provider: "twelvedata"},
+			{Interval: model.Interval1Quarter, Date: "2023-04-01", Open: "102", High: "106.25", Low: "97", Close: "98", Volume: "2500.5"},
+		},
+	},
+}
+
+func TestGetResampledPrices(t *testing.T) {
+	mockRepo := mock.NewMockSymbolRepository(gomock.NewController(t))
+	service := NewSymbolService(mockRepo, nil, nil, nil, 30, 0, nil)
+	from := time.Date(2023, 3, 30, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2023, 4, 12, 0, 0, 0, 0, time.UTC)
+	weeklyQuery := model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Week, From: from, To: to, Limit: 2}
+	mockRepo.EXPECT().GetPrices(gomock.Any(), weeklyQuery).Return([]model.Price{}, nil)
+	mockRepo.EXPECT().GetPrices(gomock.Any(), model.PriceQuery{
+		Symbol:   "AAPL",
+		Interval: model.Interval1Day,
+		From:     time.Date(2023, 3, 27, 0, 0, 0, 0, time.UTC),
+		To:       to,
+	}).Return(dailyPrices, nil)
+	prices, err := service.GetPrices(context.TODO(), weeklyQuery)
+	assert.NoError(t, err, "Prices should be returned")
+	assert.Len(t, prices, 2, "Prices should be limited")
+	assert.Equal(t, []string{"2023-04-03", "2023-04-10"}, []string{prices[0].Date, prices[1].Date}, "The latest weeks should be returned")
+}
+
+func TestGetStoredWeeklyPrices(t *testing.T) {
+	mockRepo := mock.NewMockSymbolRepository(gomock.NewController(t))
+	service := NewSymbolService(mockRepo, nil, nil, nil, 30, 0, testFreshnessPolicy)
+	query := model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Week, From: time.Date(2023, 3, 30, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 4, 12, 0, 0, 0, 0, time.UTC)}
+	stored := []model.Price{{Interval: model.Interval1Week, Date: "2023-04-03", Close: "99"}}
+	mockRepo.EXPECT().GetPrices(gomock.Any(), query).Return(stored, nil)
+	prices, err := service.GetPrices(context.TODO(), query)
+	assert.NoError(t, err, "Prices should be returned")
+	assert.Equal(t, stored, prices, "Stored weekly prices should be returned")
+}
+
+func TestGetResampledPricesInExchangeTimezone(t *testing.T) {
+	mockRepo := mock.NewMockSymbolRepository(gomock.NewController(t))
+	service := NewSymbolService(mockRepo, nil, nil, nil, 30, 0, testFreshnessPolicy)
+	query := model.PriceQuery{Symbol: "7203", Interval: model.Interval1Week, From: time.Date(2023, 4, 3, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 4, 12, 0, 0, 0, 0, time.UTC)}
+	mockRepo.EXPECT().GetPrices(gomock.Any(), query).Return(nil, nil)
+	mockRepo.EXPECT().GetBySymbol(gomock.Any(), "7203").Return(model.Symbol{Symbol: "7203", Exchanges: []model.Exchange{{MicCode: "XJPX", Timezone: "Asia/Tokyo"}}}, nil)
+	mockRepo.EXPECT().GetPrices(gomock.Any(), gomock.Any()).DoAndReturn(func(_ context.Context, daily model.PriceQuery) ([]model.Price, error) {
+		assert.Equal(t, model.Interval1Day, daily.Interval, "Daily prices should be requested")
+		assert.Equal(t, "Asia/Tokyo", daily.From.Location().String(), "Period should start in exchange timezone")
+		assert.Equal(t, "2023-04-03", daily.From.Format(model.DateLayout), "Week of monday in exchange timezone should start on the same day")
+		return dailyPrices[2:], nil
+	})
+	prices, err := service.GetPrices(context.TODO(), query)
+	assert.NoError(t, err, "Prices should be returned")
+	assert.Equal(t, []string{"2023-04-03", "2023-04-10"}, []string{prices[0].Date, prices[1].Date}, "Weeks should be dated by mondays in exchange timezone")
+}
+
+func TestGetConvertedResampledPrices(t *testing.T) {
+	mockRepo := mock.NewMockSymbolRepository(gomock.NewController(t))
+	service := NewSymbolService(mockRepo, nil, nil, nil, 30, 0, nil)
+	query := model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Month, From: time.Date(2023, 3, 30, 0, 0, 0, 0, time.UTC), To: time.Date(2023, 4, 12, 0, 0, 0, 0, time.UTC)}
+	mockRepo.EXPECT().GetPrices(gomock.Any(), model.PriceQuery{Symbol: "AAPL", Interval: model.Interval1Day, From: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC), To: query.To}).Return(dailyPrices, nil)
+	converted := 0
+	prices, err := service.GetConvertedPrices(context.TODO(), query, func(_ context.Context, prices []model.Price) ([]model.Price, error) {
+		result := make([]model.Price, 0, len(prices))
+		for _, price := range prices {
+			assert.Equal(t, model.Interval1Day, price.Interval, "Daily prices should be converted")
+			rate := model.Decimal("1")
+			if price.Date >= "2023-04-01" {
+				rate = "2"
+			}
+			price.Open, price.High, price.Low, price.Close = price.Open.Mul(rate), price.High.Mul(rate), price.Low.Mul(rate), price.Close.Mul(rate)
+			result = append(result, price)
+			converted++
+		}
+		return result, nil
+	})
+	assert.NoError(t, err, "Prices should be returned")
+	assert.Equal(t, len(dailyPrices), converted, "Every daily price should be converted")
+	if assert.Len(t, prices, 2, "Prices should be aggregated by months") {
+		assert.Equal(t, 0, prices[0].Close.Cmp("102"), "March should be converted with its rates")
+		assert.Equal(t, 0, prices[1].Open.Cmp("204"), "April should be converted with its own rate, not the rate of the period start")
+		assert.Equal(t, 0, prices[1].Low.Cmp("194"), "Low should be aggregated from converted prices")
+	}
+}
@@ -2,42 +2,69 @@ package service
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/internal/repository"
-	"github.com/galushkoart/finance-api/pkg/conpool"
+	"github.com/galushkoart/finance-api/pkg/apiclient"
+	"github.com/galushkoart/finance-api/pkg/provider"
 	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
+	"sync"
+	"time"
 )
 
 type SymbolService interface {
 	Add(ctx context.Context, symbol model.Symbol) error
 	GetBySymbol(ctx context.Context, name string) (model.Symbol, error)
-	GetAll(ctx context.Context) ([]model.Symbol, error)
+	GetAll(ctx context.Context, query model.SymbolQuery) (model.SymbolPage, error)
+	GetQuotes(ctx context.Context, names []string) ([]model.Quote, error)
+	Search(ctx context.Context, query string, limit int) ([]model.SymbolMatch, error)
+	GetPrices(ctx context.Context, query model.PriceQuery) ([]model.Price, error)
+	GetConvertedPrices(ctx context.Context, query model.PriceQuery, convert func(ctx context.Context, prices []model.Price) ([]model.Price, error)) ([]model.Price, error)
+	Backfill(ctx context.Context, name string, interval model.Interval, from time.Time) (model.BackfillResult, error)
+	Refresh(ctx context.Context, name string, since time.Time) (int, error)
 	Update(ctx context.Context, symbol model.UpdateSymbol) error
 	Delete(ctx context.Context, symbolName string) error
 }
 
 type symbolServiceWithRepoAndClient struct {
 	repo         repository.SymbolRepository
-	pool         *conpool.ConnectionPool
+	provider     apiclient.MarketDataProvider
+	searcher     apiclient.SymbolSearcher
 	auditService AuditService
+	historyDepth int
+	freshness    *FreshnessPolicy
+	lookups      flightGroup[model.Symbol]
 }
 
 func ssLog(c context.Context, e *zerolog.Event) *zerolog.Event {
 	return utils.LogRequest(c, e).Str("from", "symbolServiceWithRepoAndClient")
 }
 
-func NewSymbolService(repo repository.SymbolRepository, pool *conpool.ConnectionPool, auditService AuditService) SymbolService {
-	return &symbolServiceWithRepoAndClient{repo: repo, pool: pool, auditService: auditService}
+// NewSymbolService creates SymbolService. historyDepth is the number of prices fetched for the symbol unknown to repo.
+// Stored symbols are refreshed on read if freshness policy is not nil and considers them stale.
+// Search falls back to TwelveData catalogue through searcher if it is not nil.
+// Lookups shared by concurrent requests are cancelled after lookupTimeout if it is positive
+func NewSymbolService(repo repository.SymbolRepository, provider apiclient.MarketDataProvider, searcher apiclient.SymbolSearcher, auditService AuditService, historyDepth int, lookupTimeout time.Duration, freshness *FreshnessPolicy) SymbolService {
+	return &symbolServiceWithRepoAndClient{repo: repo, provider: provider, searcher: searcher, auditService: auditService, historyDepth: historyDepth, freshness: freshness, lookups: flightGroup[model.Symbol]{timeout: lookupTimeout}}
 }
 
+// Add stores the symbol. Error wraps model.InvalidPrice if any of its prices is invalid
 func (s *symbolServiceWithRepoAndClient) Add(ctx context.Context, symbol model.Symbol) error {
+	if err := model.ValidatePrices(symbol.Values); err != nil {
+		return err
+	}
 	go s.auditService.LogSymbolCreated(ctx, symbol.Symbol)
 	return s.repo.Add(ctx, symbol)
 }
 
+// Update changes the symbol. Error wraps model.InvalidPrice if any of new prices is invalid
 func (s *symbolServiceWithRepoAndClient) Update(ctx context.Context, symbol model.UpdateSymbol) error {
+	if err := model.ValidatePrices(symbol.Values); err != nil {
+		return err
+	}
 	go s.auditService.LogSymbolUpdated(ctx, symbol.Symbol)
 	return s.repo.Update(ctx, symbol)
 }
@@ -47,25 +74,378 @@ func (s *symbolServiceWithRepoAndClient) Delete(ctx context.Context, symbolName
 	return s.repo.Delete(ctx, symbolName)
 }
 
+// GetBySymbol coalesces concurrent lookups of the same symbol into a single repository and provider round-trip
 func (s *symbolServiceWithRepoAndClient) GetBySymbol(ctx context.Context, name string) (model.Symbol, error) {
+	return s.lookups.Do(ctx, name, func(ctx context.Context) (model.Symbol, error) {
+		return s.lookup(ctx, name)
+	})
+}
+
+func (s *symbolServiceWithRepoAndClient) lookup(ctx context.Context, name string) (model.Symbol, error) {
 	symbol, err := s.repo.GetBySymbol(ctx, name)
 	if err != nil {
 		ssLog(ctx, log.Warn()).Err(err).Msg("Couldn't fetch data from repo! Trying to get data from api")
-		timeSeries, err := s.pool.GetHistoricDataForSymbol(ctx, name)
+		timeSeries, err := s.fetch(ctx, name, apiclient.TimeSeriesParams{OutputSize: s.historyDepth})
 		if err != nil {
 			ssLog(ctx, log.Error()).Err(err).Interface("response", timeSeries).Msg("Failed to get data from api!")
 			return model.Symbol{}, err
 		}
-		symbol = timeSeriesToModel(*timeSeries)
-		err = s.repo.Add(ctx, symbol)
-		if err != nil {
-			ssLog(ctx, log.Error()).Err(err).Msgf("Couldn't save symbol!")
-			return model.Symbol{}, err
+		if len(timeSeries.Values) == 0 {
+			return model.Symbol{}, model.SymbolNotFound
 		}
+		return s.store(ctx, *timeSeries)
+	} else if s.freshness.IsStale(symbol, time.Now()) {
+		return s.refreshStale(ctx, symbol), nil
+	}
+	return symbol, nil
+}
+
+// store saves the symbol fetched from api and returns it with the latest price only
+func (s *symbolServiceWithRepoAndClient) store(ctx context.Context, timeSeries apiclient.TimeSeries) (model.Symbol, error) {
+	symbol := timeSeriesToModel(timeSeries)
+	if err := s.repo.Add(ctx, symbol); err != nil {
+		ssLog(ctx, log.Error()).Err(err).Msgf("Couldn't save %s symbol!", symbol.Symbol)
+		return model.Symbol{}, err
 	}
+	symbol.Values = symbol.Values[:1]
 	return symbol, nil
 }
 
-func (s *symbolServiceWithRepoAndClient) GetAll(ctx context.Context) ([]model.Symbol, error) {
-	return s.repo.GetAll(ctx)
+// refreshStale loads new prices of the stale symbol. Returns the symbol marked as stale if refresh failed
+func (s *symbolServiceWithRepoAndClient) refreshStale(ctx context.Context, symbol model.Symbol) model.Symbol {
+	ssLog(ctx, log.Info()).Msgf("Latest price of %s from %s is stale. Refreshing", symbol.Symbol, symbol.Values[0].Date)
+	since, err := time.Parse(model.DateLayout, symbol.Values[0].Date)
+	if err != nil {
+		ssLog(ctx, log.Error()).Err(err).Msgf("Invalid date of the latest %s price!", symbol.Symbol)
+		symbol.Stale = true
+		return symbol
+	}
+	stored, err := s.Refresh(ctx, symbol.Symbol, since)
+	if err != nil {
+		ssLog(ctx, log.Warn()).Err(err).Msgf("Failed to refresh stale %s symbol!", symbol.Symbol)
+		symbol.Stale = true
+		return symbol
+	}
+	if stored == 0 {
+		return symbol
+	}
+	refreshed, err := s.repo.GetBySymbol(ctx, symbol.Symbol)
+	if err != nil {
+		ssLog(ctx, log.Warn()).Err(err).Msgf("Couldn't fetch refreshed %s symbol from repo!", symbol.Symbol)
+		symbol.Stale = true
+		return symbol
+	}
+	return refreshed
+}
+
+func (s *symbolServiceWithRepoAndClient) GetAll(ctx context.Context, query model.SymbolQuery) (model.SymbolPage, error) {
+	return s.repo.GetAll(ctx, query)
+}
+
+// Search returns stored symbols matching query. If nothing is stored the provider catalogue is searched.
+// Failure of the provider search isn't an error as it is optional
+func (s *symbolServiceWithRepoAndClient) Search(ctx context.Context, query string, limit int) ([]model.SymbolMatch, error) {
+	found, err := s.repo.Search(ctx, query, limit)
+	if err != nil || len(found) > 0 || s.searcher == nil {
+		return found, err
+	}
+	results, err := s.searcher.SearchSymbols(ctx, query, limit)
+	if err != nil {
+		ssLog(ctx, log.Warn()).Err(err).Msgf("Failed to search %s with provider", query)
+		return found, nil
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return searchResultsToModel(results), nil
+}
+
+// GetQuotes returns the latest data of every symbol in names order. Symbols missing in repo are requested
+// from provider in a single batch and stored, stale symbols are refreshed. Both share in-flight lookups with GetBySymbol.
+// Symbols which couldn't be resolved have error in their quote. Provider error is returned instead if none of the symbols
+// could be resolved because the provider is rate limited or unavailable
+func (s *symbolServiceWithRepoAndClient) GetQuotes(ctx context.Context, names []string) ([]model.Quote, error) {
+	stored, err := s.repo.GetBySymbols(ctx, names)
+	if err != nil {
+		ssLog(ctx, log.Error()).Err(err).Msg("Couldn't fetch symbols from repo!")
+		return nil, err
+	}
+	quotes := make(map[string]model.Quote, len(names))
+	stale := make([]string, 0)
+	now := time.Now()
+	for i := range stored {
+		symbol := stored[i]
+		if s.freshness.IsStale(symbol, now) {
+			stale = append(stale, symbol.Symbol)
+			continue
+		}
+		quotes[symbol.Symbol] = model.Quote{Symbol: symbol.Symbol, Data: &symbol}
+	}
+	for name, quote := range s.refreshQuotes(ctx, stale) {
+		quotes[name] = quote
+	}
+	missing := make([]string, 0)
+	for _, name := range names {
+		if _, ok := quotes[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) > 0 {
+		symbols, errs := s.lookups.DoAll(ctx, missing, s.lookupBatch)
+		var unavailable error
+		unavailableCount := 0
+		for _, name := range missing {
+			if err, failed := errs[name]; failed {
+				quotes[name] = model.Quote{Symbol: name, Error: quoteError(name, err)}
+				if isUnavailable(err) {
+					unavailable = err
+					unavailableCount++
+				}
+			} else {
+				symbol := symbols[name]
+				quotes[name] = model.Quote{Symbol: name, Data: &symbol}
+			}
+		}
+		if unavailableCount == len(names) {
+			return nil, unavailable
+		}
+	}
+	result := make([]model.Quote, 0, len(names))
+	for _, name := range names {
+		result = append(result, quotes[name])
+	}
+	return result, nil
+}
+
+// refreshQuotes refreshes stale symbols concurrently through GetBySymbol
+func (s *symbolServiceWithRepoAndClient) refreshQuotes(ctx context.Context, names []string) map[string]model.Quote {
+	quotes := make(map[string]model.Quote, len(names))
+	mu := sync.Mutex{}
+	wg := sync.WaitGroup{}
+	wg.Add(len(names))
+	for _, name := range names {
+		name := name
+		go func() {
+			defer wg.Done()
+			symbol, err := s.GetBySymbol(ctx, name)
+			quote := model.Quote{Symbol: name, Data: &symbol}
+			if err != nil {
+				quote = model.Quote{Symbol: name, Error: quoteError(name, err)}
+			}
+			mu.Lock()
+			quotes[name] = quote
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	return quotes
+}
+
+// lookupBatch requests symbols still missing in repo from provider in a single batch and stores them
+func (s *symbolServiceWithRepoAndClient) lookupBatch(ctx context.Context, names []string) (map[string]model.Symbol, map[string]error, error) {
+	stored, err := s.repo.GetBySymbols(ctx, names)
+	if err != nil {
+		ssLog(ctx, log.Error()).Err(err).Msg("Couldn't fetch symbols from repo!")
+		return nil, nil, err
+	}
+	symbols := make(map[string]model.Symbol, len(names))
+	errs := make(map[string]error)
+	for _, symbol := range stored {
+		symbols[symbol.Symbol] = symbol
+	}
+	missing := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := symbols[name]; !ok {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return symbols, errs, nil
+	}
+	ssLog(ctx, log.Debug()).Msgf("Requesting %d missing symbols from api", len(missing))
+	results, err := s.provider.GetHistoricDataForSymbols(ctx, missing, apiclient.TimeSeriesParams{OutputSize: s.historyDepth})
+	if err != nil {
+		ssLog(ctx, log.Error()).Err(err).Msg("Failed to get batch data from api!")
+	}
+	for _, name := range missing {
+		result, ok := results[name]
+		if !ok {
+			result = apiclient.SeriesResult{Err: err}
+		}
+		if result.Err == nil && (result.TimeSeries == nil || len(result.TimeSeries.Values) == 0) {
+			result.Err = model.SymbolNotFound
+		}
+		if result.Err != nil {
+			errs[name] = result.Err
+			continue
+		}
+		if result.TimeSeries.Meta.Provider == "" {
+			result.TimeSeries.Meta.Provider = s.provider.Name()
+		}
+		symbol, storeErr := s.store(ctx, *result.TimeSeries)
+		if storeErr != nil {
+			errs[name] = storeErr
+			continue
+		}
+		symbols[name] = symbol
+	}
+	return symbols, errs, nil
+}
+
+// isUnavailable reports whether err means that the provider couldn't be used because of rate limit or unavailability
+func isUnavailable(err error) bool {
+	return apiclient.IsTemporary(err) || errors.Is(err, provider.NoProviderAvailable)
+}
+
+func quoteError(name string, err error) string {
+	switch {
+	case errors.Is(err, model.SymbolNotFound):
+		return fmt.Sprintf("symbol %s not found", name)
+	case apiclient.IsTemporary(err):
+		return "market data provider is busy, retry later"
+	default:
+		return fmt.Sprintf("failed to get %s symbol", name)
+	}
+}
+
+// GetPrices returns stored prices. Prices of model.ResampledIntervals without stored prices in the range are aggregated
+// from daily prices of whole periods in the range, so the first period includes days before query.From.
+// Periods are bucketed in timezone of the symbol exchange
+func (s *symbolServiceWithRepoAndClient) GetPrices(ctx context.Context, query model.PriceQuery) ([]model.Price, error) {
+	if !query.Interval.IsResampled() {
+		return s.repo.GetPrices(ctx, query)
+	}
+	if query.Interval == model.Interval1Week {
+		stored, err := s.repo.GetPrices(ctx, query)
+		if err != nil || len(stored) > 0 {
+			return stored, err
+		}
+	}
+	return s.resampledPrices(ctx, query, nil)
+}
+
+// GetConvertedPrices returns stored prices converted by convert. Prices of model.ResampledIntervals are always aggregated
+// from converted daily prices, so every day is converted on its own
+func (s *symbolServiceWithRepoAndClient) GetConvertedPrices(ctx context.Context, query model.PriceQuery, convert func(ctx context.Context, prices []model.Price) ([]model.Price, error)) ([]model.Price, error) {
+	if query.Interval.IsResampled() {
+		return s.resampledPrices(ctx, query, convert)
+	}
+	prices, err := s.repo.GetPrices(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return convert(ctx, prices)
+}
+
+// resampledPrices aggregates daily prices of whole periods in the range converted by convert unless it is nil
+func (s *symbolServiceWithRepoAndClient) resampledPrices(ctx context.Context, query model.PriceQuery, convert func(ctx context.Context, prices []model.Price) ([]model.Price, error)) ([]model.Price, error) {
+	location := time.UTC
+	if s.freshness != nil {
+		symbol, err := s.repo.GetBySymbol(ctx, query.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		location = s.freshness.Location(symbol)
+	}
+	dailyQuery := query
+	dailyQuery.Interval = model.Interval1Day
+	dailyQuery.From = periodStart(time.Date(query.From.Year(), query.From.Month(), query.From.Day(), 0, 0, 0, 0, location), query.Interval)
+	dailyQuery.Limit = 0
+	daily, err := s.repo.GetPrices(ctx, dailyQuery)
+	if err != nil {
+		return nil, err
+	}
+	if convert != nil {
+		if daily, err = convert(ctx, daily); err != nil {
+			return nil, err
+		}
+	}
+	prices, err := resample(daily, query.Interval, location)
+	if err != nil {
+		ssLog(ctx, log.Error()).Err(err).Msgf("Couldn't resample %s prices to %s!", query.Symbol, query.Interval)
+		return nil, err
+	}
+	if query.Limit > 0 && len(prices) > query.Limit {
+		prices = prices[len(prices)-query.Limit:]
+	}
+	return prices, nil
+}
+
+// Backfill pages api by date range from the latest price backwards until from date is covered and stores received prices
+func (s *symbolServiceWithRepoAndClient) Backfill(ctx context.Context, name string, interval model.Interval, from time.Time) (model.BackfillResult, error) {
+	result := model.BackfillResult{Symbol: name, Interval: interval, From: from.Format(model.DateLayout)}
+	if _, err := s.GetBySymbol(ctx, name); err != nil {
+		return result, err
+	}
+	go s.auditService.LogSymbolUpdated(ctx, name)
+	params := apiclient.TimeSeriesParams{Interval: string(interval), OutputSize: apiclient.MaxOutputSize, StartDate: result.From}
+	for {
+		ssLog(ctx, log.Debug()).Msgf("Backfilling %s %s from %s to %s", name, interval, params.StartDate, params.EndDate)
+		timeSeries, err := s.fetch(ctx, name, params)
+		if err == model.SymbolNotFound {
+			ssLog(ctx, log.Info()).Msgf("No more data for %s before %s", name, params.EndDate)
+			break
+		} else if err != nil {
+			ssLog(ctx, log.Error()).Err(err).Interface("response", timeSeries).Msg("Failed to get data from api!")
+			return result, err
+		}
+		if len(timeSeries.Values) == 0 {
+			break
+		}
+		prices := seriesValuesToModel(interval, timeSeries.Meta.Provider, timeSeries.Values)
+		if err = s.repo.UpsertPrices(ctx, name, prices); err != nil {
+			ssLog(ctx, log.Error()).Err(err).Msgf("Couldn't save prices!")
+			return result, err
+		}
+		result.Prices += len(prices)
+		if len(prices) < apiclient.MaxOutputSize {
+			break
+		}
+		oldest, err := time.Parse(interval.Layout(), prices[len(prices)-1].Date)
+		if err != nil {
+			return result, err
+		}
+		end := oldest.Add(-time.Second)
+		if end.Before(from) {
+			break
+		}
+		params.EndDate = end.Format(interval.Layout())
+	}
+	return result, nil
+}
+
+// Refresh loads daily prices of the symbol starting from since date and stores them.
+// If since is zero the configured history depth is loaded. Returns number of stored prices
+func (s *symbolServiceWithRepoAndClient) Refresh(ctx context.Context, name string, since time.Time) (int, error) {
+	params := apiclient.TimeSeriesParams{OutputSize: s.historyDepth}
+	if !since.IsZero() {
+		params.OutputSize = apiclient.MaxOutputSize
+		params.StartDate = since.Format(model.DateLayout)
+	}
+	timeSeries, err := s.fetch(ctx, name, params)
+	if err == model.SymbolNotFound {
+		ssLog(ctx, log.Debug()).Msgf("No new data for %s since %s", name, params.StartDate)
+		return 0, nil
+	} else if err != nil {
+		ssLog(ctx, log.Error()).Err(err).Interface("response", timeSeries).Msg("Failed to get data from api!")
+		return 0, err
+	}
+	if len(timeSeries.Values) == 0 {
+		return 0, nil
+	}
+	prices := seriesValuesToModel(model.Interval1Day, timeSeries.Meta.Provider, timeSeries.Values)
+	if err = s.repo.UpsertPrices(ctx, name, prices); err != nil {
+		ssLog(ctx, log.Error()).Err(err).Msgf("Couldn't save prices!")
+		return 0, err
+	}
+	return len(prices), nil
+}
+
+// fetch requests time series from provider and records which provider supplied the values
+func (s *symbolServiceWithRepoAndClient) fetch(ctx context.Context, name string, params apiclient.TimeSeriesParams) (*apiclient.TimeSeries, error) {
+	timeSeries, err := s.provider.GetHistoricDataForSymbol(ctx, name, params)
+	if timeSeries != nil && timeSeries.Meta.Provider == "" {
+		timeSeries.Meta.Provider = s.provider.Name()
+	}
+	return timeSeries, err
 }
@@ -0,0 +1,321 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/apiclient"
+	"github.com/galushkoart/finance-api/pkg/provider"
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestGetBySymbolCoalescing(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockProvider := mock.NewMockTwelveDataClient(controller)
+	service := NewSymbolService(mockRepo, mockProvider, nil, nil, 30, 0, nil)
+	const callers = 50
+	entered := sync.WaitGroup{}
+	entered.Add(callers)
+	service.(*symbolServiceWithRepoAndClient).lookups.joined = func(string) { entered.Done() }
+	mockRepo.EXPECT().GetBySymbol(gomock.Any(), "AAPL").DoAndReturn(func(ctx context.Context, name string) (model.Symbol, error) {
+		entered.Wait()
+		return model.Symbol{}, model.SymbolNotFound
+	}).Times(1)
+	mockProvider.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+	mockProvider.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", apiclient.TimeSeriesParams{OutputSize: 30}).Return(&apiclient.TimeSeries{
+		Meta:   apiclient.Meta{Symbol: "AAPL", Interval: "1day"},
+		Values: []apiclient.SeriesValue{{Datetime: "2023-06-02", Close: "180.95"}, {Datetime: "2023-06-01", Close: "180.09"}},
+	}, nil).Times(1)
+	mockRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	results := make(chan model.Symbol, callers)
+	wg := sync.WaitGroup{}
+	wg.Add(callers)
+	for i := 0; i < callers; i++ {
+		go func() {
+			defer wg.Done()
+			symbol, err := service.GetBySymbol(context.TODO(), "AAPL")
+			assert.NoError(t, err, "Lookup should succeed")
+			results <- symbol
+		}()
+	}
+	wg.Wait()
+	close(results)
+	for symbol := range results {
+		assert.Equal(t, []model.Price{{Interval: model.Interval1Day, Date: "2023-06-02", Close: "180.95", Provider: apiclient.TwelveDataProvider}}, symbol.Values, "Callers should share the result")
+	}
+}
+
+func TestGetBySymbolCancelledCaller(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	service := NewSymbolService(mockRepo, nil, nil, nil, 30, 0, nil)
+	joined := make(chan struct{}, 2)
+	service.(*symbolServiceWithRepoAndClient).lookups.joined = func(string) { joined <- struct{}{} }
+	release := make(chan struct{})
+	mockRepo.EXPECT().GetBySymbol(gomock.Any(), "AAPL").DoAndReturn(func(ctx context.Context, name string) (model.Symbol, error) {
+		<-release
+		assert.NoError(t, ctx.Err(), "Shared lookup should not be cancelled by the first caller")
+		return model.Symbol{Symbol: "AAPL"}, nil
+	}).Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := service.GetBySymbol(ctx, "AAPL")
+		first <- err
+	}()
+	<-joined
+	second := make(chan model.Symbol)
+	go func() {
+		symbol, _ := service.GetBySymbol(context.TODO(), "AAPL")
+		second <- symbol
+	}()
+	<-joined
+	cancel()
+	assert.ErrorIs(t, <-first, context.Canceled, "Cancelled caller should stop waiting")
+	close(release)
+	assert.Equal(t, model.Symbol{Symbol: "AAPL"}, <-second, "Waiting caller should get the result")
+}
+
+func TestGetBySymbolLookupCancelledWithLastCaller(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockProvider := mock.NewMockTwelveDataClient(controller)
+	service := NewSymbolService(mockRepo, mockProvider, nil, nil, 30, 0, nil)
+	joined := make(chan struct{}, 2)
+	service.(*symbolServiceWithRepoAndClient).lookups.joined = func(string) { joined <- struct{}{} }
+	lookupErr := make(chan error)
+	mockRepo.EXPECT().GetBySymbol(gomock.Any(), "AAPL").Return(model.Symbol{}, model.SymbolNotFound)
+	mockProvider.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", gomock.Any()).DoAndReturn(func(ctx context.Context, symbol string, params apiclient.TimeSeriesParams) (*apiclient.TimeSeries, error) {
+		<-ctx.Done()
+		lookupErr <- ctx.Err()
+		return nil, ctx.Err()
+	})
+
+	callers := make(chan error, 2)
+	cancels := make([]context.CancelFunc, 2)
+	for i := range cancels {
+		var ctx context.Context
+		ctx, cancels[i] = context.WithCancel(context.Background())
+		go func() {
+			_, err := service.GetBySymbol(ctx, "AAPL")
+			callers <- err
+		}()
+		<-joined
+	}
+	cancels[0]()
+	assert.ErrorIs(t, <-callers, context.Canceled, "Cancelled caller should stop waiting")
+	select {
+	case <-lookupErr:
+		assert.Fail(t, "Lookup should not be cancelled while another caller is waiting")
+	case <-time.After(10 * time.Millisecond):
+	}
+	cancels[1]()
+	assert.ErrorIs(t, <-callers, context.Canceled, "Cancelled caller should stop waiting")
+	assert.ErrorIs(t, <-lookupErr, context.Canceled, "Lookup should be cancelled when the last caller has left")
+}
+
+func TestGetBySymbolLookupTimeout(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockProvider := mock.NewMockTwelveDataClient(controller)
+	service := NewSymbolService(mockRepo, mockProvider, nil, nil, 30, 10*time.Millisecond, nil)
+	mockRepo.EXPECT().GetBySymbol(gomock.Any(), "AAPL").Return(model.Symbol{}, model.SymbolNotFound)
+	mockProvider.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", gomock.Any()).DoAndReturn(func(ctx context.Context, symbol string, params apiclient.TimeSeriesParams) (*apiclient.TimeSeries, error) {
+		_, hasDeadline := ctx.Deadline()
+		assert.True(t, hasDeadline, "Lookup should have a deadline for rate limiter")
+		<-ctx.Done()
+		return nil, ctx.Err()
+	})
+	_, err := service.GetBySymbol(context.TODO(), "AAPL")
+	assert.ErrorIs(t, err, context.DeadlineExceeded, "Lookup should time out even if the caller waits forever")
+}
+
+func TestGetQuotes(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockProvider := mock.NewMockTwelveDataClient(controller)
+	service := NewSymbolService(mockRepo, mockProvider, nil, nil, 30, 0, nil)
+	names := []string{"MSFT", "AAPL", "INVALID", "BUSY"}
+	mockRepo.EXPECT().GetBySymbols(gomock.Any(), names).Return([]model.Symbol{{Symbol: "AAPL"}}, nil)
+	mockRepo.EXPECT().GetBySymbols(gomock.Any(), []string{"MSFT", "INVALID", "BUSY"}).Return(nil, nil)
+	mockProvider.EXPECT().GetHistoricDataForSymbols(gomock.Any(), []string{"MSFT", "INVALID", "BUSY"}, apiclient.TimeSeriesParams{OutputSize: 30}).Return(map[string]apiclient.SeriesResult{
+		"MSFT": {TimeSeries: &apiclient.TimeSeries{
+			Meta:   apiclient.Meta{Symbol: "MSFT", Interval: "1day", Provider: apiclient.AlphaVantageProvider},
+			Values: []apiclient.SeriesValue{{Datetime: "2023-06-02", Close: "335.4"}, {Datetime: "2023-06-01", Close: "332.58"}},
+		}},
+		"INVALID": {Err: model.SymbolNotFound},
+		"BUSY":    {Err: &ratelimit.LimitExceededError{Name: apiclient.TwelveDataProvider}},
+	}, nil)
+	mockRepo.EXPECT().Add(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, symbol model.Symbol) error {
+		assert.Len(t, symbol.Values, 2, "Full history should be stored")
+		return nil
+	})
+	quotes, err := service.GetQuotes(context.TODO(), names)
+	assert.NoError(t, err, "Quotes should be resolved")
+	assert.Equal(t, []model.Quote{
+		{Symbol: "MSFT", Data: &model.Symbol{
+			Symbol: "MSFT",
+			Values: []model.Price{{Interval: model.Interval1Day, Date: "2023-06-02", Close: "335.4", Provider: apiclient.AlphaVantageProvider}},
+		}},
+		{Symbol: "AAPL", Data: &model.Symbol{Symbol: "AAPL"}},
+		{Symbol: "INVALID", Error: "symbol INVALID not found"},
+		{Symbol: "BUSY", Error: "market data provider is busy, retry later"},
+	}, quotes, "Quotes should be equal")
+}
+
+func TestGetQuotesWithoutAvailableProvider(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockProvider := mock.NewMockTwelveDataClient(controller)
+	service := NewSymbolService(mockRepo, mockProvider, nil, nil, 30, 0, nil)
+	names := []string{"MSFT", "AAPL"}
+	mockRepo.EXPECT().GetBySymbols(gomock.Any(), names).Return([]model.Symbol{}, nil).Times(2)
+	mockProvider.EXPECT().GetHistoricDataForSymbols(gomock.Any(), names, gomock.Any()).Return(nil, provider.NoProviderAvailable)
+	quotes, err := service.GetQuotes(context.TODO(), names)
+	assert.ErrorIs(t, err, provider.NoProviderAvailable, "Provider error should be returned if no symbol is resolved")
+	assert.Nil(t, quotes, "No quotes should be returned")
+}
+
+func TestGetQuotesSharesLookups(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockProvider := mock.NewMockTwelveDataClient(controller)
+	service := NewSymbolService(mockRepo, mockProvider, nil, nil, 30, 0, nil)
+	joined := make(chan string, 3)
+	service.(*symbolServiceWithRepoAndClient).lookups.joined = func(key string) { joined <- key }
+	release := make(chan struct{})
+	mockRepo.EXPECT().GetBySymbol(gomock.Any(), "MSFT").DoAndReturn(func(ctx context.Context, name string) (model.Symbol, error) {
+		<-release
+		return model.Symbol{Symbol: "MSFT"}, nil
+	}).Times(1)
+	names := []string{"MSFT", "AAPL"}
+	mockRepo.EXPECT().GetBySymbols(gomock.Any(), names).Return(nil, nil)
+	mockRepo.EXPECT().GetBySymbols(gomock.Any(), []string{"AAPL"}).Return(nil, nil)
+	mockProvider.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+	mockProvider.EXPECT().GetHistoricDataForSymbols(gomock.Any(), []string{"AAPL"}, apiclient.TimeSeriesParams{OutputSize: 30}).Return(map[string]apiclient.SeriesResult{
+		"AAPL": {TimeSeries: &apiclient.TimeSeries{
+			Meta:   apiclient.Meta{Symbol: "AAPL", Interval: "1day"},
+			Values: []apiclient.SeriesValue{{Datetime: "2023-06-02", Close: "180.95"}},
+		}},
+	}, nil).Times(1)
+	mockRepo.EXPECT().Add(gomock.Any(), gomock.Any()).Return(nil).Times(1)
+
+	single := make(chan model.Symbol)
+	go func() {
+		symbol, _ := service.GetBySymbol(context.TODO(), "MSFT")
+		single <- symbol
+	}()
+	assert.Equal(t, "MSFT", <-joined, "Single lookup should start")
+	batch := make(chan []model.Quote)
+	go func() {
+		quotes, _ := service.GetQuotes(context.TODO(), names)
+		batch <- quotes
+	}()
+	<-joined
+	<-joined
+	close(release)
+	assert.Equal(t, model.Symbol{Symbol: "MSFT"}, <-single, "Single lookup should get the result")
+	quotes := <-batch
+	assert.Equal(t, &model.Symbol{Symbol: "MSFT"}, quotes[0].Data, "Batch should share running lookup")
+	assert.Equal(t, "AAPL", quotes[1].Data.Symbol, "Missing symbol should be fetched in batch")
+}
+
+func TestGetQuotesRefreshesStale(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockProvider := mock.NewMockTwelveDataClient(controller)
+	service := NewSymbolService(mockRepo, mockProvider, nil, nil, 30, 0, testFreshnessPolicy)
+	stale := model.Symbol{Symbol: "AAPL", Type: "Common Stock", Values: []model.Price{{Date: "2023-06-01", Close: "180.09"}}}
+	refreshed := model.Symbol{Symbol: "AAPL", Type: "Common Stock", Values: []model.Price{{Date: time.Now().Format(model.DateLayout), Close: "180.95"}}}
+	mockRepo.EXPECT().GetBySymbols(gomock.Any(), []string{"AAPL"}).Return([]model.Symbol{stale}, nil)
+	mockProvider.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+	gomock.InOrder(
+		mockRepo.EXPECT().GetBySymbol(gomock.Any(), "AAPL").Return(stale, nil),
+		mockProvider.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", apiclient.TimeSeriesParams{OutputSize: apiclient.MaxOutputSize, StartDate: "2023-06-01"}).Return(&apiclient.TimeSeries{
+			Values: []apiclient.SeriesValue{{Datetime: refreshed.Values[0].Date, Close: "180.95"}},
+		}, nil),
+		mockRepo.EXPECT().UpsertPrices(gomock.Any(), "AAPL", gomock.Any()).Return(nil),
+		mockRepo.EXPECT().GetBySymbol(gomock.Any(), "AAPL").Return(refreshed, nil),
+	)
+	quotes, err := service.GetQuotes(context.TODO(), []string{"AAPL"})
+	assert.NoError(t, err, "Quotes should be resolved")
+	assert.Equal(t, []model.Quote{{Symbol: "AAPL", Data: &refreshed}}, quotes, "Stale quote should be refreshed")
+}
+
+func TestSearch(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockSymbolRepository(controller)
+	mockSearcher := mock.NewMockTwelveDataClient(controller)
+	service := NewSymbolService(mockRepo, nil, mockSearcher, nil, 30, 0, nil)
+
+	local := []model.SymbolMatch{{Symbol: "AAPL", Name: "Apple Inc", Source: model.SearchSourceLocal, Score: 3}}
+	mockRepo.EXPECT().Search(gomock.Any(), "aapl", 10).Return(local, nil)
+	found, err := service.Search(context.TODO(), "aapl", 10)
+	assert.NoError(t, err, "Search should succeed")
+	assert.Equal(t, local, found, "Stored symbols should be returned without provider search")
+
+	mockRepo.EXPECT().Search(gomock.Any(), "ibm", 1).Return([]model.SymbolMatch{}, nil)
+	mockSearcher.EXPECT().SearchSymbols(gomock.Any(), "ibm", 1).Return([]apiclient.SymbolSearchResult{
+		{Symbol: "IBM", InstrumentName: "International Business Machines Corp", Exchange: "NYSE", MicCode: "XNYS", InstrumentType: "Common Stock", Country: "United States", Currency: "USD"},
+		{Symbol: "IBM", InstrumentName: "International Business Machines Corp", Exchange: "LSE", MicCode: "XLON", InstrumentType: "Common Stock", Country: "United Kingdom", Currency: "GBP"},
+	}, nil)
+	found, err = service.Search(context.TODO(), "ibm", 1)
+	assert.NoError(t, err, "Search should succeed")
+	assert.Equal(t, []model.SymbolMatch{{
+		Symbol: "IBM", Name: "International Business Machines Corp", Type: "Common Stock", Currency: "USD",
+		Exchange: "NYSE", MicCode: "XNYS", Country: "United States", Source: apiclient.TwelveDataProvider,
+	}}, found, "Provider matches should be returned")
+
+	mockRepo.EXPECT().Search(gomock.Any(), "busy", 10).Return([]model.SymbolMatch{}, nil)
+	mockSearcher.EXPECT().SearchSymbols(gomock.Any(), "busy", 10).Return(nil, &ratelimit.LimitExceededError{Name: apiclient.TwelveDataProvider})
+	found, err = service.Search(context.TODO(), "busy", 10)
+	assert.NoError(t, err, "Provider failure should not fail search")
+	assert.Empty(t, found, "Nothing should be found")
+}
+
+func TestAddInvalidPrices(t *testing.T) {
+	service := NewSymbolService(mock.NewMockSymbolRepository(gomock.NewController(t)), nil, nil, nil, 30, 0, nil)
+	for _, td := range invalidPricesTests {
+		t.Run(td.name, func(t *testing.T) {
+			err := service.Add(context.TODO(), model.Symbol{Symbol: "AAPL", Values: []model.Price{td.price}})
+			assert.ErrorIs(t, err, model.InvalidPrice, "Price should be invalid")
+			assert.EqualError(t, err, td.expectedError, "Errors should be equal")
+		})
+	}
+}
+
+var invalidPricesTests = []struct {
+	name          string
+	price         model.Price
+	expectedError string
+}{
+	{
+		name:          utils.TestName("price without close"),
+		price:         model.Price{Date: "2023-06-02", Open: "180.5", High: "181.2", Low: "179.9"},
+		expectedError: "invalid price: 2023-06-02 must have open, high, low and close",
+	},
+	{
+		name:          utils.TestName("low greater than high"),
+		price:         model.Price{Date: "2023-06-02", Open: "180.5", High: "179.9", Low: "181.2", Close: "180.95"},
+		expectedError: "invalid price: 2023-06-02 low 181.2 is greater than high 179.9",
+	},
+	{
+		name:          utils.TestName("close above high"),
+		price:         model.Price{Date: "2023-06-02", Open: "180.5", High: "181.2", Low: "179.9", Close: "181.25"},
+		expectedError: "invalid price: 2023-06-02 open and close must be between low and high",
+	},
+	{
+		name:          utils.TestName("negative volume"),
+		price:         model.Price{Date: "2023-06-02", Open: "180.5", High: "181.2", Low: "179.9", Close: "180.95", Volume: "-100"},
+		expectedError: "invalid price: 2023-06-02 volume -100 is negative",
+	},
+}
@@ -0,0 +1,129 @@
+package service
+
+import (
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"time"
+)
+
+// calendarHorizon is the max number of days searched for the next or the last session
+const calendarHorizon = 31
+
+// tradingCalendar is parsed model.TradingCalendar
+type tradingCalendar struct {
+	source     model.TradingCalendar
+	location   *time.Location
+	open       time.Duration
+	close      time.Duration
+	earlyClose time.Duration
+	holidays   map[string]bool
+	halfDays   map[string]bool
+}
+
+func newTradingCalendar(calendar model.TradingCalendar) (*tradingCalendar, error) {
+	if calendar.MicCode == "" {
+		return nil, fmt.Errorf("%w: mic code is empty", model.InvalidCalendar)
+	}
+	location, err := time.LoadLocation(calendar.Timezone)
+	if err != nil || calendar.Timezone == "" {
+		return nil, fmt.Errorf("%w: unknown timezone %s", model.InvalidCalendar, calendar.Timezone)
+	}
+	result := &tradingCalendar{source: calendar, location: location}
+	if result.open, err = parseClock(calendar.Open); err != nil {
+		return nil, err
+	}
+	if result.close, err = parseClock(calendar.Close); err != nil {
+		return nil, err
+	}
+	if result.open >= result.close {
+		return nil, fmt.Errorf("%w: open %s must be before close %s", model.InvalidCalendar, calendar.Open, calendar.Close)
+	}
+	result.earlyClose = result.close
+	if calendar.EarlyClose != "" {
+		if result.earlyClose, err = parseClock(calendar.EarlyClose); err != nil {
+			return nil, err
+		}
+		if result.earlyClose <= result.open || result.earlyClose > result.close {
+			return nil, fmt.Errorf("%w: early close %s must be between open and close", model.InvalidCalendar, calendar.EarlyClose)
+		}
+	}
+	if result.holidays, err = parseDays(calendar.Holidays); err != nil {
+		return nil, err
+	}
+	if result.halfDays, err = parseDays(calendar.HalfDays); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func parseDays(days []string) (map[string]bool, error) {
+	result := make(map[string]bool, len(days))
+	for _, day := range days {
+		if _, err := time.Parse(model.DateLayout, day); err != nil {
+			return nil, fmt.Errorf("%w: %s must be a date in YYYY-MM-DD format", model.InvalidCalendar, day)
+		}
+		result[day] = true
+	}
+	return result, nil
+}
+
+// parseClock parses HH:MM time of the day into duration since midnight
+func parseClock(clock string) (time.Duration, error) {
+	parsed, err := time.Parse("15:04", clock)
+	if err != nil {
+		return 0, fmt.Errorf("%w: %s must be a time in HH:MM format", model.InvalidCalendar, clock)
+	}
+	return time.Duration(parsed.Hour())*time.Hour + time.Duration(parsed.Minute())*time.Minute, nil
+}
+
+// session returns open and close of the trading session on the day. ok is false if exchange doesn't trade on the day
+func (c *tradingCalendar) session(day time.Time) (open time.Time, close time.Time, ok bool) {
+	date := day.Format(model.DateLayout)
+	if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday || c.holidays[date] {
+		return open, close, false
+	}
+	closeAt := c.close
+	if c.halfDays[date] {
+		closeAt = c.earlyClose
+	}
+	return c.at(day, c.open), c.at(day, closeAt), true
+}
+
+// at returns the time of the day in exchange timezone. Day is built with time.Date to respect DST changes
+func (c *tradingCalendar) at(day time.Time, clock time.Duration) time.Time {
+	return time.Date(day.Year(), day.Month(), day.Day(), 0, int(clock/time.Minute), 0, 0, c.location)
+}
+
+func (c *tradingCalendar) status(now time.Time) model.MarketStatus {
+	local := now.In(c.location)
+	result := model.MarketStatus{MicCode: c.source.MicCode, Timezone: c.source.Timezone}
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.location)
+	for i := 0; i < calendarHorizon; i, day = i+1, day.AddDate(0, 0, 1) {
+		open, close, ok := c.session(day)
+		if !ok || !close.After(local) {
+			continue
+		}
+		if open.After(local) {
+			if result.IsOpen {
+				result.NextOpen = open
+				return result
+			}
+			result.NextOpen, result.NextClose = open, close
+			return result
+		}
+		result.IsOpen, result.NextClose = true, close
+	}
+	return result
+}
+
+// lastCompletedDay returns the day of the last session closed before now in exchange timezone
+func (c *tradingCalendar) lastCompletedDay(now time.Time) (time.Time, bool) {
+	local := now.In(c.location)
+	day := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, c.location)
+	for i := 0; i < calendarHorizon; i, day = i+1, day.AddDate(0, 0, -1) {
+		if _, close, ok := c.session(day); ok && !close.After(local) {
+			return day, true
+		}
+	}
+	return time.Time{}, false
+}
@@ -0,0 +1,116 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/calendar_repository_mock.go -source=../repository/calendar_repository.go CalendarRepository
+
+var testCalendar = model.TradingCalendar{
+	MicCode:    "XNYS",
+	Timezone:   "America/New_York",
+	Open:       "09:30",
+	Close:      "16:00",
+	EarlyClose: "13:00",
+	Holidays:   []string{"2023-07-04"},
+	HalfDays:   []string{"2023-07-03"},
+}
+
+func newTestCalendarService(t *testing.T) CalendarService {
+	mockRepo := mock.NewMockCalendarRepository(gomock.NewController(t))
+	mockRepo.EXPECT().GetAll(gomock.Any()).Return(nil, nil)
+	calendars, err := NewCalendarService(context.TODO(), mockRepo, []model.TradingCalendar{testCalendar})
+	utils.PanicOnError(err)
+	return calendars
+}
+
+func TestMarketStatus(t *testing.T) {
+	calendars := newTestCalendarService(t)
+	newYork, _ := time.LoadLocation("America/New_York")
+	at := func(day int, hour int, minute int) time.Time {
+		return time.Date(2023, 7, day, hour, minute, 0, 0, newYork)
+	}
+	// 2023-06-30 is Friday, 2023-07-03 is a half-day and 2023-07-04 is a holiday
+	for _, td := range []struct {
+		name     string
+		now      time.Time
+		expected model.MarketStatus
+	}{
+		{utils.TestName("before open"), at(5, 9, 0), model.MarketStatus{NextOpen: at(5, 9, 30), NextClose: at(5, 16, 0)}},
+		{utils.TestName("open"), at(5, 10, 0), model.MarketStatus{IsOpen: true, NextOpen: at(6, 9, 30), NextClose: at(5, 16, 0)}},
+		{utils.TestName("open before holiday"), at(3, 12, 0), model.MarketStatus{IsOpen: true, NextOpen: at(5, 9, 30), NextClose: at(3, 13, 0)}},
+		{utils.TestName("after early close"), at(3, 14, 0), model.MarketStatus{NextOpen: at(5, 9, 30), NextClose: at(5, 16, 0)}},
+		{utils.TestName("on weekend"), at(1, 12, 0), model.MarketStatus{NextOpen: at(3, 9, 30), NextClose: at(3, 13, 0)}},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			td.expected.MicCode, td.expected.Timezone = "XNYS", "America/New_York"
+			status, err := calendars.Status("XNYS", td.now.UTC())
+			assert.NoError(t, err, "Status should be calculated")
+			assert.Equal(t, td.expected.IsOpen, status.IsOpen, "Open should be equal")
+			assert.True(t, td.expected.NextOpen.Equal(status.NextOpen), "Next open should be %s but was %s", td.expected.NextOpen, status.NextOpen)
+			assert.True(t, td.expected.NextClose.Equal(status.NextClose), "Next close should be %s but was %s", td.expected.NextClose, status.NextClose)
+		})
+	}
+	_, err := calendars.Status("XXXX", time.Now())
+	assert.ErrorIs(t, err, model.CalendarNotFound, "Unknown exchange should have no calendar")
+}
+
+func TestLastCompletedDay(t *testing.T) {
+	calendars := newTestCalendarService(t)
+	for _, td := range []struct {
+		name     string
+		now      time.Time
+		expected string
+	}{
+		{utils.TestName("before close"), time.Date(2023, 7, 6, 15, 0, 0, 0, time.UTC), "2023-07-05"},
+		{utils.TestName("after close"), time.Date(2023, 7, 6, 21, 0, 0, 0, time.UTC), "2023-07-06"},
+		{utils.TestName("on holiday"), time.Date(2023, 7, 4, 21, 0, 0, 0, time.UTC), "2023-07-03"},
+		{utils.TestName("after early close"), time.Date(2023, 7, 3, 17, 30, 0, 0, time.UTC), "2023-07-03"},
+		{utils.TestName("before early close"), time.Date(2023, 7, 3, 16, 30, 0, 0, time.UTC), "2023-06-30"},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			day, ok := calendars.LastCompletedDay("XNYS", td.now)
+			assert.True(t, ok, "Exchange should have calendar")
+			assert.Equal(t, td.expected, day.Format(model.DateLayout))
+		})
+	}
+	withCalendar := NewFreshnessPolicy(nil, calendars)
+	symbol := model.Symbol{Symbol: "AAPL", Exchanges: []model.Exchange{{MicCode: "XNYS"}}}
+	assert.Equal(t, "2023-07-03", withCalendar.LastCompletedSession(symbol, time.Date(2023, 7, 5, 12, 0, 0, 0, time.UTC)).Format(model.DateLayout), "Freshness should use exchange calendar")
+	assert.Equal(t, "America/New_York", withCalendar.Location(symbol).String(), "Location should be taken from exchange calendar")
+}
+
+func TestSaveCalendar(t *testing.T) {
+	mockRepo := mock.NewMockCalendarRepository(gomock.NewController(t))
+	mockRepo.EXPECT().GetAll(gomock.Any()).Return(nil, nil)
+	calendars, _ := NewCalendarService(context.TODO(), mockRepo, nil)
+	invalid := testCalendar
+	invalid.Close = "9:00"
+	assert.ErrorIs(t, calendars.Save(context.TODO(), invalid), model.InvalidCalendar, "Invalid calendar should not be saved")
+	invalid = testCalendar
+	invalid.Holidays = []string{"2023-13-01"}
+	assert.ErrorIs(t, calendars.Save(context.TODO(), invalid), model.InvalidCalendar, "Invalid holiday should not be saved")
+
+	mockRepo.EXPECT().Save(gomock.Any(), testCalendar).Return(nil)
+	assert.NoError(t, calendars.Save(context.TODO(), testCalendar), "Calendar should be saved")
+	saved, err := calendars.Get("XNYS")
+	assert.NoError(t, err, "Saved calendar should be found")
+	assert.Equal(t, testCalendar, saved, "Calendar should be equal")
+}
+
+func TestLoadCalendars(t *testing.T) {
+	bundled, err := LoadCalendars("../../config/calendars.yaml")
+	assert.NoError(t, err, "Bundled calendars should be loaded")
+	for _, calendar := range bundled {
+		_, err = newTradingCalendar(calendar)
+		assert.NoError(t, err, "Bundled calendar %s should be valid", calendar.MicCode)
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/internal/repository"
+)
+
+// UserService manages users on behalf of admins. Every action is logged with AuditService
+type UserService interface {
+	GetAll(ctx context.Context, query model.UserQuery) (model.UserPage, error)
+	Get(ctx context.Context, userID string) (model.User, error)
+	UpdateRoles(ctx context.Context, userID string, roles []model.Role) (model.User, error)
+	SetDisabled(ctx context.Context, userID string, disabled bool) (model.User, error)
+	Logout(ctx context.Context, userID string) error
+}
+
+type userServiceWithRepo struct {
+	repo         repository.UserRepository
+	auditService AuditService
+}
+
+func NewUserService(repo repository.UserRepository, auditService AuditService) UserService {
+	return &userServiceWithRepo{repo: repo, auditService: auditService}
+}
+
+func (s *userServiceWithRepo) GetAll(ctx context.Context, query model.UserQuery) (model.UserPage, error) {
+	page, err := s.repo.GetAll(ctx, query)
+	if err == nil {
+		ids := make([]string, 0, len(page.Users))
+		for _, user := range page.Users {
+			ids = append(ids, user.ID)
+		}
+		go s.auditService.LogUsersListed(ctx, ids)
+	}
+	return page, err
+}
+
+func (s *userServiceWithRepo) Get(ctx context.Context, userID string) (model.User, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if err == nil {
+		go s.auditService.LogUserViewed(ctx, userID)
+	}
+	return user, err
+}
+
+// UpdateRoles replaces roles of the user. Duplicated roles are set once. Error wraps model.UnknownRole if any of the roles
+// has no permissions. New roles are applied to jwt tokens issued after the update
+func (s *userServiceWithRepo) UpdateRoles(ctx context.Context, userID string, roles []model.Role) (model.User, error) {
+	known, err := s.repo.GetRoles(ctx)
+	if err != nil {
+		return model.User{}, err
+	}
+	unique := make([]model.Role, 0, len(roles))
+	for _, role := range roles {
+		if !containsRole(known, role) {
+			return model.User{}, fmt.Errorf("%w: %s", model.UnknownRole, role)
+		}
+		if !containsRole(unique, role) {
+			unique = append(unique, role)
+		}
+	}
+	if err = s.repo.SetUserRoles(ctx, userID, unique); err != nil {
+		return model.User{}, err
+	}
+	go s.auditService.LogUserRolesUpdated(ctx, userID)
+	return s.repo.GetUserByID(ctx, userID)
+}
+
+// SetDisabled disables or enables the user. Disabled user can't sign in and its refresh tokens are revoked
+func (s *userServiceWithRepo) SetDisabled(ctx context.Context, userID string, disabled bool) (model.User, error) {
+	if err := s.repo.SetUserDisabled(ctx, userID, disabled); err != nil {
+		return model.User{}, err
+	}
+	if disabled {
+		go s.auditService.LogUserDisabled(ctx, userID)
+		if err := s.repo.DeleteRefreshTokens(ctx, userID); err != nil {
+			return model.User{}, err
+		}
+	} else {
+		go s.auditService.LogUserEnabled(ctx, userID)
+	}
+	return s.repo.GetUserByID(ctx, userID)
+}
+
+// Logout revokes all refresh tokens of the user. Issued jwt tokens stay valid until they expire
+func (s *userServiceWithRepo) Logout(ctx context.Context, userID string) error {
+	if _, err := s.repo.GetUserByID(ctx, userID); err != nil {
+		return err
+	}
+	if err := s.repo.DeleteRefreshTokens(ctx, userID); err != nil {
+		return err
+	}
+	go s.auditService.LogUserLoggedOut(ctx, userID)
+	return nil
+}
+
+func containsRole(roles []model.Role, role model.Role) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"context"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/user_repository_mock.go -source=../repository/user_repository.go UserRepository
+//go:generate mockgen -package mock -destination ../../mock/audit_service_mock.go -source=audit_service.go AuditService
+
+func TestUpdateRoles(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewUserService(mockRepo, mockAudit)
+	roles := []model.Role{model.AdminRole, model.ClientRole}
+	mockRepo.EXPECT().GetRoles(gomock.Any()).Return(roles, nil).Times(3)
+	mockRepo.EXPECT().SetUserRoles(gomock.Any(), "1", roles).Return(nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1", Roles: roles}, nil)
+	mockAudit.EXPECT().LogUserRolesUpdated(gomock.Any(), "1").AnyTimes()
+	user, err := service.UpdateRoles(context.TODO(), "1", roles)
+	assert.NoError(t, err, "Roles should be updated")
+	assert.Equal(t, roles, user.Roles, "Updated user should be returned")
+
+	mockRepo.EXPECT().SetUserRoles(gomock.Any(), "1", roles).Return(nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1", Roles: roles}, nil)
+	_, err = service.UpdateRoles(context.TODO(), "1", []model.Role{model.AdminRole, model.ClientRole, model.AdminRole})
+	assert.NoError(t, err, "Duplicated roles should be set once")
+
+	_, err = service.UpdateRoles(context.TODO(), "1", []model.Role{"Root"})
+	assert.ErrorIs(t, err, model.UnknownRole, "Role without permissions should not be set")
+}
+
+func TestGetAllUsers(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewUserService(mockRepo, mockAudit)
+	query := model.UserQuery{Role: model.AdminRole, Limit: 10}
+	page := model.UserPage{Users: []model.User{{ID: "1"}, {ID: "2"}}, Total: 2}
+	listed := make(chan []string, 1)
+	mockRepo.EXPECT().GetAll(gomock.Any(), query).Return(page, nil)
+	mockAudit.EXPECT().LogUsersListed(gomock.Any(), gomock.Any()).Do(func(_ context.Context, ids []string) {
+		listed <- ids
+	})
+	users, err := service.GetAll(context.TODO(), query)
+	assert.NoError(t, err, "Users should be returned")
+	assert.Equal(t, page, users, "Pages should be equal")
+	assert.Equal(t, []string{"1", "2"}, <-listed, "Listed users should be audited")
+}
+
+func TestSetDisabled(t *testing.T) {
+	controller := gomock.NewController(t)
+	mockRepo := mock.NewMockUserRepository(controller)
+	mockAudit := mock.NewMockAuditService(controller)
+	service := NewUserService(mockRepo, mockAudit)
+	mockAudit.EXPECT().LogUserDisabled(gomock.Any(), "1").AnyTimes()
+	mockAudit.EXPECT().LogUserEnabled(gomock.Any(), "1").AnyTimes()
+
+	gomock.InOrder(
+		mockRepo.EXPECT().SetUserDisabled(gomock.Any(), "1", true).Return(nil),
+		mockRepo.EXPECT().DeleteRefreshTokens(gomock.Any(), "1").Return(nil),
+		mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1", Disabled: true}, nil),
+	)
+	user, err := service.SetDisabled(context.TODO(), "1", true)
+	assert.NoError(t, err, "User should be disabled")
+	assert.True(t, user.Disabled, "Disabled user should be returned")
+
+	mockRepo.EXPECT().SetUserDisabled(gomock.Any(), "1", false).Return(nil)
+	mockRepo.EXPECT().GetUserByID(gomock.Any(), "1").Return(model.User{ID: "1"}, nil)
+	user, err = service.SetDisabled(context.TODO(), "1", false)
+	assert.NoError(t, err, "User should be enabled")
+	assert.False(t, user.Disabled, "Enabled user should be returned")
+
+	mockRepo.EXPECT().SetUserDisabled(gomock.Any(), "2", true).Return(model.UserNotFound)
+	_, err = service.SetDisabled(context.TODO(), "2", true)
+	assert.Equal(t, model.UserNotFound, err, "Unknown user should not be disabled")
+}
@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../service/analytics_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/galushkoart/finance-api/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAnalyticsService is a mock of AnalyticsService interface.
+type MockAnalyticsService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAnalyticsServiceMockRecorder
+}
+
+// MockAnalyticsServiceMockRecorder is the mock recorder for MockAnalyticsService.
+type MockAnalyticsServiceMockRecorder struct {
+	mock *MockAnalyticsService
+}
+
+// NewMockAnalyticsService creates a new mock instance.
+func NewMockAnalyticsService(ctrl *gomock.Controller) *MockAnalyticsService {
+	mock := &MockAnalyticsService{ctrl: ctrl}
+	mock.recorder = &MockAnalyticsServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAnalyticsService) EXPECT() *MockAnalyticsServiceMockRecorder {
+	return m.recorder
+}
+
+// Correlation mocks base method.
+func (m *MockAnalyticsService) Correlation(ctx context.Context, symbols []string, from, to time.Time) (model.Correlation, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Correlation", ctx, symbols, from, to)
+	ret0, _ := ret[0].(model.Correlation)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Correlation indicates an expected call of Correlation.
+func (mr *MockAnalyticsServiceMockRecorder) Correlation(ctx, symbols, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Correlation", reflect.TypeOf((*MockAnalyticsService)(nil).Correlation), ctx, symbols, from, to)
+}
+
+// Get mocks base method.
+func (m *MockAnalyticsService) Get(ctx context.Context, symbol string, asOf time.Time) (model.SymbolAnalytics, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, symbol, asOf)
+	ret0, _ := ret[0].(model.SymbolAnalytics)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockAnalyticsServiceMockRecorder) Get(ctx, symbol, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockAnalyticsService)(nil).Get), ctx, symbol, asOf)
+}
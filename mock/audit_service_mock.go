@@ -0,0 +1,275 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: audit_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockAuditService is a mock of AuditService interface.
+type MockAuditService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAuditServiceMockRecorder
+}
+
+// MockAuditServiceMockRecorder is the mock recorder for MockAuditService.
+type MockAuditServiceMockRecorder struct {
+	mock *MockAuditService
+}
+
+// NewMockAuditService creates a new mock instance.
+func NewMockAuditService(ctrl *gomock.Controller) *MockAuditService {
+	mock := &MockAuditService{ctrl: ctrl}
+	mock.recorder = &MockAuditServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAuditService) EXPECT() *MockAuditServiceMockRecorder {
+	return m.recorder
+}
+
+// LogExchangeCreated mocks base method.
+func (m *MockAuditService) LogExchangeCreated(ctx context.Context, micCode string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogExchangeCreated", ctx, micCode)
+}
+
+// LogExchangeCreated indicates an expected call of LogExchangeCreated.
+func (mr *MockAuditServiceMockRecorder) LogExchangeCreated(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogExchangeCreated", reflect.TypeOf((*MockAuditService)(nil).LogExchangeCreated), ctx, micCode)
+}
+
+// LogExchangeDeleted mocks base method.
+func (m *MockAuditService) LogExchangeDeleted(ctx context.Context, micCode string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogExchangeDeleted", ctx, micCode)
+}
+
+// LogExchangeDeleted indicates an expected call of LogExchangeDeleted.
+func (mr *MockAuditServiceMockRecorder) LogExchangeDeleted(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogExchangeDeleted", reflect.TypeOf((*MockAuditService)(nil).LogExchangeDeleted), ctx, micCode)
+}
+
+// LogExchangeSymbolAttached mocks base method.
+func (m *MockAuditService) LogExchangeSymbolAttached(ctx context.Context, micCode, symbol string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogExchangeSymbolAttached", ctx, micCode, symbol)
+}
+
+// LogExchangeSymbolAttached indicates an expected call of LogExchangeSymbolAttached.
+func (mr *MockAuditServiceMockRecorder) LogExchangeSymbolAttached(ctx, micCode, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogExchangeSymbolAttached", reflect.TypeOf((*MockAuditService)(nil).LogExchangeSymbolAttached), ctx, micCode, symbol)
+}
+
+// LogExchangeSymbolDetached mocks base method.
+func (m *MockAuditService) LogExchangeSymbolDetached(ctx context.Context, micCode, symbol string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogExchangeSymbolDetached", ctx, micCode, symbol)
+}
+
+// LogExchangeSymbolDetached indicates an expected call of LogExchangeSymbolDetached.
+func (mr *MockAuditServiceMockRecorder) LogExchangeSymbolDetached(ctx, micCode, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogExchangeSymbolDetached", reflect.TypeOf((*MockAuditService)(nil).LogExchangeSymbolDetached), ctx, micCode, symbol)
+}
+
+// LogExchangeUpdated mocks base method.
+func (m *MockAuditService) LogExchangeUpdated(ctx context.Context, micCode string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogExchangeUpdated", ctx, micCode)
+}
+
+// LogExchangeUpdated indicates an expected call of LogExchangeUpdated.
+func (mr *MockAuditServiceMockRecorder) LogExchangeUpdated(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogExchangeUpdated", reflect.TypeOf((*MockAuditService)(nil).LogExchangeUpdated), ctx, micCode)
+}
+
+// LogSymbolCreated mocks base method.
+func (m *MockAuditService) LogSymbolCreated(ctx context.Context, symbol string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogSymbolCreated", ctx, symbol)
+}
+
+// LogSymbolCreated indicates an expected call of LogSymbolCreated.
+func (mr *MockAuditServiceMockRecorder) LogSymbolCreated(ctx, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogSymbolCreated", reflect.TypeOf((*MockAuditService)(nil).LogSymbolCreated), ctx, symbol)
+}
+
+// LogSymbolDeleted mocks base method.
+func (m *MockAuditService) LogSymbolDeleted(ctx context.Context, symbol string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogSymbolDeleted", ctx, symbol)
+}
+
+// LogSymbolDeleted indicates an expected call of LogSymbolDeleted.
+func (mr *MockAuditServiceMockRecorder) LogSymbolDeleted(ctx, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogSymbolDeleted", reflect.TypeOf((*MockAuditService)(nil).LogSymbolDeleted), ctx, symbol)
+}
+
+// LogSymbolUpdated mocks base method.
+func (m *MockAuditService) LogSymbolUpdated(ctx context.Context, symbol string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogSymbolUpdated", ctx, symbol)
+}
+
+// LogSymbolUpdated indicates an expected call of LogSymbolUpdated.
+func (mr *MockAuditServiceMockRecorder) LogSymbolUpdated(ctx, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogSymbolUpdated", reflect.TypeOf((*MockAuditService)(nil).LogSymbolUpdated), ctx, symbol)
+}
+
+// LogUserDeleted mocks base method.
+func (m *MockAuditService) LogUserDeleted(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserDeleted", ctx, userID)
+}
+
+// LogUserDeleted indicates an expected call of LogUserDeleted.
+func (mr *MockAuditServiceMockRecorder) LogUserDeleted(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserDeleted", reflect.TypeOf((*MockAuditService)(nil).LogUserDeleted), ctx, userID)
+}
+
+// LogUserDisabled mocks base method.
+func (m *MockAuditService) LogUserDisabled(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserDisabled", ctx, userID)
+}
+
+// LogUserDisabled indicates an expected call of LogUserDisabled.
+func (mr *MockAuditServiceMockRecorder) LogUserDisabled(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserDisabled", reflect.TypeOf((*MockAuditService)(nil).LogUserDisabled), ctx, userID)
+}
+
+// LogUserEnabled mocks base method.
+func (m *MockAuditService) LogUserEnabled(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserEnabled", ctx, userID)
+}
+
+// LogUserEnabled indicates an expected call of LogUserEnabled.
+func (mr *MockAuditServiceMockRecorder) LogUserEnabled(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserEnabled", reflect.TypeOf((*MockAuditService)(nil).LogUserEnabled), ctx, userID)
+}
+
+// LogUserLoggedOut mocks base method.
+func (m *MockAuditService) LogUserLoggedOut(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserLoggedOut", ctx, userID)
+}
+
+// LogUserLoggedOut indicates an expected call of LogUserLoggedOut.
+func (mr *MockAuditServiceMockRecorder) LogUserLoggedOut(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserLoggedOut", reflect.TypeOf((*MockAuditService)(nil).LogUserLoggedOut), ctx, userID)
+}
+
+// LogUserPasswordChanged mocks base method.
+func (m *MockAuditService) LogUserPasswordChanged(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserPasswordChanged", ctx, userID)
+}
+
+// LogUserPasswordChanged indicates an expected call of LogUserPasswordChanged.
+func (mr *MockAuditServiceMockRecorder) LogUserPasswordChanged(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserPasswordChanged", reflect.TypeOf((*MockAuditService)(nil).LogUserPasswordChanged), ctx, userID)
+}
+
+// LogUserProfileUpdated mocks base method.
+func (m *MockAuditService) LogUserProfileUpdated(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserProfileUpdated", ctx, userID)
+}
+
+// LogUserProfileUpdated indicates an expected call of LogUserProfileUpdated.
+func (mr *MockAuditServiceMockRecorder) LogUserProfileUpdated(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserProfileUpdated", reflect.TypeOf((*MockAuditService)(nil).LogUserProfileUpdated), ctx, userID)
+}
+
+// LogUserRefreshToken mocks base method.
+func (m *MockAuditService) LogUserRefreshToken(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserRefreshToken", ctx, userID)
+}
+
+// LogUserRefreshToken indicates an expected call of LogUserRefreshToken.
+func (mr *MockAuditServiceMockRecorder) LogUserRefreshToken(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserRefreshToken", reflect.TypeOf((*MockAuditService)(nil).LogUserRefreshToken), ctx, userID)
+}
+
+// LogUserRolesUpdated mocks base method.
+func (m *MockAuditService) LogUserRolesUpdated(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserRolesUpdated", ctx, userID)
+}
+
+// LogUserRolesUpdated indicates an expected call of LogUserRolesUpdated.
+func (mr *MockAuditServiceMockRecorder) LogUserRolesUpdated(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserRolesUpdated", reflect.TypeOf((*MockAuditService)(nil).LogUserRolesUpdated), ctx, userID)
+}
+
+// LogUserSignIn mocks base method.
+func (m *MockAuditService) LogUserSignIn(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserSignIn", ctx, userID)
+}
+
+// LogUserSignIn indicates an expected call of LogUserSignIn.
+func (mr *MockAuditServiceMockRecorder) LogUserSignIn(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserSignIn", reflect.TypeOf((*MockAuditService)(nil).LogUserSignIn), ctx, userID)
+}
+
+// LogUserSignUp mocks base method.
+func (m *MockAuditService) LogUserSignUp(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserSignUp", ctx, userID)
+}
+
+// LogUserSignUp indicates an expected call of LogUserSignUp.
+func (mr *MockAuditServiceMockRecorder) LogUserSignUp(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserSignUp", reflect.TypeOf((*MockAuditService)(nil).LogUserSignUp), ctx, userID)
+}
+
+// LogUserViewed mocks base method.
+func (m *MockAuditService) LogUserViewed(ctx context.Context, userID string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUserViewed", ctx, userID)
+}
+
+// LogUserViewed indicates an expected call of LogUserViewed.
+func (mr *MockAuditServiceMockRecorder) LogUserViewed(ctx, userID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUserViewed", reflect.TypeOf((*MockAuditService)(nil).LogUserViewed), ctx, userID)
+}
+
+// LogUsersListed mocks base method.
+func (m *MockAuditService) LogUsersListed(ctx context.Context, userIDs []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "LogUsersListed", ctx, userIDs)
+}
+
+// LogUsersListed indicates an expected call of LogUsersListed.
+func (mr *MockAuditServiceMockRecorder) LogUsersListed(ctx, userIDs interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogUsersListed", reflect.TypeOf((*MockAuditService)(nil).LogUsersListed), ctx, userIDs)
+}
@@ -36,6 +36,20 @@ func (m *MockAuthService) EXPECT() *MockAuthServiceMockRecorder {
 	return m.recorder
 }
 
+// ForgotPassword mocks base method.
+func (m *MockAuthService) ForgotPassword(ctx context.Context, email string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForgotPassword", ctx, email)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ForgotPassword indicates an expected call of ForgotPassword.
+func (mr *MockAuthServiceMockRecorder) ForgotPassword(ctx, email interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForgotPassword", reflect.TypeOf((*MockAuthService)(nil).ForgotPassword), ctx, email)
+}
+
 // RefreshToken mocks base method.
 func (m *MockAuthService) RefreshToken(ctx context.Context, refreshToken string) (string, string, time.Time, error) {
 	m.ctrl.T.Helper()
@@ -53,6 +67,20 @@ func (mr *MockAuthServiceMockRecorder) RefreshToken(ctx, refreshToken interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RefreshToken", reflect.TypeOf((*MockAuthService)(nil).RefreshToken), ctx, refreshToken)
 }
 
+// ResetPassword mocks base method.
+func (m *MockAuthService) ResetPassword(ctx context.Context, reset model.ResetPassword) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResetPassword", ctx, reset)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ResetPassword indicates an expected call of ResetPassword.
+func (mr *MockAuthServiceMockRecorder) ResetPassword(ctx, reset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResetPassword", reflect.TypeOf((*MockAuthService)(nil).ResetPassword), ctx, reset)
+}
+
 // SignIn mocks base method.
 func (m *MockAuthService) SignIn(ctx context.Context, signIn model.SignIn) (string, string, time.Time, error) {
 	m.ctrl.T.Helper()
@@ -0,0 +1,65 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/calendar_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/galushkoart/finance-api/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCalendarRepository is a mock of CalendarRepository interface.
+type MockCalendarRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarRepositoryMockRecorder
+}
+
+// MockCalendarRepositoryMockRecorder is the mock recorder for MockCalendarRepository.
+type MockCalendarRepositoryMockRecorder struct {
+	mock *MockCalendarRepository
+}
+
+// NewMockCalendarRepository creates a new mock instance.
+func NewMockCalendarRepository(ctrl *gomock.Controller) *MockCalendarRepository {
+	mock := &MockCalendarRepository{ctrl: ctrl}
+	mock.recorder = &MockCalendarRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarRepository) EXPECT() *MockCalendarRepositoryMockRecorder {
+	return m.recorder
+}
+
+// GetAll mocks base method.
+func (m *MockCalendarRepository) GetAll(ctx context.Context) ([]model.TradingCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]model.TradingCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockCalendarRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockCalendarRepository)(nil).GetAll), ctx)
+}
+
+// Save mocks base method.
+func (m *MockCalendarRepository) Save(ctx context.Context, calendar model.TradingCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCalendarRepositoryMockRecorder) Save(ctx, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCalendarRepository)(nil).Save), ctx, calendar)
+}
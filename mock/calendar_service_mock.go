@@ -0,0 +1,111 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../service/calendar_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/galushkoart/finance-api/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockCalendarService is a mock of CalendarService interface.
+type MockCalendarService struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarServiceMockRecorder
+}
+
+// MockCalendarServiceMockRecorder is the mock recorder for MockCalendarService.
+type MockCalendarServiceMockRecorder struct {
+	mock *MockCalendarService
+}
+
+// NewMockCalendarService creates a new mock instance.
+func NewMockCalendarService(ctrl *gomock.Controller) *MockCalendarService {
+	mock := &MockCalendarService{ctrl: ctrl}
+	mock.recorder = &MockCalendarServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarService) EXPECT() *MockCalendarServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockCalendarService) Get(micCode string) (model.TradingCalendar, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", micCode)
+	ret0, _ := ret[0].(model.TradingCalendar)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockCalendarServiceMockRecorder) Get(micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCalendarService)(nil).Get), micCode)
+}
+
+// LastCompletedDay mocks base method.
+func (m *MockCalendarService) LastCompletedDay(micCode string, now time.Time) (time.Time, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LastCompletedDay", micCode, now)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// LastCompletedDay indicates an expected call of LastCompletedDay.
+func (mr *MockCalendarServiceMockRecorder) LastCompletedDay(micCode, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LastCompletedDay", reflect.TypeOf((*MockCalendarService)(nil).LastCompletedDay), micCode, now)
+}
+
+// Location mocks base method.
+func (m *MockCalendarService) Location(micCode string) (*time.Location, bool) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Location", micCode)
+	ret0, _ := ret[0].(*time.Location)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Location indicates an expected call of Location.
+func (mr *MockCalendarServiceMockRecorder) Location(micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Location", reflect.TypeOf((*MockCalendarService)(nil).Location), micCode)
+}
+
+// Save mocks base method.
+func (m *MockCalendarService) Save(ctx context.Context, calendar model.TradingCalendar) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, calendar)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockCalendarServiceMockRecorder) Save(ctx, calendar interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockCalendarService)(nil).Save), ctx, calendar)
+}
+
+// Status mocks base method.
+func (m *MockCalendarService) Status(micCode string, now time.Time) (model.MarketStatus, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Status", micCode, now)
+	ret0, _ := ret[0].(model.MarketStatus)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Status indicates an expected call of Status.
+func (mr *MockCalendarServiceMockRecorder) Status(micCode, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Status", reflect.TypeOf((*MockCalendarService)(nil).Status), micCode, now)
+}
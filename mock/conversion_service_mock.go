@@ -0,0 +1,82 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../service/conversion_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/galushkoart/finance-api/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockConversionService is a mock of ConversionService interface.
+type MockConversionService struct {
+	ctrl     *gomock.Controller
+	recorder *MockConversionServiceMockRecorder
+}
+
+// MockConversionServiceMockRecorder is the mock recorder for MockConversionService.
+type MockConversionServiceMockRecorder struct {
+	mock *MockConversionService
+}
+
+// NewMockConversionService creates a new mock instance.
+func NewMockConversionService(ctrl *gomock.Controller) *MockConversionService {
+	mock := &MockConversionService{ctrl: ctrl}
+	mock.recorder = &MockConversionServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConversionService) EXPECT() *MockConversionServiceMockRecorder {
+	return m.recorder
+}
+
+// Convert mocks base method.
+func (m *MockConversionService) Convert(ctx context.Context, from, to string, amount model.Decimal, date time.Time) (model.Conversion, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Convert", ctx, from, to, amount, date)
+	ret0, _ := ret[0].(model.Conversion)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Convert indicates an expected call of Convert.
+func (mr *MockConversionServiceMockRecorder) Convert(ctx, from, to, amount, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Convert", reflect.TypeOf((*MockConversionService)(nil).Convert), ctx, from, to, amount, date)
+}
+
+// ConvertPrices mocks base method.
+func (m *MockConversionService) ConvertPrices(ctx context.Context, prices []model.Price, from, to string) ([]model.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ConvertPrices", ctx, prices, from, to)
+	ret0, _ := ret[0].([]model.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ConvertPrices indicates an expected call of ConvertPrices.
+func (mr *MockConversionServiceMockRecorder) ConvertPrices(ctx, prices, from, to interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ConvertPrices", reflect.TypeOf((*MockConversionService)(nil).ConvertPrices), ctx, prices, from, to)
+}
+
+// Rate mocks base method.
+func (m *MockConversionService) Rate(ctx context.Context, from, to string, date time.Time) (model.Rate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rate", ctx, from, to, date)
+	ret0, _ := ret[0].(model.Rate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Rate indicates an expected call of Rate.
+func (mr *MockConversionServiceMockRecorder) Rate(ctx, from, to, date interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rate", reflect.TypeOf((*MockConversionService)(nil).Rate), ctx, from, to, date)
+}
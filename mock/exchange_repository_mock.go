@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/exchange_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/galushkoart/finance-api/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExchangeRepository is a mock of ExchangeRepository interface.
+type MockExchangeRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeRepositoryMockRecorder
+}
+
+// MockExchangeRepositoryMockRecorder is the mock recorder for MockExchangeRepository.
+type MockExchangeRepositoryMockRecorder struct {
+	mock *MockExchangeRepository
+}
+
+// NewMockExchangeRepository creates a new mock instance.
+func NewMockExchangeRepository(ctrl *gomock.Controller) *MockExchangeRepository {
+	mock := &MockExchangeRepository{ctrl: ctrl}
+	mock.recorder = &MockExchangeRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeRepository) EXPECT() *MockExchangeRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockExchangeRepository) Add(ctx context.Context, exchange model.Exchange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, exchange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockExchangeRepositoryMockRecorder) Add(ctx, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockExchangeRepository)(nil).Add), ctx, exchange)
+}
+
+// AttachSymbol mocks base method.
+func (m *MockExchangeRepository) AttachSymbol(ctx context.Context, micCode, symbolName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachSymbol", ctx, micCode, symbolName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachSymbol indicates an expected call of AttachSymbol.
+func (mr *MockExchangeRepositoryMockRecorder) AttachSymbol(ctx, micCode, symbolName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachSymbol", reflect.TypeOf((*MockExchangeRepository)(nil).AttachSymbol), ctx, micCode, symbolName)
+}
+
+// Delete mocks base method.
+func (m *MockExchangeRepository) Delete(ctx context.Context, micCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, micCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExchangeRepositoryMockRecorder) Delete(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExchangeRepository)(nil).Delete), ctx, micCode)
+}
+
+// DetachSymbol mocks base method.
+func (m *MockExchangeRepository) DetachSymbol(ctx context.Context, micCode, symbolName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachSymbol", ctx, micCode, symbolName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachSymbol indicates an expected call of DetachSymbol.
+func (mr *MockExchangeRepositoryMockRecorder) DetachSymbol(ctx, micCode, symbolName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachSymbol", reflect.TypeOf((*MockExchangeRepository)(nil).DetachSymbol), ctx, micCode, symbolName)
+}
+
+// GetAll mocks base method.
+func (m *MockExchangeRepository) GetAll(ctx context.Context) ([]model.Exchange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]model.Exchange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockExchangeRepositoryMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockExchangeRepository)(nil).GetAll), ctx)
+}
+
+// GetByMicCode mocks base method.
+func (m *MockExchangeRepository) GetByMicCode(ctx context.Context, micCode string) (model.Exchange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMicCode", ctx, micCode)
+	ret0, _ := ret[0].(model.Exchange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMicCode indicates an expected call of GetByMicCode.
+func (mr *MockExchangeRepositoryMockRecorder) GetByMicCode(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMicCode", reflect.TypeOf((*MockExchangeRepository)(nil).GetByMicCode), ctx, micCode)
+}
+
+// GetSymbols mocks base method.
+func (m *MockExchangeRepository) GetSymbols(ctx context.Context, micCode string) ([]model.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSymbols", ctx, micCode)
+	ret0, _ := ret[0].([]model.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSymbols indicates an expected call of GetSymbols.
+func (mr *MockExchangeRepositoryMockRecorder) GetSymbols(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSymbols", reflect.TypeOf((*MockExchangeRepository)(nil).GetSymbols), ctx, micCode)
+}
+
+// Update mocks base method.
+func (m *MockExchangeRepository) Update(ctx context.Context, micCode string, exchange model.UpdateExchange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, micCode, exchange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockExchangeRepositoryMockRecorder) Update(ctx, micCode, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockExchangeRepository)(nil).Update), ctx, micCode, exchange)
+}
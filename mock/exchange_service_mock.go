@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../service/exchange_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/galushkoart/finance-api/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockExchangeService is a mock of ExchangeService interface.
+type MockExchangeService struct {
+	ctrl     *gomock.Controller
+	recorder *MockExchangeServiceMockRecorder
+}
+
+// MockExchangeServiceMockRecorder is the mock recorder for MockExchangeService.
+type MockExchangeServiceMockRecorder struct {
+	mock *MockExchangeService
+}
+
+// NewMockExchangeService creates a new mock instance.
+func NewMockExchangeService(ctrl *gomock.Controller) *MockExchangeService {
+	mock := &MockExchangeService{ctrl: ctrl}
+	mock.recorder = &MockExchangeServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockExchangeService) EXPECT() *MockExchangeServiceMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockExchangeService) Add(ctx context.Context, exchange model.Exchange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, exchange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockExchangeServiceMockRecorder) Add(ctx, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockExchangeService)(nil).Add), ctx, exchange)
+}
+
+// AttachSymbol mocks base method.
+func (m *MockExchangeService) AttachSymbol(ctx context.Context, micCode, symbolName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AttachSymbol", ctx, micCode, symbolName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AttachSymbol indicates an expected call of AttachSymbol.
+func (mr *MockExchangeServiceMockRecorder) AttachSymbol(ctx, micCode, symbolName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AttachSymbol", reflect.TypeOf((*MockExchangeService)(nil).AttachSymbol), ctx, micCode, symbolName)
+}
+
+// Delete mocks base method.
+func (m *MockExchangeService) Delete(ctx context.Context, micCode string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, micCode)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockExchangeServiceMockRecorder) Delete(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockExchangeService)(nil).Delete), ctx, micCode)
+}
+
+// DetachSymbol mocks base method.
+func (m *MockExchangeService) DetachSymbol(ctx context.Context, micCode, symbolName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DetachSymbol", ctx, micCode, symbolName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DetachSymbol indicates an expected call of DetachSymbol.
+func (mr *MockExchangeServiceMockRecorder) DetachSymbol(ctx, micCode, symbolName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DetachSymbol", reflect.TypeOf((*MockExchangeService)(nil).DetachSymbol), ctx, micCode, symbolName)
+}
+
+// GetAll mocks base method.
+func (m *MockExchangeService) GetAll(ctx context.Context) ([]model.Exchange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx)
+	ret0, _ := ret[0].([]model.Exchange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockExchangeServiceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockExchangeService)(nil).GetAll), ctx)
+}
+
+// GetByMicCode mocks base method.
+func (m *MockExchangeService) GetByMicCode(ctx context.Context, micCode string) (model.Exchange, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetByMicCode", ctx, micCode)
+	ret0, _ := ret[0].(model.Exchange)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetByMicCode indicates an expected call of GetByMicCode.
+func (mr *MockExchangeServiceMockRecorder) GetByMicCode(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByMicCode", reflect.TypeOf((*MockExchangeService)(nil).GetByMicCode), ctx, micCode)
+}
+
+// GetSymbols mocks base method.
+func (m *MockExchangeService) GetSymbols(ctx context.Context, micCode string) ([]model.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSymbols", ctx, micCode)
+	ret0, _ := ret[0].([]model.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSymbols indicates an expected call of GetSymbols.
+func (mr *MockExchangeServiceMockRecorder) GetSymbols(ctx, micCode interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSymbols", reflect.TypeOf((*MockExchangeService)(nil).GetSymbols), ctx, micCode)
+}
+
+// Update mocks base method.
+func (m *MockExchangeService) Update(ctx context.Context, micCode string, exchange model.UpdateExchange) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, micCode, exchange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockExchangeServiceMockRecorder) Update(ctx, micCode, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockExchangeService)(nil).Update), ctx, micCode, exchange)
+}
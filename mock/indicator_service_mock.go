@@ -0,0 +1,52 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../service/indicator_service.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+
+	model "github.com/galushkoart/finance-api/internal/model"
+	indicators "github.com/galushkoart/finance-api/pkg/indicators"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockIndicatorService is a mock of IndicatorService interface.
+type MockIndicatorService struct {
+	ctrl     *gomock.Controller
+	recorder *MockIndicatorServiceMockRecorder
+}
+
+// MockIndicatorServiceMockRecorder is the mock recorder for MockIndicatorService.
+type MockIndicatorServiceMockRecorder struct {
+	mock *MockIndicatorService
+}
+
+// NewMockIndicatorService creates a new mock instance.
+func NewMockIndicatorService(ctrl *gomock.Controller) *MockIndicatorService {
+	mock := &MockIndicatorService{ctrl: ctrl}
+	mock.recorder = &MockIndicatorServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockIndicatorService) EXPECT() *MockIndicatorServiceMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method.
+func (m *MockIndicatorService) Get(ctx context.Context, query model.PriceQuery, name string, params indicators.Params) (model.Indicator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, query, name, params)
+	ret0, _ := ret[0].(model.Indicator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockIndicatorServiceMockRecorder) Get(ctx, query, name, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockIndicatorService)(nil).Get), ctx, query, name, params)
+}
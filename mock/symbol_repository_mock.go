@@ -0,0 +1,183 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ../repository/symbol_repository.go
+
+// Package mock is a generated GoMock package.
+package mock
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	model "github.com/galushkoart/finance-api/internal/model"
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockSymbolRepository is a mock of SymbolRepository interface.
+type MockSymbolRepository struct {
+	ctrl     *gomock.Controller
+	recorder *MockSymbolRepositoryMockRecorder
+}
+
+// MockSymbolRepositoryMockRecorder is the mock recorder for MockSymbolRepository.
+type MockSymbolRepositoryMockRecorder struct {
+	mock *MockSymbolRepository
+}
+
+// NewMockSymbolRepository creates a new mock instance.
+func NewMockSymbolRepository(ctrl *gomock.Controller) *MockSymbolRepository {
+	mock := &MockSymbolRepository{ctrl: ctrl}
+	mock.recorder = &MockSymbolRepositoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSymbolRepository) EXPECT() *MockSymbolRepositoryMockRecorder {
+	return m.recorder
+}
+
+// Add mocks base method.
+func (m *MockSymbolRepository) Add(ctx context.Context, symbol model.Symbol) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Add", ctx, symbol)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Add indicates an expected call of Add.
+func (mr *MockSymbolRepositoryMockRecorder) Add(ctx, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockSymbolRepository)(nil).Add), ctx, symbol)
+}
+
+// Delete mocks base method.
+func (m *MockSymbolRepository) Delete(ctx context.Context, symbolName string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, symbolName)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockSymbolRepositoryMockRecorder) Delete(ctx, symbolName interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockSymbolRepository)(nil).Delete), ctx, symbolName)
+}
+
+// GetAll mocks base method.
+func (m *MockSymbolRepository) GetAll(ctx context.Context, query model.SymbolQuery) (model.SymbolPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAll", ctx, query)
+	ret0, _ := ret[0].(model.SymbolPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAll indicates an expected call of GetAll.
+func (mr *MockSymbolRepositoryMockRecorder) GetAll(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSymbolRepository)(nil).GetAll), ctx, query)
+}
+
+// GetBySymbol mocks base method.
+func (m *MockSymbolRepository) GetBySymbol(ctx context.Context, name string) (model.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySymbol", ctx, name)
+	ret0, _ := ret[0].(model.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySymbol indicates an expected call of GetBySymbol.
+func (mr *MockSymbolRepositoryMockRecorder) GetBySymbol(ctx, name interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySymbol", reflect.TypeOf((*MockSymbolRepository)(nil).GetBySymbol), ctx, name)
+}
+
+// GetBySymbols mocks base method.
+func (m *MockSymbolRepository) GetBySymbols(ctx context.Context, names []string) ([]model.Symbol, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBySymbols", ctx, names)
+	ret0, _ := ret[0].([]model.Symbol)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBySymbols indicates an expected call of GetBySymbols.
+func (mr *MockSymbolRepositoryMockRecorder) GetBySymbols(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySymbols", reflect.TypeOf((*MockSymbolRepository)(nil).GetBySymbols), ctx, names)
+}
+
+// GetLatestDates mocks base method.
+func (m *MockSymbolRepository) GetLatestDates(ctx context.Context, interval model.Interval) (map[string]time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestDates", ctx, interval)
+	ret0, _ := ret[0].(map[string]time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestDates indicates an expected call of GetLatestDates.
+func (mr *MockSymbolRepositoryMockRecorder) GetLatestDates(ctx, interval interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestDates", reflect.TypeOf((*MockSymbolRepository)(nil).GetLatestDates), ctx, interval)
+}
+
+// GetPrices mocks base method.
+func (m *MockSymbolRepository) GetPrices(ctx context.Context, query model.PriceQuery) ([]model.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrices", ctx, query)
+	ret0, _ := ret[0].([]model.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrices indicates an expected call of GetPrices.
+func (mr *MockSymbolRepositoryMockRecorder) GetPrices(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrices", reflect.TypeOf((*MockSymbolRepository)(nil).GetPrices), ctx, query)
+}
+
+// Search mocks base method.
+func (m *MockSymbolRepository) Search(ctx context.Context, query string, limit int) ([]model.SymbolMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit)
+	ret0, _ := ret[0].([]model.SymbolMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSymbolRepositoryMockRecorder) Search(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSymbolRepository)(nil).Search), ctx, query, limit)
+}
+
+// Update mocks base method.
+func (m *MockSymbolRepository) Update(ctx context.Context, symbol model.UpdateSymbol) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Update", ctx, symbol)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Update indicates an expected call of Update.
+func (mr *MockSymbolRepositoryMockRecorder) Update(ctx, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Update", reflect.TypeOf((*MockSymbolRepository)(nil).Update), ctx, symbol)
+}
+
+// UpsertPrices mocks base method.
+func (m *MockSymbolRepository) UpsertPrices(ctx context.Context, symbolName string, prices []model.Price) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpsertPrices", ctx, symbolName, prices)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpsertPrices indicates an expected call of UpsertPrices.
+func (mr *MockSymbolRepositoryMockRecorder) UpsertPrices(ctx, symbolName, prices interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpsertPrices", reflect.TypeOf((*MockSymbolRepository)(nil).UpsertPrices), ctx, symbolName, prices)
+}
@@ -7,6 +7,7 @@ package mock
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	model "github.com/galushkoart/finance-api/internal/model"
 	gomock "github.com/golang/mock/gomock"
@@ -49,6 +50,21 @@ func (mr *MockSymbolServiceMockRecorder) Add(ctx, symbol interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Add", reflect.TypeOf((*MockSymbolService)(nil).Add), ctx, symbol)
 }
 
+// Backfill mocks base method.
+func (m *MockSymbolService) Backfill(ctx context.Context, name string, interval model.Interval, from time.Time) (model.BackfillResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Backfill", ctx, name, interval, from)
+	ret0, _ := ret[0].(model.BackfillResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Backfill indicates an expected call of Backfill.
+func (mr *MockSymbolServiceMockRecorder) Backfill(ctx, name, interval, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Backfill", reflect.TypeOf((*MockSymbolService)(nil).Backfill), ctx, name, interval, from)
+}
+
 // Delete mocks base method.
 func (m *MockSymbolService) Delete(ctx context.Context, symbolName string) error {
 	m.ctrl.T.Helper()
@@ -64,18 +80,18 @@ func (mr *MockSymbolServiceMockRecorder) Delete(ctx, symbolName interface{}) *go
 }
 
 // GetAll mocks base method.
-func (m *MockSymbolService) GetAll(ctx context.Context) ([]model.Symbol, error) {
+func (m *MockSymbolService) GetAll(ctx context.Context, query model.SymbolQuery) (model.SymbolPage, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAll", ctx)
-	ret0, _ := ret[0].([]model.Symbol)
+	ret := m.ctrl.Call(m, "GetAll", ctx, query)
+	ret0, _ := ret[0].(model.SymbolPage)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAll indicates an expected call of GetAll.
-func (mr *MockSymbolServiceMockRecorder) GetAll(ctx interface{}) *gomock.Call {
+func (mr *MockSymbolServiceMockRecorder) GetAll(ctx, query interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSymbolService)(nil).GetAll), ctx)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAll", reflect.TypeOf((*MockSymbolService)(nil).GetAll), ctx, query)
 }
 
 // GetBySymbol mocks base method.
@@ -93,6 +109,81 @@ func (mr *MockSymbolServiceMockRecorder) GetBySymbol(ctx, name interface{}) *gom
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBySymbol", reflect.TypeOf((*MockSymbolService)(nil).GetBySymbol), ctx, name)
 }
 
+// GetConvertedPrices mocks base method.
+func (m *MockSymbolService) GetConvertedPrices(ctx context.Context, query model.PriceQuery, convert func(context.Context, []model.Price) ([]model.Price, error)) ([]model.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetConvertedPrices", ctx, query, convert)
+	ret0, _ := ret[0].([]model.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetConvertedPrices indicates an expected call of GetConvertedPrices.
+func (mr *MockSymbolServiceMockRecorder) GetConvertedPrices(ctx, query, convert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetConvertedPrices", reflect.TypeOf((*MockSymbolService)(nil).GetConvertedPrices), ctx, query, convert)
+}
+
+// GetPrices mocks base method.
+func (m *MockSymbolService) GetPrices(ctx context.Context, query model.PriceQuery) ([]model.Price, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrices", ctx, query)
+	ret0, _ := ret[0].([]model.Price)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrices indicates an expected call of GetPrices.
+func (mr *MockSymbolServiceMockRecorder) GetPrices(ctx, query interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrices", reflect.TypeOf((*MockSymbolService)(nil).GetPrices), ctx, query)
+}
+
+// GetQuotes mocks base method.
+func (m *MockSymbolService) GetQuotes(ctx context.Context, names []string) ([]model.Quote, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetQuotes", ctx, names)
+	ret0, _ := ret[0].([]model.Quote)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetQuotes indicates an expected call of GetQuotes.
+func (mr *MockSymbolServiceMockRecorder) GetQuotes(ctx, names interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetQuotes", reflect.TypeOf((*MockSymbolService)(nil).GetQuotes), ctx, names)
+}
+
+// Refresh mocks base method.
+func (m *MockSymbolService) Refresh(ctx context.Context, name string, since time.Time) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Refresh", ctx, name, since)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Refresh indicates an expected call of Refresh.
+func (mr *MockSymbolServiceMockRecorder) Refresh(ctx, name, since interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Refresh", reflect.TypeOf((*MockSymbolService)(nil).Refresh), ctx, name, since)
+}
+
+// Search mocks base method.
+func (m *MockSymbolService) Search(ctx context.Context, query string, limit int) ([]model.SymbolMatch, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", ctx, query, limit)
+	ret0, _ := ret[0].([]model.SymbolMatch)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSymbolServiceMockRecorder) Search(ctx, query, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSymbolService)(nil).Search), ctx, query, limit)
+}
+
 // Update mocks base method.
 func (m *MockSymbolService) Update(ctx context.Context, symbol model.UpdateSymbol) error {
 	m.ctrl.T.Helper()
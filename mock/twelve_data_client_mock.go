@@ -36,16 +36,60 @@ func (m *MockTwelveDataClient) EXPECT() *MockTwelveDataClientMockRecorder {
 }
 
 // GetHistoricDataForSymbol mocks base method.
-func (m *MockTwelveDataClient) GetHistoricDataForSymbol(ctx context.Context, symbol string) (*apiclient.TimeSeries, error) {
+func (m *MockTwelveDataClient) GetHistoricDataForSymbol(ctx context.Context, symbol string, timeSeriesParams apiclient.TimeSeriesParams) (*apiclient.TimeSeries, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetHistoricDataForSymbol", ctx, symbol)
+	ret := m.ctrl.Call(m, "GetHistoricDataForSymbol", ctx, symbol, timeSeriesParams)
 	ret0, _ := ret[0].(*apiclient.TimeSeries)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetHistoricDataForSymbol indicates an expected call of GetHistoricDataForSymbol.
-func (mr *MockTwelveDataClientMockRecorder) GetHistoricDataForSymbol(ctx, symbol interface{}) *gomock.Call {
+func (mr *MockTwelveDataClientMockRecorder) GetHistoricDataForSymbol(ctx, symbol, timeSeriesParams interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricDataForSymbol", reflect.TypeOf((*MockTwelveDataClient)(nil).GetHistoricDataForSymbol), ctx, symbol)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricDataForSymbol", reflect.TypeOf((*MockTwelveDataClient)(nil).GetHistoricDataForSymbol), ctx, symbol, timeSeriesParams)
+}
+
+// GetHistoricDataForSymbols mocks base method.
+func (m *MockTwelveDataClient) GetHistoricDataForSymbols(ctx context.Context, symbols []string, timeSeriesParams apiclient.TimeSeriesParams) (map[string]apiclient.SeriesResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetHistoricDataForSymbols", ctx, symbols, timeSeriesParams)
+	ret0, _ := ret[0].(map[string]apiclient.SeriesResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHistoricDataForSymbols indicates an expected call of GetHistoricDataForSymbols.
+func (mr *MockTwelveDataClientMockRecorder) GetHistoricDataForSymbols(ctx, symbols, timeSeriesParams interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHistoricDataForSymbols", reflect.TypeOf((*MockTwelveDataClient)(nil).GetHistoricDataForSymbols), ctx, symbols, timeSeriesParams)
+}
+
+// Name mocks base method.
+func (m *MockTwelveDataClient) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockTwelveDataClientMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockTwelveDataClient)(nil).Name))
+}
+
+// SearchSymbols mocks base method.
+func (m *MockTwelveDataClient) SearchSymbols(ctx context.Context, query string, outputSize int) ([]apiclient.SymbolSearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchSymbols", ctx, query, outputSize)
+	ret0, _ := ret[0].([]apiclient.SymbolSearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SearchSymbols indicates an expected call of SearchSymbols.
+func (mr *MockTwelveDataClientMockRecorder) SearchSymbols(ctx, query, outputSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchSymbols", reflect.TypeOf((*MockTwelveDataClient)(nil).SearchSymbols), ctx, query, outputSize)
 }
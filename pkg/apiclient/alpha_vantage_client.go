@@ -0,0 +1,239 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/goccy/go-json"
+	"github.com/rs/zerolog/log"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+const AlphaVantageProvider = "alphaVantage"
+
+// AlphaVantageCredits is the number of api calls alpha vantage counts per symbol for each endpoint
+var AlphaVantageCredits = map[string]int{
+	TimeSeriesEndpoint: 1,
+}
+
+var UnknownAlphaVantageError = errors.New("unknown alpha vantage error")
+
+type AlphaVantageApiKeyError struct {
+	Err error
+}
+
+func (e *AlphaVantageApiKeyError) Is(target error) bool {
+	_, ok := target.(*AlphaVantageApiKeyError)
+	if !ok {
+		return false
+	}
+	return e.Error() == target.Error()
+}
+
+func (e *AlphaVantageApiKeyError) Error() string {
+	return "problem with alpha vantage api key: " + e.Err.Error()
+}
+
+type alphaVantageClient struct {
+	c      *http.Client
+	apiKey string
+	host   string
+}
+
+func NewAlphaVantageClient(apiKey string, apiHost string, clientTimout time.Duration) MarketDataProvider {
+	matched, err := regexp.MatchString("http(s)?://[\\w\\-.]+(:\\d{1,5})?", apiHost)
+	if !matched {
+		log.Panic().Err(err).Msg("Invalid apiHost!")
+	}
+	return &alphaVantageClient{
+		c: &http.Client{
+			Timeout:   clientTimout,
+			Transport: NewRequestLoggingTransport(http.DefaultTransport),
+		},
+		apiKey: apiKey,
+		host:   apiHost,
+	}
+}
+
+func (c *alphaVantageClient) Name() string {
+	return AlphaVantageProvider
+}
+
+// alphaVantageIntervals maps intervals to alpha vantage function suffix and interval parameter
+var alphaVantageIntervals = map[string]struct {
+	function string
+	interval string
+}{
+	"1min":  {"INTRADAY", "1min"},
+	"5min":  {"INTRADAY", "5min"},
+	"15min": {"INTRADAY", "15min"},
+	"1h":    {"INTRADAY", "60min"},
+	"1day":  {"DAILY", ""},
+	"1week": {"WEEKLY", ""},
+}
+
+// compactOutputSize is the number of values alpha vantage returns without outputsize=full
+const compactOutputSize = 100
+
+// GetHistoricDataForSymbol requests TIME_SERIES_* functions for equities and FX_* functions for fx pairs like EUR/USD.
+// Alpha vantage doesn't support date ranges, so StartDate, EndDate and OutputSize are applied to the received values
+func (c *alphaVantageClient) GetHistoricDataForSymbol(ctx context.Context, symbol string, timeSeriesParams TimeSeriesParams) (*TimeSeries, error) {
+	interval := timeSeriesParams.Interval
+	if interval == "" {
+		interval = "1day"
+	}
+	avInterval, ok := alphaVantageIntervals[interval]
+	if !ok {
+		return nil, UnknownAlphaVantageError
+	}
+	params := url.Values{}
+	params.Add("apikey", c.apiKey)
+	if base, quote, isFx := strings.Cut(symbol, "/"); isFx {
+		params.Add("function", "FX_"+avInterval.function)
+		params.Add("from_symbol", base)
+		params.Add("to_symbol", quote)
+	} else {
+		params.Add("function", "TIME_SERIES_"+avInterval.function)
+		params.Add("symbol", symbol)
+	}
+	if avInterval.interval != "" {
+		params.Add("interval", avInterval.interval)
+	}
+	if timeSeriesParams.OutputSize == 0 || timeSeriesParams.OutputSize > compactOutputSize || timeSeriesParams.StartDate != "" || timeSeriesParams.EndDate != "" {
+		params.Add("outputsize", "full")
+	}
+	u, _ := url.ParseRequestURI(c.host)
+	u.Path = "query"
+	u.RawQuery = params.Encode()
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := c.c.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode != 200 {
+		return nil, UnknownAlphaVantageError
+	}
+	var results map[string]json.RawMessage
+	if err = json.Unmarshal(responseBody, &results); err != nil {
+		return nil, err
+	}
+	if message, ok := results["Error Message"]; ok {
+		log.Debug().Str("from", "alphaVantageClient").Msgf("Error from alpha vantage: %s", message)
+		return nil, model.SymbolNotFound
+	}
+	for _, key := range []string{"Note", "Information"} {
+		if rawMessage, ok := results[key]; ok {
+			var message string
+			_ = json.Unmarshal(rawMessage, &message)
+			return nil, &AlphaVantageApiKeyError{Err: errors.New("message: " + message)}
+		}
+	}
+	return alphaVantageToTimeSeries(symbol, interval, results, timeSeriesParams)
+}
+
+// GetHistoricDataForSymbols requests symbols one by one as alpha vantage doesn't support batch requests.
+// If the provider fails, the failure is returned for all remaining symbols
+func (c *alphaVantageClient) GetHistoricDataForSymbols(ctx context.Context, symbols []string, timeSeriesParams TimeSeriesParams) (map[string]SeriesResult, error) {
+	results := make(map[string]SeriesResult, len(symbols))
+	for i, symbol := range symbols {
+		timeSeries, err := c.GetHistoricDataForSymbol(ctx, symbol, timeSeriesParams)
+		if IsProviderFailure(err) {
+			if i == 0 {
+				return nil, err
+			}
+			for _, failed := range symbols[i:] {
+				results[failed] = SeriesResult{Err: err}
+			}
+			break
+		}
+		results[symbol] = SeriesResult{TimeSeries: timeSeries, Err: err}
+	}
+	return results, nil
+}
+
+func alphaVantageToTimeSeries(symbol string, interval string, results map[string]json.RawMessage, params TimeSeriesParams) (*TimeSeries, error) {
+	var meta map[string]string
+	var series map[string]map[string]model.Decimal
+	for key, value := range results {
+		var err error
+		if key == "Meta Data" {
+			err = json.Unmarshal(value, &meta)
+		} else {
+			err = json.Unmarshal(value, &series)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if meta == nil || series == nil {
+		return nil, UnknownAlphaVantageError
+	}
+	result := &TimeSeries{Meta: Meta{Symbol: symbol, Interval: interval}, Status: "ok"}
+	for key, value := range meta {
+		switch {
+		case strings.HasSuffix(key, "Time Zone"):
+			result.Meta.ExchangeTimezone = alphaVantageTimezone(value)
+		case strings.HasSuffix(key, "From Symbol"):
+			result.Meta.CurrencyBase = value
+		case strings.HasSuffix(key, "To Symbol"):
+			result.Meta.CurrencyQuote = value
+			result.Meta.Currency = value
+		}
+	}
+	result.Values = make([]SeriesValue, 0, len(series))
+	for datetime, value := range series {
+		if params.StartDate != "" && datetime < params.StartDate {
+			continue
+		}
+		if params.EndDate != "" && truncate(datetime, len(params.EndDate)) > params.EndDate {
+			continue
+		}
+		result.Values = append(result.Values, SeriesValue{
+			Datetime: datetime,
+			Open:     value["1. open"],
+			High:     value["2. high"],
+			Low:      value["3. low"],
+			Close:    value["4. close"],
+			Volume:   value["5. volume"],
+		})
+	}
+	sort.Slice(result.Values, func(i, j int) bool {
+		return result.Values[i].Datetime > result.Values[j].Datetime
+	})
+	if params.OutputSize > 0 && len(result.Values) > params.OutputSize {
+		result.Values = result.Values[:params.OutputSize]
+	}
+	if len(result.Values) == 0 {
+		return result, model.SymbolNotFound
+	}
+	return result, nil
+}
+
+// alphaVantageTimezone converts deprecated zone names returned by alpha vantage to IANA names
+func alphaVantageTimezone(timezone string) string {
+	if timezone == "US/Eastern" {
+		return "America/New_York"
+	}
+	return timezone
+}
+
+func truncate(s string, length int) string {
+	if len(s) > length {
+		return s[:length]
+	}
+	return s
+}
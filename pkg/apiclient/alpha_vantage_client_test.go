@@ -0,0 +1,134 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestAlphaVantageGetHistoricDataForSymbol(t *testing.T) {
+	for _, tt := range alphaVantageTestData {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/query", r.URL.Path, "Path should be equal")
+				assert.Equal(t, tt.expectedQuery, r.URL.RawQuery, "Query should be equal")
+				w.WriteHeader(tt.receivedCode)
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+			client := NewAlphaVantageClient("test", server.URL, time.Second)
+			timeSeries, err := client.GetHistoricDataForSymbol(context.TODO(), tt.symbol, tt.params)
+			assert.Equal(t, tt.expected, timeSeries, "TimeSeries should be equal")
+			assert.ErrorIs(t, err, tt.expectedError, "Error should be equal")
+		})
+	}
+}
+
+const alphaVantageDaily = `{
+    "Meta Data": {
+        "1. Information": "Daily Prices (open, high, low, close) and Volumes",
+        "2. Symbol": "IBM",
+        "3. Last Refreshed": "2023-06-02",
+        "4. Output Size": "Compact",
+        "5. Time Zone": "US/Eastern"
+    },
+    "Time Series (Daily)": {
+        "2023-06-01": {"1. open": "128.4400", "2. high": "130.1450", "3. low": "127.7800", "4. close": "129.8200", "5. volume": "4136086"},
+        "2023-06-02": {"1. open": "130.3800", "2. high": "133.1200", "3. low": "130.1500", "4. close": "132.4200", "5. volume": "5375796"},
+        "2023-05-31": {"1. open": "128.5100", "2. high": "129.2400", "3. low": "126.6500", "4. close": "128.5900", "5. volume": "11086137"}
+    }
+}`
+
+const alphaVantageFxDaily = `{
+    "Meta Data": {
+        "1. Information": "Forex Daily Prices (open, high, low, close)",
+        "2. From Symbol": "EUR",
+        "3. To Symbol": "USD",
+        "4. Output Size": "Compact",
+        "5. Last Refreshed": "2023-06-02 21:55:00",
+        "6. Time Zone": "UTC"
+    },
+    "Time Series FX (Daily)": {
+        "2023-06-02": {"1. open": "1.07630", "2. high": "1.07790", "3. low": "1.06920", "4. close": "1.07080"}
+    }
+}`
+
+var ibmMeta = Meta{Symbol: "IBM", Interval: "1day", ExchangeTimezone: "America/New_York"}
+
+var alphaVantageTestData = []struct {
+	name          string
+	symbol        string
+	params        TimeSeriesParams
+	expectedQuery string
+	receivedCode  int
+	response      string
+	expected      *TimeSeries
+	expectedError error
+}{
+	{
+		utils.TestName("Daily compact"), "IBM", TimeSeriesParams{OutputSize: 2},
+		"apikey=test&function=TIME_SERIES_DAILY&symbol=IBM", 200, alphaVantageDaily,
+		&TimeSeries{Meta: ibmMeta, Status: "ok", Values: []SeriesValue{
+			{Datetime: "2023-06-02", Open: "130.3800", High: "133.1200", Low: "130.1500", Close: "132.4200", Volume: "5375796"},
+			{Datetime: "2023-06-01", Open: "128.4400", High: "130.1450", Low: "127.7800", Close: "129.8200", Volume: "4136086"},
+		}},
+		nil,
+	},
+	{
+		utils.TestName("Daily date range"), "IBM", TimeSeriesParams{StartDate: "2023-05-31", EndDate: "2023-06-01"},
+		"apikey=test&function=TIME_SERIES_DAILY&outputsize=full&symbol=IBM", 200, alphaVantageDaily,
+		&TimeSeries{Meta: ibmMeta, Status: "ok", Values: []SeriesValue{
+			{Datetime: "2023-06-01", Open: "128.4400", High: "130.1450", Low: "127.7800", Close: "129.8200", Volume: "4136086"},
+			{Datetime: "2023-05-31", Open: "128.5100", High: "129.2400", Low: "126.6500", Close: "128.5900", Volume: "11086137"},
+		}},
+		nil,
+	},
+	{
+		utils.TestName("Fx pair"), "EUR/USD", TimeSeriesParams{OutputSize: 30},
+		"apikey=test&from_symbol=EUR&function=FX_DAILY&to_symbol=USD", 200, alphaVantageFxDaily,
+		&TimeSeries{
+			Meta:   Meta{Symbol: "EUR/USD", Interval: "1day", Currency: "USD", CurrencyBase: "EUR", CurrencyQuote: "USD", ExchangeTimezone: "UTC"},
+			Status: "ok",
+			Values: []SeriesValue{{Datetime: "2023-06-02", Open: "1.07630", High: "1.07790", Low: "1.06920", Close: "1.07080"}},
+		},
+		nil,
+	},
+	{
+		utils.TestName("Intraday interval"), "IBM", TimeSeriesParams{Interval: "1h", OutputSize: 10},
+		"apikey=test&function=TIME_SERIES_INTRADAY&interval=60min&symbol=IBM", 200,
+		`{"Meta Data": {"2. Symbol": "IBM", "6. Time Zone": "US/Eastern"}, "Time Series (60min)": {"2023-06-02 19:00:00": {"1. open": "132.4", "2. high": "132.5", "3. low": "132.3", "4. close": "132.42", "5. volume": "100"}}}`,
+		&TimeSeries{Meta: Meta{Symbol: "IBM", Interval: "1h", ExchangeTimezone: "America/New_York"}, Status: "ok", Values: []SeriesValue{
+			{Datetime: "2023-06-02 19:00:00", Open: "132.4", High: "132.5", Low: "132.3", Close: "132.42", Volume: "100"},
+		}},
+		nil,
+	},
+	{
+		utils.TestName("No values in range"), "IBM", TimeSeriesParams{StartDate: "2023-07-01"},
+		"apikey=test&function=TIME_SERIES_DAILY&outputsize=full&symbol=IBM", 200, alphaVantageDaily,
+		&TimeSeries{Meta: ibmMeta, Status: "ok", Values: []SeriesValue{}},
+		model.SymbolNotFound,
+	},
+	{
+		utils.TestName("Invalid symbol"), "INVALID", TimeSeriesParams{OutputSize: 1},
+		"apikey=test&function=TIME_SERIES_DAILY&symbol=INVALID", 200,
+		`{"Error Message": "Invalid API call. Please retry or visit the documentation (https://www.alphavantage.co/documentation/) for TIME_SERIES_DAILY."}`,
+		nil, model.SymbolNotFound,
+	},
+	{
+		utils.TestName("Rate limit"), "IBM", TimeSeriesParams{OutputSize: 1},
+		"apikey=test&function=TIME_SERIES_DAILY&symbol=IBM", 200,
+		`{"Note": "Our standard API call frequency is 5 calls per minute"}`,
+		nil, &AlphaVantageApiKeyError{errors.New("message: Our standard API call frequency is 5 calls per minute")},
+	},
+	{
+		utils.TestName("Status code 500"), "IBM", TimeSeriesParams{OutputSize: 1},
+		"apikey=test&function=TIME_SERIES_DAILY&symbol=IBM", 500, "",
+		nil, UnknownAlphaVantageError,
+	},
+}
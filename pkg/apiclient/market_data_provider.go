@@ -0,0 +1,58 @@
+package apiclient
+
+import (
+	"context"
+	"errors"
+	"net/url"
+)
+
+// TimeSeriesEndpoint is the name of historical prices request used for api credits accounting
+const TimeSeriesEndpoint = "time_series"
+
+// SymbolSearchEndpoint is the name of symbol search request used for api credits accounting
+const SymbolSearchEndpoint = "symbol_search"
+
+// MarketDataProvider is a vendor independent source of historical prices.
+// Implementations return model.SymbolNotFound if provider doesn't have data for requested symbol
+type MarketDataProvider interface {
+	Name() string
+	GetHistoricDataForSymbol(ctx context.Context, symbol string, timeSeriesParams TimeSeriesParams) (*TimeSeries, error)
+	// GetHistoricDataForSymbols returns results for every requested symbol.
+	// Error is returned only if the whole request failed
+	GetHistoricDataForSymbols(ctx context.Context, symbols []string, timeSeriesParams TimeSeriesParams) (map[string]SeriesResult, error)
+}
+
+// SymbolSearcher finds instruments by partial symbol or name in the provider catalogue
+type SymbolSearcher interface {
+	SearchSymbols(ctx context.Context, query string, outputSize int) ([]SymbolSearchResult, error)
+}
+
+// IsApiKeyError reports whether err is caused by invalid or exhausted api key
+func IsApiKeyError(err error) bool {
+	var twelveDataKeyError *TwelveDataApiKeyError
+	var alphaVantageKeyError *AlphaVantageApiKeyError
+	return errors.As(err, &twelveDataKeyError) || errors.As(err, &alphaVantageKeyError)
+}
+
+// IsInvalidApiKey reports whether api key is rejected by provider and shouldn't be used anymore
+func IsInvalidApiKey(err error) bool {
+	var invalid interface{ InvalidKey() bool }
+	return errors.As(err, &invalid) && invalid.InvalidKey()
+}
+
+// IsProviderFailure reports whether err is caused by provider itself (api key, limits, unknown errors or transport)
+// and the request can be retried with another provider
+func IsProviderFailure(err error) bool {
+	var transportError *url.Error
+	return errors.Is(err, UnknownTwelveDataError) ||
+		errors.Is(err, UnknownAlphaVantageError) ||
+		IsApiKeyError(err) ||
+		errors.As(err, &transportError) ||
+		IsTemporary(err)
+}
+
+// IsTemporary reports whether err is temporary unavailability of the provider like exceeded rate limit
+func IsTemporary(err error) bool {
+	var temporary interface{ Temporary() bool }
+	return errors.As(err, &temporary) && temporary.Temporary()
+}
@@ -1,5 +1,7 @@
 package apiclient
 
+import "github.com/galushkoart/finance-api/internal/model"
+
 type TimeSeries struct {
 	Meta    Meta          `json:"meta,omitempty"`
 	Values  []SeriesValue `json:"values,omitempty"`
@@ -18,13 +20,38 @@ type Meta struct {
 	Exchange         string `json:"exchange,omitempty"`
 	MicCode          string `json:"mic_code,omitempty"`
 	Type             string `json:"type,omitempty"`
+	Provider         string `json:"provider,omitempty"`
 }
 
 type SeriesValue struct {
-	Datetime string `json:"datetime,omitempty"`
-	Open     string `json:"open,omitempty"`
-	High     string `json:"high,omitempty"`
-	Low      string `json:"low,omitempty"`
-	Close    string `json:"close,omitempty"`
-	Volume   string `json:"volume,omitempty"`
+	Datetime string        `json:"datetime,omitempty"`
+	Open     model.Decimal `json:"open,omitempty"`
+	High     model.Decimal `json:"high,omitempty"`
+	Low      model.Decimal `json:"low,omitempty"`
+	Close    model.Decimal `json:"close,omitempty"`
+	Volume   model.Decimal `json:"volume,omitempty"`
+}
+
+// SeriesResult is time series or error of a single symbol in batch response
+type SeriesResult struct {
+	TimeSeries *TimeSeries
+	Err        error
+}
+
+type SymbolSearch struct {
+	Data    []SymbolSearchResult `json:"data,omitempty"`
+	Code    int                  `json:"code,omitempty"`
+	Message string               `json:"message,omitempty"`
+	Status  string               `json:"status,omitempty"`
+}
+
+type SymbolSearchResult struct {
+	Symbol           string `json:"symbol,omitempty"`
+	InstrumentName   string `json:"instrument_name,omitempty"`
+	Exchange         string `json:"exchange,omitempty"`
+	MicCode          string `json:"mic_code,omitempty"`
+	ExchangeTimezone string `json:"exchange_timezone,omitempty"`
+	InstrumentType   string `json:"instrument_type,omitempty"`
+	Country          string `json:"country,omitempty"`
+	Currency         string `json:"currency,omitempty"`
 }
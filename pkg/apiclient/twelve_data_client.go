@@ -10,13 +10,21 @@ import (
 	"net/http"
 	"net/url"
 	"regexp"
+	"strconv"
+	"strings"
 	"time"
 )
 
 var UnknownTwelveDataError = errors.New("unknown twelve data error")
 
 type TwelveDataApiKeyError struct {
-	Err error
+	Err  error
+	Code int
+}
+
+// InvalidKey reports whether api key is rejected by TwelveData rather than exhausted
+func (e *TwelveDataApiKeyError) InvalidKey() bool {
+	return e.Code == 401
 }
 
 func (e *TwelveDataApiKeyError) Is(target error) bool {
@@ -37,8 +45,30 @@ type twelveDataClient struct {
 	host   string
 }
 
+const TwelveDataProvider = "twelveData"
+
+// TwelveDataCredits is the number of api credits TwelveData charges per symbol for each endpoint
+var TwelveDataCredits = map[string]int{
+	TimeSeriesEndpoint:   1,
+	SymbolSearchEndpoint: 1,
+}
+
+// MaxOutputSize is the max number of values TwelveData returns in a single time_series response
+const MaxOutputSize = 5000
+
+// TimeSeriesParams are optional parameters of time_series request. Zero values are omitted, default interval is 1day
+type TimeSeriesParams struct {
+	Interval   string
+	OutputSize int
+	StartDate  string
+	EndDate    string
+}
+
 type TwelveDataClient interface {
-	GetHistoricDataForSymbol(ctx context.Context, symbol string) (*TimeSeries, error)
+	Name() string
+	GetHistoricDataForSymbol(ctx context.Context, symbol string, timeSeriesParams TimeSeriesParams) (*TimeSeries, error)
+	GetHistoricDataForSymbols(ctx context.Context, symbols []string, timeSeriesParams TimeSeriesParams) (map[string]SeriesResult, error)
+	SearchSymbols(ctx context.Context, query string, outputSize int) ([]SymbolSearchResult, error)
 }
 
 func NewTwelveDataClient(apiKey string, apiHost string, clientTimout time.Duration) TwelveDataClient {
@@ -56,6 +86,10 @@ func NewTwelveDataClient(apiKey string, apiHost string, clientTimout time.Durati
 	}
 }
 
+func (c *twelveDataClient) Name() string {
+	return TwelveDataProvider
+}
+
 func (c *twelveDataClient) getUrl(resource string, params *url.Values) string {
 	u, _ := url.ParseRequestURI(c.host)
 	u.Path = resource
@@ -64,12 +98,111 @@ func (c *twelveDataClient) getUrl(resource string, params *url.Values) string {
 	return result
 }
 
-func (c *twelveDataClient) GetHistoricDataForSymbol(ctx context.Context, symbol string) (*TimeSeries, error) {
+func (c *twelveDataClient) GetHistoricDataForSymbol(ctx context.Context, symbol string, timeSeriesParams TimeSeriesParams) (*TimeSeries, error) {
+	responseBody, err := c.getTimeSeries(ctx, symbol, timeSeriesParams)
+	if err != nil {
+		return nil, err
+	}
+	var results TimeSeries
+	if err = json.Unmarshal(responseBody, &results); err != nil {
+		return nil, err
+	}
+	if err = twelveDataError(results.Status, results.Code, results.Message); err != nil {
+		return &results, err
+	}
+	return &results, nil
+}
+
+// GetHistoricDataForSymbols requests time series of several symbols in a single batch request.
+// Errors of particular symbols are returned in results, error is returned only if the whole request failed
+func (c *twelveDataClient) GetHistoricDataForSymbols(ctx context.Context, symbols []string, timeSeriesParams TimeSeriesParams) (map[string]SeriesResult, error) {
+	if len(symbols) == 1 {
+		timeSeries, err := c.GetHistoricDataForSymbol(ctx, symbols[0], timeSeriesParams)
+		if IsProviderFailure(err) {
+			return nil, err
+		}
+		return map[string]SeriesResult{symbols[0]: {TimeSeries: timeSeries, Err: err}}, nil
+	}
+	responseBody, err := c.getTimeSeries(ctx, strings.Join(symbols, ","), timeSeriesParams)
+	if err != nil {
+		return nil, err
+	}
+	var batch map[string]json.RawMessage
+	if err = json.Unmarshal(responseBody, &batch); err != nil {
+		return nil, err
+	}
+	if _, failed := batch["status"]; failed {
+		var results TimeSeries
+		if err = json.Unmarshal(responseBody, &results); err != nil {
+			return nil, err
+		}
+		if err = twelveDataError(results.Status, results.Code, results.Message); err != nil {
+			return nil, err
+		}
+		return nil, UnknownTwelveDataError
+	}
+	results := make(map[string]SeriesResult, len(symbols))
+	for _, symbol := range symbols {
+		rawSeries, ok := batch[symbol]
+		if !ok {
+			results[symbol] = SeriesResult{Err: model.SymbolNotFound}
+			continue
+		}
+		var timeSeries TimeSeries
+		if err = json.Unmarshal(rawSeries, &timeSeries); err != nil {
+			results[symbol] = SeriesResult{Err: err}
+			continue
+		}
+		results[symbol] = SeriesResult{TimeSeries: &timeSeries, Err: twelveDataError(timeSeries.Status, timeSeries.Code, timeSeries.Message)}
+	}
+	return results, nil
+}
+
+func (c *twelveDataClient) getTimeSeries(ctx context.Context, symbol string, timeSeriesParams TimeSeriesParams) ([]byte, error) {
 	params := url.Values{}
 	params.Add("apikey", c.apiKey)
 	params.Add("symbol", symbol)
-	params.Add("interval", "1day")
-	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getUrl("time_series", &params), nil)
+	if timeSeriesParams.Interval != "" {
+		params.Add("interval", timeSeriesParams.Interval)
+	} else {
+		params.Add("interval", "1day")
+	}
+	if timeSeriesParams.OutputSize > 0 {
+		params.Add("outputsize", strconv.Itoa(timeSeriesParams.OutputSize))
+	}
+	if timeSeriesParams.StartDate != "" {
+		params.Add("start_date", timeSeriesParams.StartDate)
+	}
+	if timeSeriesParams.EndDate != "" {
+		params.Add("end_date", timeSeriesParams.EndDate)
+	}
+	return c.get(ctx, TimeSeriesEndpoint, &params)
+}
+
+// SearchSymbols requests symbols and instrument names matching query. Empty result is not an error
+func (c *twelveDataClient) SearchSymbols(ctx context.Context, query string, outputSize int) ([]SymbolSearchResult, error) {
+	params := url.Values{}
+	params.Add("apikey", c.apiKey)
+	params.Add("symbol", query)
+	if outputSize > 0 {
+		params.Add("outputsize", strconv.Itoa(outputSize))
+	}
+	responseBody, err := c.get(ctx, SymbolSearchEndpoint, &params)
+	if err != nil {
+		return nil, err
+	}
+	var results SymbolSearch
+	if err = json.Unmarshal(responseBody, &results); err != nil {
+		return nil, err
+	}
+	if err = twelveDataError(results.Status, results.Code, results.Message); err != nil {
+		return nil, err
+	}
+	return results.Data, nil
+}
+
+func (c *twelveDataClient) get(ctx context.Context, resource string, params *url.Values) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, c.getUrl(resource, params), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -84,17 +217,17 @@ func (c *twelveDataClient) GetHistoricDataForSymbol(ctx context.Context, symbol
 	if response.StatusCode != 200 {
 		return nil, UnknownTwelveDataError
 	}
-	var results TimeSeries
-	if err = json.Unmarshal(responseBody, &results); err != nil {
-		return nil, err
+	return responseBody, nil
+}
+
+func twelveDataError(status string, code int, message string) error {
+	if status != "error" {
+		return nil
 	}
-	if results.Status == "error" {
-		if results.Code == 400 || results.Code == 404 {
-			return &results, model.SymbolNotFound
-		} else if results.Code == 401 || results.Code == 429 {
-			return &results, &TwelveDataApiKeyError{Err: errors.New("message: " + results.Message)}
-		}
-		return &results, UnknownTwelveDataError
+	if code == 400 || code == 404 {
+		return model.SymbolNotFound
+	} else if code == 401 || code == 429 {
+		return &TwelveDataApiKeyError{Err: errors.New("message: " + message), Code: code}
 	}
-	return &results, nil
+	return UnknownTwelveDataError
 }
@@ -6,7 +6,9 @@ import (
 	"github.com/galushkoart/finance-api/internal/model"
 	"github.com/galushkoart/finance-api/pkg/utils"
 	"github.com/stretchr/testify/assert"
+	"io"
 	"net/http"
+	"strings"
 	"testing"
 )
 
@@ -20,13 +22,100 @@ func TestGetHistoricDataForSymbol(t *testing.T) {
 						Body:       utils.BodyFromStruct(tt.expected),
 					}, tt.transportError
 				})}
-			timeSeries, returnError := client.GetHistoricDataForSymbol(context.TODO(), "TEST")
+			timeSeries, returnError := client.GetHistoricDataForSymbol(context.TODO(), "TEST", TimeSeriesParams{})
 			assert.Equal(t, tt.expected, timeSeries, "TimeSeries should be equal")
 			assert.ErrorIs(t, returnError, tt.expectedError, "Error should be equal")
 		})
 	}
 }
 
+func TestGetHistoricDataForSymbolParams(t *testing.T) {
+	for _, tt := range paramsTestData {
+		t.Run(tt.name, func(t *testing.T) {
+			client := twelveDataClient{host: "http://localhost", apiKey: "test",
+				c: utils.MockClient(func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, tt.expectedQuery, r.URL.RawQuery, "Query should be equal")
+					return &http.Response{StatusCode: 200, Body: utils.BodyFromStruct(TimeSeries{Status: "ok"})}, nil
+				})}
+			_, err := client.GetHistoricDataForSymbol(context.TODO(), "TEST", tt.params)
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestGetHistoricDataForSymbols(t *testing.T) {
+	for _, tt := range batchTestData {
+		t.Run(tt.name, func(t *testing.T) {
+			client := twelveDataClient{host: "http://localhost", apiKey: "test",
+				c: utils.MockClient(func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, tt.expectedQuery, r.URL.RawQuery, "Query should be equal")
+					return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(tt.response))}, nil
+				})}
+			results, err := client.GetHistoricDataForSymbols(context.TODO(), tt.symbols, TimeSeriesParams{OutputSize: 1})
+			assert.Equal(t, tt.expected, results, "Results should be equal")
+			assert.ErrorIs(t, err, tt.expectedError, "Error should be equal")
+		})
+	}
+}
+
+var batchTestData = []struct {
+	name          string
+	symbols       []string
+	expectedQuery string
+	response      string
+	expected      map[string]SeriesResult
+	expectedError error
+}{
+	{
+		utils.TestName("Batch with invalid symbol"), []string{"AAPL", "EUR/USD", "INVALID"},
+		"apikey=test&interval=1day&outputsize=1&symbol=AAPL%2CEUR%2FUSD%2CINVALID",
+		`{"AAPL": {"meta": {"symbol": "AAPL"}, "values": [{"datetime": "2023-06-02", "close": "180.95"}], "status": "ok"},
+		"EUR/USD": {"meta": {"symbol": "EUR/USD"}, "values": [{"datetime": "2023-06-02", "close": "1.0708"}], "status": "ok"},
+		"INVALID": {"code": 400, "message": "symbol not found", "status": "error"}}`,
+		map[string]SeriesResult{
+			"AAPL":    {TimeSeries: &TimeSeries{Meta: Meta{Symbol: "AAPL"}, Values: []SeriesValue{{Datetime: "2023-06-02", Close: "180.95"}}, Status: "ok"}},
+			"EUR/USD": {TimeSeries: &TimeSeries{Meta: Meta{Symbol: "EUR/USD"}, Values: []SeriesValue{{Datetime: "2023-06-02", Close: "1.0708"}}, Status: "ok"}},
+			"INVALID": {TimeSeries: &TimeSeries{Code: 400, Message: "symbol not found", Status: "error"}, Err: model.SymbolNotFound},
+		},
+		nil,
+	},
+	{
+		utils.TestName("Batch with missing symbol"), []string{"AAPL", "MISSING"},
+		"apikey=test&interval=1day&outputsize=1&symbol=AAPL%2CMISSING",
+		`{"AAPL": {"meta": {"symbol": "AAPL"}, "status": "ok"}}`,
+		map[string]SeriesResult{
+			"AAPL":    {TimeSeries: &TimeSeries{Meta: Meta{Symbol: "AAPL"}, Status: "ok"}},
+			"MISSING": {Err: model.SymbolNotFound},
+		},
+		nil,
+	},
+	{
+		utils.TestName("Batch rate limit"), []string{"AAPL", "MSFT"},
+		"apikey=test&interval=1day&outputsize=1&symbol=AAPL%2CMSFT",
+		`{"code": 429, "message": "Overuse", "status": "error"}`,
+		nil,
+		&TwelveDataApiKeyError{Err: errors.New("message: Overuse"), Code: 429},
+	},
+	{
+		utils.TestName("Single symbol"), []string{"AAPL"},
+		"apikey=test&interval=1day&outputsize=1&symbol=AAPL",
+		`{"meta": {"symbol": "AAPL"}, "status": "ok"}`,
+		map[string]SeriesResult{"AAPL": {TimeSeries: &TimeSeries{Meta: Meta{Symbol: "AAPL"}, Status: "ok"}}},
+		nil,
+	},
+}
+
+var paramsTestData = []struct {
+	name          string
+	params        TimeSeriesParams
+	expectedQuery string
+}{
+	{utils.TestName("Default params"), TimeSeriesParams{}, "apikey=test&interval=1day&symbol=TEST"},
+	{utils.TestName("Output size"), TimeSeriesParams{OutputSize: 365}, "apikey=test&interval=1day&outputsize=365&symbol=TEST"},
+	{utils.TestName("Date range"), TimeSeriesParams{OutputSize: MaxOutputSize, StartDate: "2020-01-01", EndDate: "2023-06-02"}, "apikey=test&end_date=2023-06-02&interval=1day&outputsize=5000&start_date=2020-01-01&symbol=TEST"},
+	{utils.TestName("Intraday interval"), TimeSeriesParams{Interval: "5min", EndDate: "2023-06-02 15:30:00"}, "apikey=test&end_date=2023-06-02+15%3A30%3A00&interval=5min&symbol=TEST"},
+}
+
 var transportError = errors.New("transport error")
 
 var testData = []struct {
@@ -72,11 +161,52 @@ var testData = []struct {
 		nil,
 	},
 	{utils.TestName("Error from TwelveData 400"), &TimeSeries{Code: 400, Status: "error", Message: "Some error"}, 200, nil, model.SymbolNotFound},
-	{utils.TestName("Error from TwelveData 401"), &TimeSeries{Code: 401, Status: "error", Message: "API key is invalid"}, 200, nil, &TwelveDataApiKeyError{errors.New("message: API key is invalid")}},
+	{utils.TestName("Error from TwelveData 401"), &TimeSeries{Code: 401, Status: "error", Message: "API key is invalid"}, 200, nil, &TwelveDataApiKeyError{Err: errors.New("message: API key is invalid"), Code: 401}},
 	{utils.TestName("Error from TwelveData 404"), &TimeSeries{Code: 404, Status: "error", Message: "Not Found"}, 200, nil, model.SymbolNotFound},
-	{utils.TestName("Error from TwelveData 429"), &TimeSeries{Code: 429, Status: "error", Message: "Overuse"}, 200, nil, &TwelveDataApiKeyError{errors.New("message: Overuse")}},
+	{utils.TestName("Error from TwelveData 429"), &TimeSeries{Code: 429, Status: "error", Message: "Overuse"}, 200, nil, &TwelveDataApiKeyError{Err: errors.New("message: Overuse"), Code: 429}},
 	{utils.TestName("Error from TwelveData 500"), &TimeSeries{Code: 500, Status: "error", Message: "Some error"}, 200, nil, UnknownTwelveDataError},
 	{utils.TestName("Empty body"), &TimeSeries{}, 200, nil, nil},
 	{utils.TestName("Status code 404"), nil, 404, nil, UnknownTwelveDataError},
 	{utils.TestName("Transport Error"), nil, 200, transportError, transportError},
 }
+
+func TestSearchSymbols(t *testing.T) {
+	for _, tt := range searchTestData {
+		t.Run(tt.name, func(t *testing.T) {
+			client := twelveDataClient{host: "http://localhost", apiKey: "test",
+				c: utils.MockClient(func(r *http.Request) (*http.Response, error) {
+					assert.Equal(t, "/symbol_search", r.URL.Path, "Path should be equal")
+					assert.Equal(t, "apikey=test&outputsize=2&symbol=app", r.URL.RawQuery, "Query should be equal")
+					return &http.Response{StatusCode: 200, Body: io.NopCloser(strings.NewReader(tt.response))}, nil
+				})}
+			results, err := client.SearchSymbols(context.TODO(), "app", 2)
+			assert.Equal(t, tt.expected, results, "Results should be equal")
+			assert.ErrorIs(t, err, tt.expectedError, "Error should be equal")
+		})
+	}
+}
+
+var searchTestData = []struct {
+	name          string
+	response      string
+	expected      []SymbolSearchResult
+	expectedError error
+}{
+	{
+		utils.TestName("Found symbols"),
+		`{"data": [{"symbol": "AAPL", "instrument_name": "Apple Inc", "exchange": "NASDAQ", "mic_code": "XNGS", "exchange_timezone": "America/New_York", "instrument_type": "Common Stock", "country": "United States", "currency": "USD"},
+		{"symbol": "APP", "instrument_name": "AppLovin Corp", "exchange": "NASDAQ", "mic_code": "XNGS", "instrument_type": "Common Stock", "country": "United States", "currency": "USD"}], "status": "ok"}`,
+		[]SymbolSearchResult{
+			{Symbol: "AAPL", InstrumentName: "Apple Inc", Exchange: "NASDAQ", MicCode: "XNGS", ExchangeTimezone: "America/New_York", InstrumentType: "Common Stock", Country: "United States", Currency: "USD"},
+			{Symbol: "APP", InstrumentName: "AppLovin Corp", Exchange: "NASDAQ", MicCode: "XNGS", InstrumentType: "Common Stock", Country: "United States", Currency: "USD"},
+		},
+		nil,
+	},
+	{
+		utils.TestName("Nothing found"), `{"data": [], "status": "ok"}`, []SymbolSearchResult{}, nil,
+	},
+	{
+		utils.TestName("Invalid api key"), `{"code": 401, "message": "Invalid key", "status": "error"}`,
+		nil, &TwelveDataApiKeyError{Err: errors.New("message: Invalid key"), Code: 401},
+	},
+}
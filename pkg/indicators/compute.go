@@ -0,0 +1,110 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	NameSMA       = "sma"
+	NameEMA       = "ema"
+	NameRSI       = "rsi"
+	NameMACD      = "macd"
+	NameBollinger = "bbands"
+)
+
+// Names of supported indicators
+var Names = []string{NameSMA, NameEMA, NameRSI, NameMACD, NameBollinger}
+
+// MaxPeriod is the max number of values any indicator can look back
+const MaxPeriod = 500
+
+// MaxStdDev is the max width of Bollinger bands in standard deviations
+const MaxStdDev = 10
+
+var UnknownIndicator = errors.New("unknown indicator")
+
+// Params of indicator. Period is used by SMA, EMA, RSI and Bollinger bands, Fast, Slow and Signal periods by MACD.
+// StdDev is the width of Bollinger bands in standard deviations
+type Params struct {
+	Period int     `json:"period,omitempty"`
+	Fast   int     `json:"fast,omitempty"`
+	Slow   int     `json:"slow,omitempty"`
+	Signal int     `json:"signal,omitempty"`
+	StdDev float64 `json:"stddev,omitempty"`
+}
+
+// DefaultParams returns commonly used params of the indicator
+func DefaultParams(name string) (Params, error) {
+	switch name {
+	case NameSMA, NameEMA:
+		return Params{Period: 20}, nil
+	case NameRSI:
+		return Params{Period: 14}, nil
+	case NameMACD:
+		return Params{Fast: 12, Slow: 26, Signal: 9}, nil
+	case NameBollinger:
+		return Params{Period: 20, StdDev: 2}, nil
+	}
+	return Params{}, fmt.Errorf("%w %s", UnknownIndicator, name)
+}
+
+// Validate checks that params used by the indicator are in range
+func (p Params) Validate(name string) error {
+	switch name {
+	case NameMACD:
+		for _, param := range []struct {
+			name  string
+			value int
+		}{{"fast", p.Fast}, {"slow", p.Slow}, {"signal", p.Signal}} {
+			if param.value < 1 || param.value > MaxPeriod {
+				return fmt.Errorf("'%s' must be a number between 1 and %d", param.name, MaxPeriod)
+			}
+		}
+		if p.Fast >= p.Slow {
+			return errors.New("'fast' must be less than 'slow'")
+		}
+	case NameSMA, NameEMA, NameRSI, NameBollinger:
+		if p.Period < 2 || p.Period > MaxPeriod {
+			return fmt.Errorf("'period' must be a number between 2 and %d", MaxPeriod)
+		}
+		if name == NameBollinger && (math.IsNaN(p.StdDev) || p.StdDev <= 0 || p.StdDev > MaxStdDev) {
+			return fmt.Errorf("'stddev' must be a positive number not greater than %d", MaxStdDev)
+		}
+	default:
+		return fmt.Errorf("%w %s", UnknownIndicator, name)
+	}
+	return nil
+}
+
+// Lookback returns the number of values preceding the first value of the indicator it needs to warm up.
+// It is at least the longest period of the indicator
+func (p Params) Lookback(name string) int {
+	if name == NameMACD {
+		return p.Slow + p.Signal
+	}
+	return p.Period
+}
+
+// Compute returns lines of the indicator by their names. SMA, EMA and RSI have a single line named after the indicator,
+// MACD has macd, signal and histogram lines, Bollinger bands have middle, upper and lower lines
+func Compute(name string, values []float64, params Params) (map[string][]float64, error) {
+	if err := params.Validate(name); err != nil {
+		return nil, err
+	}
+	switch name {
+	case NameSMA:
+		return map[string][]float64{name: SMA(values, params.Period)}, nil
+	case NameEMA:
+		return map[string][]float64{name: EMA(values, params.Period)}, nil
+	case NameRSI:
+		return map[string][]float64{name: RSI(values, params.Period)}, nil
+	case NameMACD:
+		macd, signal, histogram := MACD(values, params.Fast, params.Slow, params.Signal)
+		return map[string][]float64{"macd": macd, "signal": signal, "histogram": histogram}, nil
+	default:
+		middle, upper, lower := BollingerBands(values, params.Period, params.StdDev)
+		return map[string][]float64{"middle": middle, "upper": upper, "lower": lower}, nil
+	}
+}
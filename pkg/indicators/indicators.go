@@ -0,0 +1,116 @@
+// Package indicators computes technical indicators of price series.
+// Results are aligned with input values: value of the indicator at i is computed from values up to i inclusive,
+// values before the indicator has enough data are NaN
+package indicators
+
+import "math"
+
+func nanSeries(length int) []float64 {
+	result := make([]float64, length)
+	for i := range result {
+		result[i] = math.NaN()
+	}
+	return result
+}
+
+// SMA is simple moving average of the last period values
+func SMA(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	sum := 0.0
+	for i, value := range values {
+		sum += value
+		if i >= period {
+			sum -= values[i-period]
+		}
+		if i >= period-1 {
+			result[i] = sum / float64(period)
+		}
+	}
+	return result
+}
+
+// EMA is exponential moving average with 2/(period+1) smoothing seeded by SMA of the first period values
+func EMA(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	if len(values) < period {
+		return result
+	}
+	k := 2 / float64(period+1)
+	result[period-1] = SMA(values[:period], period)[period-1]
+	for i := period; i < len(values); i++ {
+		result[i] = values[i]*k + result[i-1]*(1-k)
+	}
+	return result
+}
+
+// RSI is relative strength index with Wilder's smoothing of average gain and loss
+func RSI(values []float64, period int) []float64 {
+	result := nanSeries(len(values))
+	if len(values) <= period {
+		return result
+	}
+	var gain, loss float64
+	for i := 1; i <= period; i++ {
+		change := values[i] - values[i-1]
+		if change > 0 {
+			gain += change
+		} else {
+			loss -= change
+		}
+	}
+	gain, loss = gain/float64(period), loss/float64(period)
+	result[period] = rsi(gain, loss)
+	for i := period + 1; i < len(values); i++ {
+		change := values[i] - values[i-1]
+		gain, loss = gain*float64(period-1), loss*float64(period-1)
+		if change > 0 {
+			gain += change
+		} else {
+			loss -= change
+		}
+		gain, loss = gain/float64(period), loss/float64(period)
+		result[i] = rsi(gain, loss)
+	}
+	return result
+}
+
+func rsi(gain, loss float64) float64 {
+	if loss == 0 {
+		return 100
+	}
+	return 100 - 100/(1+gain/loss)
+}
+
+// MACD returns difference of fast and slow EMA, its signal EMA and histogram as difference of MACD and signal
+func MACD(values []float64, fast, slow, signal int) (macd, signalLine, histogram []float64) {
+	fastEMA, slowEMA := EMA(values, fast), EMA(values, slow)
+	macd = nanSeries(len(values))
+	for i := range values {
+		macd[i] = fastEMA[i] - slowEMA[i]
+	}
+	signalLine, histogram = nanSeries(len(values)), nanSeries(len(values))
+	if len(values) < slow {
+		return macd, signalLine, histogram
+	}
+	// signal is computed from defined MACD values only, which start with slow EMA
+	copy(signalLine[slow-1:], EMA(macd[slow-1:], signal))
+	for i := range values {
+		histogram[i] = macd[i] - signalLine[i]
+	}
+	return macd, signalLine, histogram
+}
+
+// BollingerBands returns SMA of the period as middle band and bands deviated from it by k population standard deviations
+func BollingerBands(values []float64, period int, k float64) (middle, upper, lower []float64) {
+	middle = SMA(values, period)
+	upper, lower = nanSeries(len(values)), nanSeries(len(values))
+	for i := period - 1; i < len(values); i++ {
+		variance := 0.0
+		for _, value := range values[i-period+1 : i+1] {
+			variance += (value - middle[i]) * (value - middle[i])
+		}
+		deviation := math.Sqrt(variance / float64(period))
+		upper[i], lower[i] = middle[i]+k*deviation, middle[i]-k*deviation
+	}
+	return middle, upper, lower
+}
@@ -0,0 +1,71 @@
+package indicators
+
+import (
+	"github.com/stretchr/testify/assert"
+	"math"
+	"testing"
+)
+
+var nan = math.NaN()
+
+func TestIndicators(t *testing.T) {
+	rising := []float64{1, 2, 3, 4, 5, 6}
+	macd, signal, histogram := MACD(rising, 2, 3, 2)
+	middle, upper, lower := BollingerBands([]float64{1, 2, 3, 4}, 3, 2)
+	for _, td := range []struct {
+		name     string
+		actual   []float64
+		expected []float64
+	}{
+		{name: "sma", actual: SMA(rising, 3), expected: []float64{nan, nan, 2, 3, 4, 5}},
+		{name: "ema", actual: EMA([]float64{1, 2, 3, 5, 4}, 3), expected: []float64{nan, nan, 2, 3.5, 3.75}},
+		{name: "rsi of rising prices", actual: RSI(rising, 3), expected: []float64{nan, nan, nan, 100, 100, 100}},
+		{name: "rsi", actual: RSI([]float64{10, 11, 10, 12, 11}, 2), expected: []float64{nan, nan, 50, 250.0 / 3, 50}},
+		{name: "macd", actual: macd, expected: []float64{nan, nan, 0.5, 0.5, 0.5, 0.5}},
+		{name: "macd signal", actual: signal, expected: []float64{nan, nan, nan, 0.5, 0.5, 0.5}},
+		{name: "macd histogram", actual: histogram, expected: []float64{nan, nan, nan, 0, 0, 0}},
+		{name: "bollinger middle band", actual: middle, expected: []float64{nan, nan, 2, 3}},
+		{name: "bollinger upper band", actual: upper, expected: []float64{nan, nan, 2 + 2*math.Sqrt(2.0/3), 3 + 2*math.Sqrt(2.0/3)}},
+		{name: "bollinger lower band", actual: lower, expected: []float64{nan, nan, 2 - 2*math.Sqrt(2.0/3), 3 - 2*math.Sqrt(2.0/3)}},
+		{name: "sma of short series", actual: SMA([]float64{1, 2}, 3), expected: []float64{nan, nan}},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			assert.Len(t, td.actual, len(td.expected), "Indicator should be aligned with values")
+			for i := range td.expected {
+				if math.IsNaN(td.expected[i]) {
+					assert.True(t, math.IsNaN(td.actual[i]), "Value %d should be undefined", i)
+				} else {
+					assert.InDelta(t, td.expected[i], td.actual[i], 1e-9, "Value %d should be equal", i)
+				}
+			}
+		})
+	}
+}
+
+func TestParamsValidate(t *testing.T) {
+	for _, td := range []struct {
+		name          string
+		indicator     string
+		params        Params
+		expectedError string
+	}{
+		{name: "valid sma", indicator: NameSMA, params: Params{Period: 20}},
+		{name: "too short period", indicator: NameRSI, params: Params{Period: 1}, expectedError: "'period' must be a number between 2 and 500"},
+		{name: "bollinger without width", indicator: NameBollinger, params: Params{Period: 20}, expectedError: "'stddev' must be a positive number not greater than 10"},
+		{name: "bollinger with too wide bands", indicator: NameBollinger, params: Params{Period: 20, StdDev: 11}, expectedError: "'stddev' must be a positive number not greater than 10"},
+		{name: "bollinger with infinite width", indicator: NameBollinger, params: Params{Period: 20, StdDev: math.Inf(1)}, expectedError: "'stddev' must be a positive number not greater than 10"},
+		{name: "bollinger with NaN width", indicator: NameBollinger, params: Params{Period: 20, StdDev: math.NaN()}, expectedError: "'stddev' must be a positive number not greater than 10"},
+		{name: "macd without signal", indicator: NameMACD, params: Params{Fast: 12, Slow: 26}, expectedError: "'signal' must be a number between 1 and 500"},
+		{name: "macd with fast slower than slow", indicator: NameMACD, params: Params{Fast: 26, Slow: 12, Signal: 9}, expectedError: "'fast' must be less than 'slow'"},
+		{name: "unknown indicator", indicator: "vwap", params: Params{Period: 20}, expectedError: "unknown indicator vwap"},
+	} {
+		t.Run(td.name, func(t *testing.T) {
+			err := td.params.Validate(td.indicator)
+			if td.expectedError == "" {
+				assert.NoError(t, err, "Params should be valid")
+			} else {
+				assert.EqualError(t, err, td.expectedError, "Errors should be equal")
+			}
+		})
+	}
+}
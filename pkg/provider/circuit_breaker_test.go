@@ -0,0 +1,50 @@
+package provider
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestCircuitBreaker(t *testing.T) {
+	now := time.Date(2023, 6, 2, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(2, time.Minute)
+	breaker.now = func() time.Time { return now }
+
+	assert.True(t, breaker.Allow(), "Closed breaker should allow requests")
+	breaker.Failure()
+	assert.Equal(t, "closed", breaker.State(), "Breaker should stay closed before threshold")
+	breaker.Failure()
+	assert.Equal(t, "open", breaker.State(), "Breaker should open on threshold")
+	assert.False(t, breaker.Allow(), "Open breaker should reject requests")
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow(), "Breaker should allow trial request after timeout")
+	assert.Equal(t, "half-open", breaker.State(), "Breaker should be half-open")
+	assert.False(t, breaker.Allow(), "Half-open breaker should allow only one trial request")
+	breaker.Failure()
+	assert.Equal(t, "open", breaker.State(), "Failed trial should open breaker")
+
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow(), "Breaker should allow trial request after timeout")
+	breaker.Success()
+	assert.Equal(t, "closed", breaker.State(), "Successful trial should close breaker")
+	breaker.Failure()
+	assert.Equal(t, "closed", breaker.State(), "Failures should be reset after success")
+}
+
+func TestCircuitBreakerRelease(t *testing.T) {
+	now := time.Date(2023, 6, 2, 12, 0, 0, 0, time.UTC)
+	breaker := NewCircuitBreaker(1, time.Minute)
+	breaker.now = func() time.Time { return now }
+	breaker.Failure()
+	now = now.Add(time.Minute)
+	assert.True(t, breaker.Allow(), "Breaker should allow trial request after timeout")
+	breaker.Release()
+	assert.Equal(t, "open", breaker.State(), "Released trial should open breaker")
+	assert.True(t, breaker.Allow(), "Breaker should allow new trial request after release")
+	breaker.Release()
+	breaker.Success()
+	breaker.Release()
+	assert.Equal(t, "closed", breaker.State(), "Release should not change closed breaker")
+}
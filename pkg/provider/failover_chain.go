@@ -0,0 +1,140 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/pkg/apiclient"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"time"
+)
+
+const ChainProvider = "chain"
+
+var NoProviderAvailable = errors.New("no market data provider available")
+
+type chainLink struct {
+	provider apiclient.MarketDataProvider
+	breaker  *CircuitBreaker
+}
+
+// FailoverChain requests providers in the configured order and falls through to the next one
+// when provider fails with api key, limit, unknown or transport error. Every provider has its own circuit breaker
+type FailoverChain struct {
+	links []chainLink
+	log   zerolog.Logger
+}
+
+func NewFailoverChain(failureThreshold int, openTimeout time.Duration, providers ...apiclient.MarketDataProvider) *FailoverChain {
+	links := make([]chainLink, len(providers))
+	for i, provider := range providers {
+		links[i] = chainLink{provider: provider, breaker: NewCircuitBreaker(failureThreshold, openTimeout)}
+	}
+	return &FailoverChain{links: links, log: log.With().Str("from", "failoverChain").Logger()}
+}
+
+func (c *FailoverChain) Name() string {
+	return ChainProvider
+}
+
+// GetHistoricDataForSymbol returns time series of the first healthy provider.
+// Meta.Provider of the result contains the name of the provider which supplied the values
+func (c *FailoverChain) GetHistoricDataForSymbol(ctx context.Context, symbol string, params apiclient.TimeSeriesParams) (*apiclient.TimeSeries, error) {
+	lastErr := NoProviderAvailable
+	for _, link := range c.links {
+		name := link.provider.Name()
+		if !link.breaker.Allow() {
+			c.log.Debug().Msgf("Circuit breaker of %s is open. Skipping", name)
+			continue
+		}
+		result, err := link.provider.GetHistoricDataForSymbol(ctx, symbol, params)
+		if ctx.Err() != nil {
+			link.breaker.Release()
+			return result, err
+		}
+		if apiclient.IsTemporary(err) {
+			link.breaker.Release()
+			c.log.Info().Err(err).Msgf("Provider %s is temporary unavailable for %s symbol", name, symbol)
+			lastErr = err
+			continue
+		}
+		if err != nil && apiclient.IsProviderFailure(err) {
+			link.breaker.Failure()
+			c.log.Warn().Err(err).Msgf("Provider %s failed for %s symbol. Circuit breaker is %s", name, symbol, link.breaker.State())
+			lastErr = err
+			continue
+		}
+		link.breaker.Success()
+		if result != nil {
+			result.Meta.Provider = name
+		}
+		return result, err
+	}
+	return nil, lastErr
+}
+
+// GetHistoricDataForSymbols merges results of providers: symbols failed with provider failure
+// are requested from the next provider. Error is returned only if no provider returned results
+func (c *FailoverChain) GetHistoricDataForSymbols(ctx context.Context, symbols []string, params apiclient.TimeSeriesParams) (map[string]apiclient.SeriesResult, error) {
+	results := make(map[string]apiclient.SeriesResult, len(symbols))
+	remaining := symbols
+	lastErr := NoProviderAvailable
+	for _, link := range c.links {
+		if len(remaining) == 0 {
+			break
+		}
+		name := link.provider.Name()
+		if !link.breaker.Allow() {
+			c.log.Debug().Msgf("Circuit breaker of %s is open. Skipping", name)
+			continue
+		}
+		batch, err := link.provider.GetHistoricDataForSymbols(ctx, remaining, params)
+		if ctx.Err() != nil {
+			link.breaker.Release()
+			return nil, ctx.Err()
+		}
+		if apiclient.IsTemporary(err) {
+			link.breaker.Release()
+			c.log.Info().Err(err).Msgf("Provider %s is temporary unavailable for %d symbols", name, len(remaining))
+			lastErr = err
+			continue
+		}
+		if err != nil && apiclient.IsProviderFailure(err) {
+			link.breaker.Failure()
+			c.log.Warn().Err(err).Msgf("Provider %s failed for %d symbols. Circuit breaker is %s", name, len(remaining), link.breaker.State())
+			lastErr = err
+			continue
+		}
+		link.breaker.Success()
+		if err != nil {
+			// error isn't caused by the provider, so other providers are not asked like for single symbol
+			if len(results) == 0 {
+				return nil, err
+			}
+			for _, symbol := range remaining {
+				results[symbol] = apiclient.SeriesResult{Err: err}
+			}
+			break
+		}
+		failed := make([]string, 0)
+		for _, symbol := range remaining {
+			result, ok := batch[symbol]
+			if !ok {
+				result = apiclient.SeriesResult{Err: model.SymbolNotFound}
+			}
+			if result.TimeSeries != nil {
+				result.TimeSeries.Meta.Provider = name
+			}
+			results[symbol] = result
+			if apiclient.IsProviderFailure(result.Err) {
+				failed = append(failed, symbol)
+			}
+		}
+		remaining = failed
+	}
+	if len(results) == 0 {
+		return nil, lastErr
+	}
+	return results, nil
+}
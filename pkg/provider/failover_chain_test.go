@@ -0,0 +1,195 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"github.com/galushkoart/finance-api/internal/model"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/apiclient"
+	"github.com/galushkoart/finance-api/pkg/ratelimit"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+var chainParams = apiclient.TimeSeriesParams{OutputSize: 1}
+
+func TestFailoverChain(t *testing.T) {
+	for _, tt := range failoverChainTests {
+		t.Run(tt.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+			primary := mock.NewMockTwelveDataClient(controller)
+			secondary := mock.NewMockTwelveDataClient(controller)
+			primary.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+			secondary.EXPECT().Name().Return(apiclient.AlphaVantageProvider).AnyTimes()
+			tt.mockBehavior(primary, secondary)
+			chain := NewFailoverChain(1, time.Minute, primary, secondary)
+			result, err := chain.GetHistoricDataForSymbol(context.TODO(), "AAPL", chainParams)
+			assert.Equal(t, tt.expected, result, "TimeSeries should be equal")
+			assert.ErrorIs(t, err, tt.expectedError, "Error should be equal")
+		})
+	}
+}
+
+func TestFailoverChainOpenBreaker(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	primary := mock.NewMockTwelveDataClient(controller)
+	secondary := mock.NewMockTwelveDataClient(controller)
+	primary.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+	secondary.EXPECT().Name().Return(apiclient.AlphaVantageProvider).AnyTimes()
+	primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(nil, apiclient.UnknownTwelveDataError).Times(1)
+	secondary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(&apiclient.TimeSeries{}, nil).Times(2)
+	chain := NewFailoverChain(1, time.Minute, primary, secondary)
+	for i := 0; i < 2; i++ {
+		result, err := chain.GetHistoricDataForSymbol(context.TODO(), "AAPL", chainParams)
+		assert.NoError(t, err, "Should be served by secondary provider")
+		assert.Equal(t, apiclient.AlphaVantageProvider, result.Meta.Provider, "Provider should be recorded")
+	}
+}
+
+func TestFailoverChainCancelledTrial(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	primary := mock.NewMockTwelveDataClient(controller)
+	secondary := mock.NewMockTwelveDataClient(controller)
+	primary.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+	secondary.EXPECT().Name().Return(apiclient.AlphaVantageProvider).AnyTimes()
+	chain := NewFailoverChain(1, 0, primary, secondary)
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	gomock.InOrder(
+		primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(nil, apiclient.UnknownTwelveDataError),
+		secondary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(&apiclient.TimeSeries{}, nil),
+		primary.EXPECT().GetHistoricDataForSymbol(cancelled, "AAPL", chainParams).Return(nil, context.Canceled),
+		primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(&apiclient.TimeSeries{}, nil),
+	)
+	_, err := chain.GetHistoricDataForSymbol(context.TODO(), "AAPL", chainParams)
+	assert.NoError(t, err, "Should be served by secondary provider")
+	_, err = chain.GetHistoricDataForSymbol(cancelled, "AAPL", chainParams)
+	assert.ErrorIs(t, err, context.Canceled, "Trial request should be cancelled")
+	result, err := chain.GetHistoricDataForSymbol(context.TODO(), "AAPL", chainParams)
+	assert.NoError(t, err, "Primary provider should get new trial request after cancelled one")
+	assert.Equal(t, apiclient.TwelveDataProvider, result.Meta.Provider, "Provider should be recorded")
+}
+
+type chainMockBehavior func(primary, secondary *mock.MockTwelveDataClient)
+
+var failoverChainTests = []struct {
+	name          string
+	mockBehavior  chainMockBehavior
+	expected      *apiclient.TimeSeries
+	expectedError error
+}{
+	{
+		utils.TestName("Primary provider"),
+		func(primary, secondary *mock.MockTwelveDataClient) {
+			primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(&apiclient.TimeSeries{Status: "ok"}, nil).Times(1)
+		},
+		&apiclient.TimeSeries{Meta: apiclient.Meta{Provider: apiclient.TwelveDataProvider}, Status: "ok"},
+		nil,
+	},
+	{
+		utils.TestName("Fallback on api key error"),
+		func(primary, secondary *mock.MockTwelveDataClient) {
+			primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(nil, &apiclient.TwelveDataApiKeyError{Err: errors.New("code: 429")}).Times(1)
+			secondary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(&apiclient.TimeSeries{Status: "ok"}, nil).Times(1)
+		},
+		&apiclient.TimeSeries{Meta: apiclient.Meta{Provider: apiclient.AlphaVantageProvider}, Status: "ok"},
+		nil,
+	},
+	{
+		utils.TestName("Fallback on unknown error"),
+		func(primary, secondary *mock.MockTwelveDataClient) {
+			primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(nil, apiclient.UnknownTwelveDataError).Times(1)
+			secondary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(&apiclient.TimeSeries{Status: "ok"}, nil).Times(1)
+		},
+		&apiclient.TimeSeries{Meta: apiclient.Meta{Provider: apiclient.AlphaVantageProvider}, Status: "ok"},
+		nil,
+	},
+	{
+		utils.TestName("Fallback on rate limit"),
+		func(primary, secondary *mock.MockTwelveDataClient) {
+			primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(nil, &ratelimit.LimitExceededError{Name: apiclient.TwelveDataProvider, RetryAfter: time.Minute}).Times(1)
+			secondary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(&apiclient.TimeSeries{Status: "ok"}, nil).Times(1)
+		},
+		&apiclient.TimeSeries{Meta: apiclient.Meta{Provider: apiclient.AlphaVantageProvider}, Status: "ok"},
+		nil,
+	},
+	{
+		utils.TestName("Symbol not found"),
+		func(primary, secondary *mock.MockTwelveDataClient) {
+			primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(nil, model.SymbolNotFound).Times(1)
+		},
+		nil,
+		model.SymbolNotFound,
+	},
+	{
+		utils.TestName("All providers failed"),
+		func(primary, secondary *mock.MockTwelveDataClient) {
+			primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(nil, apiclient.UnknownTwelveDataError).Times(1)
+			secondary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(nil, apiclient.UnknownAlphaVantageError).Times(1)
+		},
+		nil,
+		apiclient.UnknownAlphaVantageError,
+	},
+}
+
+func TestFailoverChainBatchMerging(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	primary := mock.NewMockTwelveDataClient(controller)
+	secondary := mock.NewMockTwelveDataClient(controller)
+	primary.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+	secondary.EXPECT().Name().Return(apiclient.AlphaVantageProvider).AnyTimes()
+	primary.EXPECT().GetHistoricDataForSymbols(gomock.Any(), []string{"AAPL", "MSFT", "INVALID"}, chainParams).Return(map[string]apiclient.SeriesResult{
+		"AAPL":    {TimeSeries: &apiclient.TimeSeries{Status: "ok"}},
+		"MSFT":    {Err: apiclient.UnknownTwelveDataError},
+		"INVALID": {Err: model.SymbolNotFound},
+	}, nil)
+	secondary.EXPECT().GetHistoricDataForSymbols(gomock.Any(), []string{"MSFT"}, chainParams).Return(map[string]apiclient.SeriesResult{
+		"MSFT": {TimeSeries: &apiclient.TimeSeries{Status: "ok"}},
+	}, nil)
+	chain := NewFailoverChain(1, time.Minute, primary, secondary)
+	results, err := chain.GetHistoricDataForSymbols(context.TODO(), []string{"AAPL", "MSFT", "INVALID"}, chainParams)
+	assert.NoError(t, err, "Batch should be resolved")
+	assert.Equal(t, map[string]apiclient.SeriesResult{
+		"AAPL":    {TimeSeries: &apiclient.TimeSeries{Meta: apiclient.Meta{Provider: apiclient.TwelveDataProvider}, Status: "ok"}},
+		"MSFT":    {TimeSeries: &apiclient.TimeSeries{Meta: apiclient.Meta{Provider: apiclient.AlphaVantageProvider}, Status: "ok"}},
+		"INVALID": {Err: model.SymbolNotFound},
+	}, results, "Results of providers should be merged")
+}
+
+func TestFailoverChainBatchFailed(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	primary := mock.NewMockTwelveDataClient(controller)
+	primary.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+	primary.EXPECT().GetHistoricDataForSymbols(gomock.Any(), []string{"AAPL", "MSFT"}, chainParams).Return(nil, apiclient.UnknownTwelveDataError)
+	chain := NewFailoverChain(1, time.Minute, primary)
+	results, err := chain.GetHistoricDataForSymbols(context.TODO(), []string{"AAPL", "MSFT"}, chainParams)
+	assert.Nil(t, results, "Results should be empty")
+	assert.ErrorIs(t, err, apiclient.UnknownTwelveDataError, "Error should be equal")
+}
+
+func TestFailoverChainBatchNotProviderError(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+	primary := mock.NewMockTwelveDataClient(controller)
+	secondary := mock.NewMockTwelveDataClient(controller)
+	primary.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+	secondary.EXPECT().Name().Return(apiclient.AlphaVantageProvider).AnyTimes()
+	decodeErr := errors.New("decode error")
+	primary.EXPECT().GetHistoricDataForSymbols(gomock.Any(), []string{"AAPL", "MSFT"}, chainParams).Return(nil, decodeErr)
+	primary.EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", chainParams).Return(&apiclient.TimeSeries{}, nil)
+	chain := NewFailoverChain(1, time.Minute, primary, secondary)
+	results, err := chain.GetHistoricDataForSymbols(context.TODO(), []string{"AAPL", "MSFT"}, chainParams)
+	assert.Nil(t, results, "Results should be empty")
+	assert.ErrorIs(t, err, decodeErr, "Error should be returned without asking other providers")
+	result, err := chain.GetHistoricDataForSymbol(context.TODO(), "AAPL", chainParams)
+	assert.NoError(t, err, "Breaker of primary provider should stay closed")
+	assert.Equal(t, apiclient.TwelveDataProvider, result.Meta.Provider, "Primary provider should be used")
+}
@@ -0,0 +1,232 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// LimitExceededError is returned when request can't be queued: the queue is full
+// or the wait for credits exceeds deadline of the request context
+type LimitExceededError struct {
+	Name       string
+	RetryAfter time.Duration
+}
+
+func (e *LimitExceededError) Error() string {
+	return fmt.Sprintf("rate limit of %s exceeded, retry after %s", e.Name, e.RetryAfter)
+}
+
+// Temporary marks error as retryable by other providers
+func (e *LimitExceededError) Temporary() bool {
+	return true
+}
+
+// Budget is number of credits available per minute and per day. Zero means unlimited
+type Budget struct {
+	PerMinute int
+	PerDay    int
+}
+
+// Stats of the limiter. Remaining credits are -1 for unlimited budget. Queued is the number of requests waiting at the moment,
+// other counters are accumulated since start
+type Stats struct {
+	Name               string     `json:"name"`
+	RemainingPerMinute int        `json:"remainingPerMinute"`
+	RemainingPerDay    int        `json:"remainingPerDay"`
+	Queued             int        `json:"queued"`
+	Acquired           int64      `json:"acquired"`
+	Delayed            int64      `json:"delayed"`
+	Rejected           int64      `json:"rejected"`
+	Cancelled          int64      `json:"cancelled"`
+	Keys               []KeyStats `json:"keys,omitempty"`
+}
+
+// KeyStats is remaining budget of a single api key. Key is masked
+type KeyStats struct {
+	Key                string    `json:"key"`
+	RemainingPerMinute int       `json:"remainingPerMinute"`
+	RemainingPerDay    int       `json:"remainingPerDay"`
+	DisabledUntil      time.Time `json:"disabledUntil,omitempty"`
+}
+
+// Reporter provides stats of rate limited resource
+type Reporter interface {
+	Stats() Stats
+}
+
+// bucket is refilled continuously with capacity tokens per period. Tokens may go negative for reserved credits
+type bucket struct {
+	capacity float64
+	tokens   float64
+	period   time.Duration
+	updated  time.Time
+}
+
+func newBucket(capacity int, period time.Duration, now time.Time) *bucket {
+	return &bucket{capacity: float64(capacity), tokens: float64(capacity), period: period, updated: now}
+}
+
+func (b *bucket) refill(now time.Time) {
+	elapsed := now.Sub(b.updated)
+	if elapsed <= 0 {
+		return
+	}
+	b.tokens = math.Min(b.capacity, b.tokens+b.capacity*float64(elapsed)/float64(b.period))
+	b.updated = now
+}
+
+// waitFor returns duration after which cost tokens are available
+func (b *bucket) waitFor(cost float64) time.Duration {
+	if b.tokens >= cost {
+		return 0
+	}
+	return time.Duration(math.Ceil((cost - b.tokens) / b.capacity * float64(b.period)))
+}
+
+func (b *bucket) remaining() int {
+	return int(math.Max(0, math.Floor(b.tokens)))
+}
+
+// Limiter is token bucket rate limiter with per-minute and per-day budgets.
+// Requests reserve credits in order of arrival and wait until credits are available or request context is done
+type Limiter struct {
+	mu        sync.Mutex
+	name      string
+	perMinute *bucket
+	perDay    *bucket
+	maxQueue  int
+	stats     Stats
+	now       func() time.Time
+}
+
+// NewLimiter creates limiter with budget and at most maxQueue waiting requests. Zero maxQueue means unlimited queue
+func NewLimiter(name string, budget Budget, maxQueue int) *Limiter {
+	l := &Limiter{name: name, maxQueue: maxQueue, now: time.Now}
+	now := l.now()
+	if budget.PerMinute > 0 {
+		l.perMinute = newBucket(budget.PerMinute, time.Minute, now)
+	}
+	if budget.PerDay > 0 {
+		l.perDay = newBucket(budget.PerDay, 24*time.Hour, now)
+	}
+	return l
+}
+
+func (l *Limiter) buckets() []*bucket {
+	result := make([]*bucket, 0, 2)
+	for _, b := range []*bucket{l.perMinute, l.perDay} {
+		if b != nil {
+			result = append(result, b)
+		}
+	}
+	return result
+}
+
+// TryAcquire takes cost credits if they are available now. Otherwise returns time to wait for them
+func (l *Limiter) TryAcquire(cost int) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	wait := l.refill(cost)
+	if wait > 0 {
+		return false, wait
+	}
+	l.take(float64(cost))
+	l.stats.Acquired++
+	return true, 0
+}
+
+// Drain spends remaining per-minute credits when provider reports exhausted budget before limiter does
+func (l *Limiter) Drain() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.perMinute != nil {
+		l.perMinute.refill(l.now())
+		l.perMinute.tokens = math.Min(0, l.perMinute.tokens)
+	}
+}
+
+// refill updates buckets and returns time to wait for cost credits
+func (l *Limiter) refill(cost int) time.Duration {
+	now := l.now()
+	var wait time.Duration
+	for _, b := range l.buckets() {
+		b.refill(now)
+		if w := b.waitFor(float64(cost)); w > wait {
+			wait = w
+		}
+	}
+	return wait
+}
+
+// Wait blocks until cost credits are available. Returns LimitExceededError without waiting
+// if the queue is full or credits won't be available before context deadline.
+// Context error is returned without taking credits if the context is already done
+func (l *Limiter) Wait(ctx context.Context, cost int) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	l.mu.Lock()
+	wait := l.refill(cost)
+	if wait > 0 {
+		deadline, hasDeadline := ctx.Deadline()
+		if (l.maxQueue > 0 && l.stats.Queued >= l.maxQueue) || (hasDeadline && l.now().Add(wait).After(deadline)) {
+			l.stats.Rejected++
+			l.mu.Unlock()
+			return &LimitExceededError{Name: l.name, RetryAfter: wait}
+		}
+	}
+	l.take(float64(cost))
+	if wait == 0 {
+		l.stats.Acquired++
+		l.mu.Unlock()
+		return nil
+	}
+	l.stats.Queued++
+	l.stats.Delayed++
+	l.mu.Unlock()
+
+	timer := time.NewTimer(wait)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		l.mu.Lock()
+		l.stats.Queued--
+		l.stats.Acquired++
+		l.mu.Unlock()
+		return nil
+	case <-ctx.Done():
+		l.mu.Lock()
+		l.stats.Queued--
+		l.stats.Cancelled++
+		l.take(-float64(cost))
+		l.mu.Unlock()
+		return ctx.Err()
+	}
+}
+
+func (l *Limiter) take(cost float64) {
+	for _, b := range l.buckets() {
+		b.tokens = math.Min(b.capacity, b.tokens-cost)
+	}
+}
+
+func (l *Limiter) Stats() Stats {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	now := l.now()
+	stats := l.stats
+	stats.Name = l.name
+	stats.RemainingPerMinute, stats.RemainingPerDay = -1, -1
+	if l.perMinute != nil {
+		l.perMinute.refill(now)
+		stats.RemainingPerMinute = l.perMinute.remaining()
+	}
+	if l.perDay != nil {
+		l.perDay.refill(now)
+		stats.RemainingPerDay = l.perDay.remaining()
+	}
+	return stats
+}
@@ -0,0 +1,83 @@
+package ratelimit
+
+import (
+	"context"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestLimiterAcquire(t *testing.T) {
+	limiter := NewLimiter("test", Budget{PerMinute: 2, PerDay: 10}, 1)
+	assert.NoError(t, limiter.Wait(context.TODO(), 1), "First credit should be acquired")
+	assert.NoError(t, limiter.Wait(context.TODO(), 1), "Second credit should be acquired")
+	stats := limiter.Stats()
+	assert.Equal(t, Stats{Name: "test", RemainingPerMinute: 0, RemainingPerDay: 8, Acquired: 2}, stats, "Stats should be equal")
+}
+
+func TestLimiterCancelledContext(t *testing.T) {
+	limiter := NewLimiter("test", Budget{PerMinute: 2}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.ErrorIs(t, limiter.Wait(ctx, 1), context.Canceled, "Cancelled request should not wait")
+	assert.Equal(t, Stats{Name: "test", RemainingPerMinute: 2, RemainingPerDay: -1}, limiter.Stats(), "Cancelled request should not take credits")
+}
+
+func TestLimiterRefill(t *testing.T) {
+	now := time.Date(2023, 6, 2, 12, 0, 0, 0, time.UTC)
+	limiter := NewLimiter("test", Budget{PerMinute: 6}, 0)
+	limiter.now = func() time.Time { return now }
+	limiter.perMinute.updated = now
+	for i := 0; i < 6; i++ {
+		assert.NoError(t, limiter.Wait(context.TODO(), 1), "Credit should be acquired")
+	}
+	now = now.Add(20 * time.Second)
+	assert.Equal(t, 2, limiter.Stats().RemainingPerMinute, "Bucket should be refilled proportionally")
+	assert.Equal(t, -1, limiter.Stats().RemainingPerDay, "Unlimited budget should be reported as -1")
+}
+
+func TestLimiterQueueFull(t *testing.T) {
+	limiter := NewLimiter("test", Budget{PerMinute: 1}, 1)
+	assert.NoError(t, limiter.Wait(context.TODO(), 1), "Credit should be acquired")
+	ctx, cancel := context.WithCancel(context.Background())
+	waited := make(chan error)
+	go func() {
+		waited <- limiter.Wait(ctx, 1)
+	}()
+	assert.Eventually(t, func() bool { return limiter.Stats().Queued == 1 }, time.Second, time.Millisecond, "Request should be queued")
+
+	err := limiter.Wait(context.TODO(), 1)
+	var limitErr *LimitExceededError
+	assert.ErrorAs(t, err, &limitErr, "Request should be rejected on full queue")
+	assert.Greater(t, limitErr.RetryAfter, time.Minute, "Retry after should include queued credits")
+
+	cancel()
+	assert.ErrorIs(t, <-waited, context.Canceled, "Queued request should be cancelled")
+	stats := limiter.Stats()
+	assert.Equal(t, 0, stats.Queued, "Queue should be empty")
+	assert.Equal(t, int64(1), stats.Rejected, "Rejected requests should be counted")
+	assert.Equal(t, int64(1), stats.Cancelled, "Cancelled requests should be counted")
+	assert.Equal(t, int64(1), stats.Delayed, "Delayed requests should be counted")
+}
+
+func TestLimiterDeadline(t *testing.T) {
+	limiter := NewLimiter("test", Budget{PerDay: 1}, 0)
+	assert.NoError(t, limiter.Wait(context.TODO(), 1), "Credit should be acquired")
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	start := time.Now()
+	err := limiter.Wait(ctx, 1)
+	var limitErr *LimitExceededError
+	assert.ErrorAs(t, err, &limitErr, "Request should be rejected when credits are not available before deadline")
+	assert.Less(t, time.Since(start), 100*time.Millisecond, "Request should be rejected without waiting")
+}
+
+func TestLimiterWait(t *testing.T) {
+	limiter := NewLimiter("test", Budget{PerMinute: 1200}, 0)
+	for i := 0; i < 1200; i++ {
+		assert.NoError(t, limiter.Wait(context.TODO(), 1), "Credit should be acquired")
+	}
+	start := time.Now()
+	assert.NoError(t, limiter.Wait(context.TODO(), 2), "Credits should be acquired after wait")
+	assert.GreaterOrEqual(t, time.Since(start), 80*time.Millisecond, "Request should wait for credits")
+}
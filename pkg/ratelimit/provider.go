@@ -0,0 +1,252 @@
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"github.com/galushkoart/finance-api/pkg/apiclient"
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+	"math"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// invalidKeyTimeout is the time api key rejected by provider is not used
+const invalidKeyTimeout = time.Hour
+
+type apiKey struct {
+	label         string
+	client        apiclient.MarketDataProvider
+	limiter       *Limiter
+	mu            sync.Mutex
+	disabledUntil time.Time
+}
+
+func (k *apiKey) disable(until time.Time) {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	k.disabledUntil = until
+}
+
+func (k *apiKey) disabledFor(now time.Time) time.Duration {
+	k.mu.Lock()
+	defer k.mu.Unlock()
+	return k.disabledUntil.Sub(now)
+}
+
+// Provider limits requests to the market data provider. Every api key has its own budget
+// and keys are rotated on each request. Exhausted or invalid keys are skipped
+type Provider struct {
+	name     string
+	keys     []*apiKey
+	credits  map[string]int
+	budget   Budget
+	next     atomic.Uint32
+	rejected atomic.Int64
+	wg       sync.WaitGroup
+	log      zerolog.Logger
+	now      func() time.Time
+}
+
+// NewProvider creates client for every api key with newClient. Credits are charged per symbol for each endpoint,
+// endpoints missing in credits cost a single credit
+func NewProvider(apiKeys []string, newClient func(apiKey string) apiclient.MarketDataProvider, credits map[string]int, budget Budget, maxQueue int) *Provider {
+	if len(apiKeys) == 0 {
+		apiKeys = []string{""}
+	}
+	p := &Provider{credits: credits, budget: budget, now: time.Now}
+	for _, key := range apiKeys {
+		client := newClient(key)
+		p.name = client.Name()
+		p.keys = append(p.keys, &apiKey{label: maskKey(key), client: client, limiter: NewLimiter(client.Name(), budget, maxQueue)})
+	}
+	p.log = log.With().Str("from", "rateLimitedProvider").Str("provider", p.name).Logger()
+	p.log.Info().Msgf("Rate limiter initialized for %d keys with %d credits per minute and %d per day", len(p.keys), budget.PerMinute, budget.PerDay)
+	return p
+}
+
+func maskKey(key string) string {
+	if len(key) <= 4 {
+		return "****"
+	}
+	return "****" + key[len(key)-4:]
+}
+
+func (p *Provider) Name() string {
+	return p.name
+}
+
+// Stats sums counters and remaining credits of all keys
+func (p *Provider) Stats() Stats {
+	result := Stats{Name: p.name, Rejected: p.rejected.Load(), Keys: make([]KeyStats, 0, len(p.keys))}
+	now := p.now()
+	for _, key := range p.keys {
+		stats := key.limiter.Stats()
+		keyStats := KeyStats{Key: key.label, RemainingPerMinute: stats.RemainingPerMinute, RemainingPerDay: stats.RemainingPerDay}
+		if disabledFor := key.disabledFor(now); disabledFor > 0 {
+			keyStats.DisabledUntil = now.Add(disabledFor)
+		} else {
+			result.RemainingPerMinute = addRemaining(result.RemainingPerMinute, stats.RemainingPerMinute)
+			result.RemainingPerDay = addRemaining(result.RemainingPerDay, stats.RemainingPerDay)
+		}
+		result.Keys = append(result.Keys, keyStats)
+		result.Queued += stats.Queued
+		result.Acquired += stats.Acquired
+		result.Delayed += stats.Delayed
+		result.Rejected += stats.Rejected
+		result.Cancelled += stats.Cancelled
+	}
+	return result
+}
+
+func addRemaining(total int, remaining int) int {
+	if total < 0 || remaining < 0 {
+		return -1
+	}
+	return total + remaining
+}
+
+// Stop waits for running requests to finish
+func (p *Provider) Stop() {
+	p.wg.Wait()
+}
+
+func (p *Provider) cost(endpoint string, symbols int) int {
+	credits, ok := p.credits[endpoint]
+	if !ok {
+		credits = 1
+	}
+	return credits * symbols
+}
+
+func (p *Provider) GetHistoricDataForSymbol(ctx context.Context, symbol string, params apiclient.TimeSeriesParams) (*apiclient.TimeSeries, error) {
+	return call(ctx, p, p.cost(apiclient.TimeSeriesEndpoint, 1), func(client apiclient.MarketDataProvider) (*apiclient.TimeSeries, error) {
+		return client.GetHistoricDataForSymbol(ctx, symbol, params)
+	})
+}
+
+// GetHistoricDataForSymbols splits symbols into batches which fit into per-minute budget of a single key.
+// Failure of a batch is returned for its symbols. Error is returned only if all batches failed
+func (p *Provider) GetHistoricDataForSymbols(ctx context.Context, symbols []string, params apiclient.TimeSeriesParams) (map[string]apiclient.SeriesResult, error) {
+	results := make(map[string]apiclient.SeriesResult, len(symbols))
+	var lastErr error
+	succeeded := false
+	for _, batch := range p.batches(symbols) {
+		batchResults, err := call(ctx, p, p.cost(apiclient.TimeSeriesEndpoint, len(batch)), func(client apiclient.MarketDataProvider) (map[string]apiclient.SeriesResult, error) {
+			return client.GetHistoricDataForSymbols(ctx, batch, params)
+		})
+		if err != nil {
+			lastErr = err
+			for _, symbol := range batch {
+				results[symbol] = apiclient.SeriesResult{Err: err}
+			}
+			continue
+		}
+		succeeded = true
+		for symbol, result := range batchResults {
+			results[symbol] = result
+		}
+	}
+	if !succeeded {
+		return nil, lastErr
+	}
+	return results, nil
+}
+
+// SearchSymbols searches symbols with the provider if its client supports search
+func (p *Provider) SearchSymbols(ctx context.Context, query string, outputSize int) ([]apiclient.SymbolSearchResult, error) {
+	if _, ok := p.keys[0].client.(apiclient.SymbolSearcher); !ok {
+		return nil, fmt.Errorf("%s doesn't support symbol search", p.name)
+	}
+	return call(ctx, p, p.cost(apiclient.SymbolSearchEndpoint, 1), func(client apiclient.MarketDataProvider) ([]apiclient.SymbolSearchResult, error) {
+		return client.(apiclient.SymbolSearcher).SearchSymbols(ctx, query, outputSize)
+	})
+}
+
+// batches splits symbols into parts costing no more than per-minute budget of a key
+func (p *Provider) batches(symbols []string) [][]string {
+	size := len(symbols)
+	if p.budget.PerMinute > 0 {
+		size = p.budget.PerMinute / p.cost(apiclient.TimeSeriesEndpoint, 1)
+	}
+	if size < 1 {
+		size = 1
+	}
+	result := make([][]string, 0, len(symbols)/size+1)
+	for start := 0; start < len(symbols); start += size {
+		end := start + size
+		if end > len(symbols) {
+			end = len(symbols)
+		}
+		result = append(result, symbols[start:end])
+	}
+	return result
+}
+
+// call sends request with the key which has credits. Request is retried with another key
+// if provider rejects the key as invalid or exhausted
+func call[T any](ctx context.Context, p *Provider, cost int, request func(client apiclient.MarketDataProvider) (T, error)) (T, error) {
+	tried := make(map[*apiKey]bool, len(p.keys))
+	var result T
+	var err error
+	for len(tried) < len(p.keys) {
+		var key *apiKey
+		key, err = p.acquire(ctx, cost, tried)
+		if err != nil {
+			return result, err
+		}
+		tried[key] = true
+		p.wg.Add(1)
+		result, err = request(key.client)
+		p.wg.Done()
+		if !apiclient.IsApiKeyError(err) {
+			return result, err
+		}
+		if apiclient.IsInvalidApiKey(err) {
+			p.log.Error().Err(err).Msgf("Api key %s is invalid. Disabling it for %s", key.label, invalidKeyTimeout)
+			key.disable(p.now().Add(invalidKeyTimeout))
+		} else {
+			p.log.Warn().Err(err).Msgf("Api key %s is exhausted. Rotating", key.label)
+			key.limiter.Drain()
+		}
+	}
+	return result, err
+}
+
+// acquire takes credits from the first key which has them now starting from the next key in rotation.
+// If all keys are exhausted waits for the key which is restored first
+func (p *Provider) acquire(ctx context.Context, cost int, tried map[*apiKey]bool) (*apiKey, error) {
+	start := int(p.next.Add(1) - 1)
+	now := p.now()
+	var candidate *apiKey
+	minWait := time.Duration(math.MaxInt64)
+	retryAfter := time.Duration(math.MaxInt64)
+	for i := range p.keys {
+		key := p.keys[(start+i)%len(p.keys)]
+		if tried[key] {
+			continue
+		}
+		if disabledFor := key.disabledFor(now); disabledFor > 0 {
+			if disabledFor < retryAfter {
+				retryAfter = disabledFor
+			}
+			continue
+		}
+		acquired, wait := key.limiter.TryAcquire(cost)
+		if acquired {
+			return key, nil
+		}
+		if wait < minWait {
+			candidate, minWait = key, wait
+		}
+	}
+	if candidate == nil {
+		p.rejected.Add(1)
+		return nil, &LimitExceededError{Name: p.name, RetryAfter: retryAfter}
+	}
+	if err := candidate.limiter.Wait(ctx, cost); err != nil {
+		return nil, err
+	}
+	return candidate, nil
+}
@@ -0,0 +1,114 @@
+package ratelimit
+
+import (
+	"context"
+	"errors"
+	"github.com/galushkoart/finance-api/mock"
+	"github.com/galushkoart/finance-api/pkg/apiclient"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+//go:generate echo $PWD - $GOFILE
+//go:generate mockgen -package mock -destination ../../mock/twelve_data_client_mock.go -source=../apiclient/twelve_data_client.go TwelveDataClient
+
+func newMockProvider(t *testing.T, apiKeys []string, budget Budget) (*Provider, map[string]*mock.MockTwelveDataClient) {
+	controller := gomock.NewController(t)
+	t.Cleanup(controller.Finish)
+	clients := make(map[string]*mock.MockTwelveDataClient)
+	provider := NewProvider(apiKeys, func(apiKey string) apiclient.MarketDataProvider {
+		client := mock.NewMockTwelveDataClient(controller)
+		client.EXPECT().Name().Return(apiclient.TwelveDataProvider).AnyTimes()
+		clients[apiKey] = client
+		return client
+	}, apiclient.TwelveDataCredits, budget, 1)
+	return provider, clients
+}
+
+func TestProvider(t *testing.T) {
+	provider, clients := newMockProvider(t, []string{"key1"}, Budget{PerMinute: 10})
+	clients["key1"].EXPECT().GetHistoricDataForSymbol(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, symbol string, params apiclient.TimeSeriesParams) (*apiclient.TimeSeries, error) {
+		return &apiclient.TimeSeries{Meta: apiclient.Meta{Symbol: symbol}}, nil
+	}).Times(10)
+	wg := sync.WaitGroup{}
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		symbolId := strconv.Itoa(i)
+		go func() {
+			defer wg.Done()
+			symbol, err := provider.GetHistoricDataForSymbol(context.TODO(), symbolId, apiclient.TimeSeriesParams{})
+			assert.NoError(t, err, "Request within budget should succeed")
+			assert.Equal(t, symbolId, symbol.Meta.Symbol, "Symbol should be equal")
+		}()
+	}
+	wg.Wait()
+	provider.Stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := provider.GetHistoricDataForSymbol(ctx, "TEST", apiclient.TimeSeriesParams{})
+	assert.ErrorIs(t, err, context.Canceled, "Cancelled request should not reach provider")
+	stats := provider.Stats()
+	assert.Equal(t, apiclient.TwelveDataProvider, stats.Name, "Name should be equal")
+	assert.Equal(t, int64(10), stats.Acquired, "Acquired requests should be counted")
+	assert.Equal(t, []KeyStats{{Key: "****", RemainingPerMinute: 0, RemainingPerDay: -1}}, stats.Keys, "Key stats should be equal")
+}
+
+func TestProviderKeyRotation(t *testing.T) {
+	provider, clients := newMockProvider(t, []string{"first-key", "second-key"}, Budget{PerMinute: 1, PerDay: 100})
+	clients["first-key"].EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", gomock.Any()).Return(&apiclient.TimeSeries{Status: "ok"}, nil).Times(1)
+	clients["second-key"].EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", gomock.Any()).Return(&apiclient.TimeSeries{Status: "ok"}, nil).Times(1)
+	for i := 0; i < 2; i++ {
+		_, err := provider.GetHistoricDataForSymbol(context.TODO(), "AAPL", apiclient.TimeSeriesParams{})
+		assert.NoError(t, err, "Request should be served by one of the keys")
+	}
+	stats := provider.Stats()
+	assert.Equal(t, 0, stats.RemainingPerMinute, "Both keys should be exhausted")
+	assert.Equal(t, 198, stats.RemainingPerDay, "Daily budgets of keys should be summed")
+	assert.Len(t, stats.Keys, 2, "Every key should be reported")
+}
+
+func TestProviderInvalidKey(t *testing.T) {
+	provider, clients := newMockProvider(t, []string{"invalid-key", "valid-key"}, Budget{PerMinute: 10})
+	invalidKeyError := &apiclient.TwelveDataApiKeyError{Err: errors.New("message: API key is invalid"), Code: 401}
+	clients["invalid-key"].EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", gomock.Any()).Return(nil, invalidKeyError).Times(1)
+	clients["valid-key"].EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", gomock.Any()).Return(&apiclient.TimeSeries{Status: "ok"}, nil).Times(2)
+	for i := 0; i < 2; i++ {
+		_, err := provider.GetHistoricDataForSymbol(context.TODO(), "AAPL", apiclient.TimeSeriesParams{})
+		assert.NoError(t, err, "Request should be served by valid key")
+	}
+	stats := provider.Stats()
+	assert.False(t, stats.Keys[0].DisabledUntil.IsZero(), "Invalid key should be disabled")
+	assert.True(t, stats.Keys[1].DisabledUntil.IsZero(), "Valid key should be enabled")
+}
+
+func TestProviderExhaustedKeys(t *testing.T) {
+	provider, clients := newMockProvider(t, []string{"first-key", "second-key"}, Budget{PerMinute: 10})
+	exhaustedError := &apiclient.TwelveDataApiKeyError{Err: errors.New("message: Overuse"), Code: 429}
+	clients["first-key"].EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", gomock.Any()).Return(nil, exhaustedError).Times(1)
+	clients["second-key"].EXPECT().GetHistoricDataForSymbol(gomock.Any(), "AAPL", gomock.Any()).Return(nil, exhaustedError).Times(1)
+	_, err := provider.GetHistoricDataForSymbol(context.TODO(), "AAPL", apiclient.TimeSeriesParams{})
+	assert.ErrorIs(t, err, exhaustedError, "Error of the last key should be returned")
+	stats := provider.Stats()
+	assert.Equal(t, 0, stats.RemainingPerMinute, "Exhausted keys should be drained")
+}
+
+func TestProviderBatches(t *testing.T) {
+	provider, clients := newMockProvider(t, []string{"key1"}, Budget{PerMinute: 2})
+	provider.keys[0].limiter = NewLimiter("test", Budget{}, 0)
+	first := clients["key1"].EXPECT().GetHistoricDataForSymbols(gomock.Any(), []string{"AAPL", "MSFT"}, gomock.Any()).Return(map[string]apiclient.SeriesResult{
+		"AAPL": {TimeSeries: &apiclient.TimeSeries{Status: "ok"}},
+		"MSFT": {TimeSeries: &apiclient.TimeSeries{Status: "ok"}},
+	}, nil)
+	clients["key1"].EXPECT().GetHistoricDataForSymbols(gomock.Any(), []string{"IBM"}, gomock.Any()).Return(nil, apiclient.UnknownTwelveDataError).After(first)
+	results, err := provider.GetHistoricDataForSymbols(context.TODO(), []string{"AAPL", "MSFT", "IBM"}, apiclient.TimeSeriesParams{})
+	assert.NoError(t, err, "Error should be returned only if all batches failed")
+	assert.Equal(t, map[string]apiclient.SeriesResult{
+		"AAPL": {TimeSeries: &apiclient.TimeSeries{Status: "ok"}},
+		"MSFT": {TimeSeries: &apiclient.TimeSeries{Status: "ok"}},
+		"IBM":  {Err: apiclient.UnknownTwelveDataError},
+	}, results, "Results should be equal")
+}
@@ -0,0 +1,126 @@
+package service
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"github.com/galushkoart/finance-api/pkg/utils"
+	"github.com/rs/zerolog/log"
+	"net"
+	"net/smtp"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Notifier sends a message to the user by email address
+type Notifier interface {
+	Notify(ctx context.Context, to string, subject string, body string) error
+}
+
+type SMTPNotifier struct {
+	addr    string
+	host    string
+	from    string
+	auth    smtp.Auth
+	timeout time.Duration
+}
+
+// NewSMTPNotifier creates notifier sending plain text emails. Auth is skipped if username is empty.
+// Sending a message including connection is aborted after timeout
+func NewSMTPNotifier(host string, port int, username string, password string, from string, timeout time.Duration) *SMTPNotifier {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+	return &SMTPNotifier{addr: net.JoinHostPort(host, strconv.Itoa(port)), host: host, from: from, auth: auth, timeout: timeout}
+}
+
+func (n *SMTPNotifier) Notify(ctx context.Context, to string, subject string, body string) error {
+	if strings.ContainsAny(to+subject, "\r\n") {
+		return fmt.Errorf("header of message to %s contains line break", to)
+	}
+	msg := fmt.Sprintf("From: %s\r\nTo: %s\r\nSubject: %s\r\nDate: %s\r\nMIME-Version: 1.0\r\nContent-Type: text/plain; charset=UTF-8\r\n\r\n%s\r\n",
+		n.from, to, subject, time.Now().Format(time.RFC1123Z), body)
+	err := n.send(ctx, to, []byte(msg))
+	if err != nil {
+		utils.LogRequest(ctx, log.Error()).Str("from", "smtpNotifier").Err(err).Msgf("Failed to send %s message", subject)
+	}
+	return err
+}
+
+// send does the same as smtp.SendMail, but the whole conversation is bound by timeout and context
+func (n *SMTPNotifier) send(ctx context.Context, to string, msg []byte) error {
+	ctx, cancel := context.WithTimeout(ctx, n.timeout)
+	defer cancel()
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", n.addr)
+	if err != nil {
+		return err
+	}
+	deadline, _ := ctx.Deadline()
+	if err = conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+	client, err := smtp.NewClient(conn, n.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err = client.StartTLS(&tls.Config{ServerName: n.host}); err != nil {
+			return err
+		}
+	}
+	if ok, _ := client.Extension("AUTH"); ok && n.auth != nil {
+		if err = client.Auth(n.auth); err != nil {
+			return err
+		}
+	}
+	if err = client.Mail(n.from); err != nil {
+		return err
+	}
+	if err = client.Rcpt(to); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err = writer.Write(msg); err != nil {
+		return err
+	}
+	if err = writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// FileNotifier appends messages to the file instead of sending them. It is meant for local development
+type FileNotifier struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileNotifier(path string) *FileNotifier {
+	return &FileNotifier{path: path}
+}
+
+func (n *FileNotifier) Notify(ctx context.Context, to string, subject string, body string) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	file, err := os.OpenFile(n.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+	_, err = fmt.Fprintf(file, "%s\nTo: %s\nSubject: %s\n\n%s\n\n", time.Now().UTC().Format(time.RFC3339), to, subject, body)
+	if err == nil {
+		utils.LogRequest(ctx, log.Info()).Str("from", "fileNotifier").Msgf("%s message to %s is written to %s", subject, to, n.path)
+	}
+	return err
+}
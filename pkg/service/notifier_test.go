@@ -0,0 +1,107 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSMTPNotifier(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err, "Test server should listen")
+	defer listener.Close()
+	received := make(chan string, 1)
+	go serveSMTP(listener, received)
+	port := listener.Addr().(*net.TCPAddr).Port
+	notifier := NewSMTPNotifier("127.0.0.1", port, "", "", "no-reply@test.com", time.Second)
+
+	err = notifier.Notify(context.TODO(), "user@test.com", "Password reset", "Reset link")
+	assert.NoError(t, err, "Message should be sent")
+	conversation := <-received
+	assert.Contains(t, conversation, "MAIL FROM:<no-reply@test.com>", "Message should be sent from configured address")
+	assert.Contains(t, conversation, "RCPT TO:<user@test.com>", "Message should be sent to the user")
+	assert.Contains(t, conversation, "Subject: Password reset\r\n", "Message should contain subject")
+	assert.Contains(t, conversation, "\r\n\r\nReset link\r\n.\r\n", "Message should end with body")
+
+	err = notifier.Notify(context.TODO(), "user@test.com\r\nBcc: other@test.com", "Password reset", "Reset link")
+	assert.Error(t, err, "Header injection should be rejected")
+}
+
+func TestSMTPNotifierTimeout(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err, "Test server should listen")
+	defer listener.Close()
+	go func() {
+		// server accepts connection but never greets
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			_, _ = io.Copy(io.Discard, conn)
+		}
+	}()
+	port := listener.Addr().(*net.TCPAddr).Port
+	notifier := NewSMTPNotifier("127.0.0.1", port, "", "", "no-reply@test.com", 50*time.Millisecond)
+	start := time.Now()
+	err = notifier.Notify(context.TODO(), "user@test.com", "Password reset", "Reset link")
+	assert.Error(t, err, "Stalled server should fail the message")
+	assert.Less(t, time.Since(start), time.Second, "Stalled server should not block longer than timeout")
+}
+
+// serveSMTP accepts single connection, replies to the client commands and sends received lines to the channel
+func serveSMTP(listener net.Listener, received chan<- string) {
+	conn, err := listener.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+	var conversation strings.Builder
+	reply := func(line string) { _, _ = fmt.Fprintf(conn, "%s\r\n", line) }
+	reply("220 test.com ESMTP")
+	data := false
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			break
+		}
+		conversation.WriteString(line)
+		switch {
+		case data:
+			if line == ".\r\n" {
+				data = false
+				reply("250 OK")
+			}
+		case strings.HasPrefix(line, "EHLO"):
+			reply("250 test.com")
+		case strings.HasPrefix(line, "DATA"):
+			data = true
+			reply("354 Start mail input")
+		case strings.HasPrefix(line, "QUIT"):
+			reply("221 Bye")
+			received <- conversation.String()
+			return
+		default:
+			reply("250 OK")
+		}
+	}
+	received <- conversation.String()
+}
+
+func TestFileNotifier(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notifications.txt")
+	notifier := NewFileNotifier(path)
+	assert.NoError(t, notifier.Notify(context.TODO(), "first@test.com", "Password reset", "First link"))
+	assert.NoError(t, notifier.Notify(context.TODO(), "second@test.com", "Password reset", "Second link"))
+	content, err := os.ReadFile(path)
+	assert.NoError(t, err, "Messages should be written to the file")
+	assert.Contains(t, string(content), "To: first@test.com\nSubject: Password reset\n\nFirst link\n", "First message should be written")
+	assert.Contains(t, string(content), "To: second@test.com\nSubject: Password reset\n\nSecond link\n", "Second message should be appended")
+}
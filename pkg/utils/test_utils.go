@@ -105,7 +105,19 @@ func CommonResponseAssertions(t *testing.T, response *http.Response, err error,
 	assert.JSONEq(t, string(expectedJson), string(responseJson))
 }
 
+// testRolePermissions mirrors permissions of roles in role_permissions table
+var testRolePermissions = map[model.Role][]model.Permission{
+	model.ClientRole: {model.SymbolsRead, model.ExchangesRead, model.AnalyticsRead},
+	model.AdminRole: {model.SymbolsRead, model.SymbolsWrite, model.ExchangesRead, model.ExchangesWrite, model.AnalyticsRead,
+		model.ProvidersRead, model.UsersAdmin},
+}
+
+// TestAuthMiddleware authenticates request with role from "Role" header. Client role is used if header is empty
 func TestAuthMiddleware(c *fiber.Ctx) error {
-	c.Locals("role", model.Role(c.GetReqHeaders()["Role"]))
+	role := model.Role(c.GetReqHeaders()["Role"])
+	if role == "" {
+		role = model.ClientRole
+	}
+	c.Locals("principal", model.Principal{ID: c.GetReqHeaders()["User-Id"], Roles: []model.Role{role}, Permissions: testRolePermissions[role]})
 	return c.Next()
 }